@@ -0,0 +1,264 @@
+package apispec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// EmbeddedSpec is the LoadSpec location naming the spec bundle embedded in
+// this binary, without any spec files from disk.
+const EmbeddedSpec = "embedded"
+
+// The kinds of Change reported by Diff.
+const (
+	ChangeParameterAdded       = "parameter-added"
+	ChangeParameterRemoved     = "parameter-removed"
+	ChangeParameterRequired    = "parameter-required"
+	ChangeParameterOptional    = "parameter-optional"
+	ChangePathParameterRenamed = "path-parameter-renamed"
+	ChangeRequestBodyAdded     = "request-body-added"
+	ChangeRequestBodyRemoved   = "request-body-removed"
+	ChangeRequestBodyRequired  = "request-body-required"
+	ChangeRequestBodyOptional  = "request-body-optional"
+	ChangePropertyAdded        = "property-added"
+	ChangePropertyRemoved      = "property-removed"
+	ChangePropertyRequired     = "property-required"
+	ChangePropertyOptional     = "property-optional"
+	ChangePropertyTypeModified = "property-type-changed"
+)
+
+// OperationRef identifies an operation added or removed between two specs.
+type OperationRef struct {
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	Summary string `json:"summary,omitempty"`
+}
+
+// Change is a single difference in an operation's request between two
+// specs. Name is the parameter ("in:name") or request-body property (a
+// dotted path for nested fields) the change applies to. Breaking is set when
+// a request that was valid against the old spec may be rejected, or behave
+// differently, against the new one.
+type Change struct {
+	Kind     string `json:"kind"`
+	Name     string `json:"name,omitempty"`
+	Detail   string `json:"detail"`
+	Breaking bool   `json:"breaking"`
+}
+
+// OperationDiff lists the changes of an operation present in both specs.
+type OperationDiff struct {
+	Method  string   `json:"method"`
+	Path    string   `json:"path"`
+	Changes []Change `json:"changes"`
+}
+
+// SpecDiff is the result of Diff. A removed operation is always breaking.
+type SpecDiff struct {
+	Added   []OperationRef  `json:"added"`
+	Removed []OperationRef  `json:"removed"`
+	Changed []OperationDiff `json:"changed"`
+}
+
+// BreakingChanges counts the removed operations and breaking changes.
+func (d SpecDiff) BreakingChanges() int {
+	count := len(d.Removed)
+	for _, op := range d.Changed {
+		for _, change := range op.Changes {
+			if change.Breaking {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// LoadSpec parses the operations of a single spec: EmbeddedSpec for the
+// embedded bundle, or a spec file or directory of spec files on disk. Unlike
+// Operations, a file that fails to parse is an error rather than skipped, as
+// its operations would otherwise be reported as removed.
+func LoadSpec(location string) ([]Operation, error) {
+	if location == EmbeddedSpec {
+		ops, _, failures, err := parseDir(specFS, rootDir)
+		return ops, firstFailure(err, failures)
+	}
+	info, err := os.Stat(location)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		ops, _, failures, err := parseDir(os.DirFS(location), ".")
+		return ops, firstFailure(err, failures)
+	}
+	ops, _, err := parseFile(os.DirFS(filepath.Dir(location)), filepath.Base(location))
+	if err != nil {
+		return nil, fmt.Errorf("apispec: %s: %w", location, err)
+	}
+	return mergeOperations(nil, ops), nil
+}
+
+func firstFailure(err error, failures []FileError) error {
+	if err != nil || len(failures) == 0 {
+		return err
+	}
+	return fmt.Errorf("apispec: %w", failures[0])
+}
+
+// pathParamPattern matches a {placeholder} of an operation path.
+var pathParamPattern = regexp.MustCompile(`\{[^{}/]*\}`)
+
+// Diff compares the operations of two specs, matched by method and path,
+// regardless of the names of the path placeholders. Only what a client sends
+// is compared: parameters and request-body properties, but not responses or
+// descriptions.
+func Diff(before, after []Operation) SpecDiff {
+	key := func(o Operation) string { return o.Method + " " + pathParamPattern.ReplaceAllString(o.Path, "{}") }
+	old := make(map[string]Operation, len(before))
+	for _, op := range before {
+		old[key(op)] = op
+	}
+	seen := make(map[string]bool, len(after))
+	diff := SpecDiff{Added: []OperationRef{}, Removed: []OperationRef{}, Changed: []OperationDiff{}}
+	for _, op := range after {
+		seen[key(op)] = true
+		prev, ok := old[key(op)]
+		if !ok {
+			diff.Added = append(diff.Added, OperationRef{Method: op.Method, Path: op.Path, Summary: op.Summary})
+			continue
+		}
+		params, changes := renamePathParameters(prev, op.Path)
+		changes = append(changes, diffParameters(params, op.Parameters)...)
+		changes = append(changes, diffRequestBody(prev.RequestBody, op.RequestBody)...)
+		if len(changes) > 0 {
+			diff.Changed = append(diff.Changed, OperationDiff{Method: op.Method, Path: op.Path, Changes: changes})
+		}
+	}
+	for _, op := range before {
+		if !seen[key(op)] {
+			diff.Removed = append(diff.Removed, OperationRef{Method: op.Method, Path: op.Path, Summary: op.Summary})
+		}
+	}
+	return diff
+}
+
+// renamePathParameters renames the path parameters of prev after the
+// placeholders of path, which only differs from prev.Path by their names, and
+// reports each rename. A renamed placeholder doesn't change the requests
+// clients send, so it isn't breaking.
+func renamePathParameters(prev Operation, path string) ([]Parameter, []Change) {
+	oldNames := pathParamPattern.FindAllString(prev.Path, -1)
+	newNames := pathParamPattern.FindAllString(path, -1)
+	renames := make(map[string]string)
+	var changes []Change
+	for i := range oldNames {
+		oldName, newName := strings.Trim(oldNames[i], "{}"), strings.Trim(newNames[i], "{}")
+		if oldName == newName {
+			continue
+		}
+		renames[oldName] = newName
+		changes = append(changes, Change{Kind: ChangePathParameterRenamed, Name: "path:" + newName, Detail: "path parameter renamed from " + oldName})
+	}
+	if len(renames) == 0 {
+		return prev.Parameters, nil
+	}
+	params := make([]Parameter, len(prev.Parameters))
+	for i, p := range prev.Parameters {
+		if newName, ok := renames[p.Name]; ok && p.In == "path" {
+			p.Name = newName
+		}
+		params[i] = p
+	}
+	return params, changes
+}
+
+func diffParameters(before, after []Parameter) []Change {
+	key := func(p Parameter) string { return p.In + ":" + p.Name }
+	old := make(map[string]Parameter, len(before))
+	for _, p := range before {
+		old[key(p)] = p
+	}
+	var changes []Change
+	for _, p := range after {
+		prev, ok := old[key(p)]
+		delete(old, key(p))
+		switch {
+		case !ok && p.Required:
+			changes = append(changes, Change{Kind: ChangeParameterAdded, Name: key(p), Detail: "new required parameter", Breaking: true})
+		case !ok:
+			changes = append(changes, Change{Kind: ChangeParameterAdded, Name: key(p), Detail: "new optional parameter"})
+		case !prev.Required && p.Required:
+			changes = append(changes, Change{Kind: ChangeParameterRequired, Name: key(p), Detail: "parameter is now required", Breaking: true})
+		case prev.Required && !p.Required:
+			changes = append(changes, Change{Kind: ChangeParameterOptional, Name: key(p), Detail: "parameter is now optional"})
+		}
+	}
+	for _, p := range before {
+		if _, ok := old[key(p)]; ok {
+			changes = append(changes, Change{Kind: ChangeParameterRemoved, Name: key(p), Detail: "parameter removed", Breaking: true})
+		}
+	}
+	return changes
+}
+
+func diffRequestBody(before, after *RequestBody) []Change {
+	switch {
+	case before == nil && after == nil:
+		return nil
+	case before == nil:
+		return []Change{{Kind: ChangeRequestBodyAdded, Detail: "new request body", Breaking: after.Required}}
+	case after == nil:
+		return []Change{{Kind: ChangeRequestBodyRemoved, Detail: "request body removed", Breaking: true}}
+	}
+	var changes []Change
+	if !before.Required && after.Required {
+		changes = append(changes, Change{Kind: ChangeRequestBodyRequired, Detail: "request body is now required", Breaking: true})
+	} else if before.Required && !after.Required {
+		changes = append(changes, Change{Kind: ChangeRequestBodyOptional, Detail: "request body is now optional"})
+	}
+	return append(changes, diffProperties("", before.Properties, after.Properties)...)
+}
+
+// diffProperties compares two levels of request-body properties, recursing
+// into the fields of properties present on both sides.
+func diffProperties(prefix string, before, after []Property) []Change {
+	old := make(map[string]Property, len(before))
+	for _, p := range before {
+		old[p.Name] = p
+	}
+	var changes []Change
+	for _, p := range after {
+		name := prefix + p.Name
+		prev, ok := old[p.Name]
+		delete(old, p.Name)
+		switch {
+		case !ok && p.Required:
+			changes = append(changes, Change{Kind: ChangePropertyAdded, Name: name, Detail: "new required property", Breaking: true})
+			continue
+		case !ok:
+			changes = append(changes, Change{Kind: ChangePropertyAdded, Name: name, Detail: "new optional property"})
+			continue
+		case !prev.Required && p.Required:
+			changes = append(changes, Change{Kind: ChangePropertyRequired, Name: name, Detail: "property is now required", Breaking: true})
+		case prev.Required && !p.Required:
+			changes = append(changes, Change{Kind: ChangePropertyOptional, Name: name, Detail: "property is now optional"})
+		}
+		if prev.Type != p.Type {
+			changes = append(changes, Change{Kind: ChangePropertyTypeModified, Name: name, Detail: fmt.Sprintf("type changed from %s to %s", prev.Type, p.Type), Breaking: true})
+			continue
+		}
+		changes = append(changes, diffProperties(name+".", prev.Properties, p.Properties)...)
+	}
+	removed := make([]string, 0, len(old))
+	for name := range old {
+		removed = append(removed, name)
+	}
+	sort.Strings(removed)
+	for _, name := range removed {
+		changes = append(changes, Change{Kind: ChangePropertyRemoved, Name: prefix + name, Detail: "property removed", Breaking: true})
+	}
+	return changes
+}
@@ -0,0 +1,116 @@
+package apispec
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff_AddedRemovedChanged(t *testing.T) {
+	before := []Operation{
+		{Method: "GET", Path: "/gone", Summary: "Gone"},
+		{Method: "GET", Path: "/same", Parameters: []Parameter{{Name: "q", In: "query"}}},
+		{Method: "POST", Path: "/users",
+			Parameters: []Parameter{{Name: "dryRun", In: "query"}, {Name: "legacy", In: "header"}},
+			RequestBody: &RequestBody{Properties: []Property{
+				{Name: "name", Type: "string", Required: true},
+				{Name: "age", Type: "integer"},
+				{Name: "nickname", Type: "string"},
+				{Name: "address", Type: "Address", Properties: []Property{{Name: "city", Type: "string"}}},
+			}},
+		},
+	}
+	after := []Operation{
+		{Method: "GET", Path: "/new", Summary: "New"},
+		{Method: "GET", Path: "/same", Parameters: []Parameter{{Name: "q", In: "query"}}},
+		{Method: "POST", Path: "/users",
+			Parameters: []Parameter{{Name: "dryRun", In: "query", Required: true}, {Name: "page", In: "query"}},
+			RequestBody: &RequestBody{Required: true, Properties: []Property{
+				{Name: "name", Type: "string", Required: true},
+				{Name: "age", Type: "string"},
+				{Name: "email", Type: "string", Required: true},
+				{Name: "address", Type: "Address", Properties: []Property{{Name: "city", Type: "string", Required: true}}},
+			}},
+		},
+	}
+
+	diff := Diff(before, after)
+	assert.Equal(t, []OperationRef{{Method: "GET", Path: "/new", Summary: "New"}}, diff.Added)
+	assert.Equal(t, []OperationRef{{Method: "GET", Path: "/gone", Summary: "Gone"}}, diff.Removed)
+	require.Len(t, diff.Changed, 1, "/same is unchanged")
+	assert.Equal(t, "POST", diff.Changed[0].Method)
+	assert.Equal(t, []Change{
+		{Kind: ChangeParameterRequired, Name: "query:dryRun", Detail: "parameter is now required", Breaking: true},
+		{Kind: ChangeParameterAdded, Name: "query:page", Detail: "new optional parameter"},
+		{Kind: ChangeParameterRemoved, Name: "header:legacy", Detail: "parameter removed", Breaking: true},
+		{Kind: ChangeRequestBodyRequired, Detail: "request body is now required", Breaking: true},
+		{Kind: ChangePropertyTypeModified, Name: "age", Detail: "type changed from integer to string", Breaking: true},
+		{Kind: ChangePropertyAdded, Name: "email", Detail: "new required property", Breaking: true},
+		{Kind: ChangePropertyRequired, Name: "address.city", Detail: "property is now required", Breaking: true},
+		{Kind: ChangePropertyRemoved, Name: "nickname", Detail: "property removed", Breaking: true},
+	}, diff.Changed[0].Changes)
+	assert.Equal(t, 8, diff.BreakingChanges())
+}
+
+func TestDiff_NonBreaking(t *testing.T) {
+	before := []Operation{{Method: "POST", Path: "/a", RequestBody: &RequestBody{Required: true}}}
+	after := []Operation{
+		{Method: "POST", Path: "/a", RequestBody: &RequestBody{Properties: []Property{{Name: "note", Type: "string"}}}},
+		{Method: "GET", Path: "/b"},
+	}
+	diff := Diff(before, after)
+	assert.Len(t, diff.Added, 1)
+	require.Len(t, diff.Changed, 1)
+	assert.Len(t, diff.Changed[0].Changes, 2)
+	assert.Zero(t, diff.BreakingChanges())
+}
+
+func TestDiff_RenamedPathParameter(t *testing.T) {
+	before := []Operation{{Method: "GET", Path: "/repositories/{repoKey}/items/{path}", Parameters: []Parameter{
+		{Name: "repoKey", In: "path", Required: true},
+		{Name: "path", In: "path", Required: true},
+		{Name: "repoKey", In: "query"},
+	}}}
+	after := []Operation{
+		{Method: "GET", Path: "/repositories/{key}/items/{path}", Parameters: []Parameter{
+			{Name: "key", In: "path", Required: true},
+			{Name: "path", In: "path", Required: true},
+			{Name: "repoKey", In: "query"},
+		}},
+		{Method: "DELETE", Path: "/repositories/{key}/items/{path}"},
+	}
+
+	diff := Diff(before, after)
+	assert.Equal(t, []OperationRef{{Method: "DELETE", Path: "/repositories/{key}/items/{path}"}}, diff.Added)
+	assert.Empty(t, diff.Removed)
+	assert.Equal(t, []OperationDiff{{Method: "GET", Path: "/repositories/{key}/items/{path}", Changes: []Change{
+		{Kind: ChangePathParameterRenamed, Name: "path:key", Detail: "path parameter renamed from repoKey"},
+	}}}, diff.Changed)
+	assert.Zero(t, diff.BreakingChanges())
+}
+
+func TestLoadSpec(t *testing.T) {
+	dir := t.TempDir()
+	writeSpec(t, dir, "platform.yaml", widgetsSpec)
+
+	fromDir, err := LoadSpec(dir)
+	require.NoError(t, err)
+	fromFile, err := LoadSpec(filepath.Join(dir, "platform.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, fromDir, fromFile)
+	require.Len(t, fromFile, 2)
+	assert.Equal(t, "/gadgets", fromFile[0].Path, "operations are sorted by path")
+
+	embedded, err := LoadSpec(EmbeddedSpec)
+	require.NoError(t, err)
+	assert.NotEmpty(t, embedded)
+
+	writeSpec(t, dir, "broken.yaml", "paths: [")
+	_, err = LoadSpec(dir)
+	assert.ErrorContains(t, err, "broken.yaml", "a file that fails to parse must not read as removed operations")
+
+	_, err = LoadSpec(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,73 @@
+package apispec
+
+import "encoding/json"
+
+// Example payload kinds, as reported by RequestBody.Payload.
+const (
+	ExampleDeclared    = "declared"
+	ExampleSynthesized = "synthesized"
+)
+
+// Payload returns the request body example to show or send: the spec's
+// declared Example when there is one, or else the SynthesizedExample, along
+// with which of the two it is. It returns nil and "" when there's neither.
+func (rb *RequestBody) Payload() (json.RawMessage, string) {
+	switch {
+	case rb == nil:
+		return nil, ""
+	case len(rb.Example) > 0:
+		return rb.Example, ExampleDeclared
+	case len(rb.SynthesizedExample) > 0:
+		return rb.SynthesizedExample, ExampleSynthesized
+	}
+	return nil, ""
+}
+
+// synthesizeExample generates the smallest payload schema accepts: an object
+// holding only its required properties, recursively, each set to its default,
+// its first enum value or a placeholder for its type. It returns nil when the
+// schema has no required properties, since an empty object isn't worth
+// showing as an example.
+func (r schemaResolver) synthesizeExample(schema rawSchema) json.RawMessage {
+	value, ok := r.exampleValue(schema, nil).(map[string]any)
+	if !ok || len(value) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (r schemaResolver) exampleValue(schema rawSchema, expanding []string) any {
+	schema, expanding, ref := r.resolve(schema, expanding)
+	switch {
+	case schema.Default != nil:
+		return schema.Default
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	}
+	switch schema.Type {
+	case "string":
+		return ""
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	case "array":
+		return []any{}
+	}
+	// An object, or an untyped schema. Its nested objects are filled in down
+	// to the same depth, and with the same cycle detection, as Property.
+	object := map[string]any{}
+	if ref != "" || len(expanding) > maxSchemaDepth {
+		return object
+	}
+	for _, name := range schema.Required {
+		if prop, ok := schema.Properties[name]; ok {
+			object[name] = r.exampleValue(prop, expanding)
+		}
+	}
+	return object
+}
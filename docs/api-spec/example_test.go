@@ -0,0 +1,107 @@
+package apispec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSynthesizeExample(t *testing.T) {
+	d := parseRawDoc(t, `
+components:
+  schemas:
+    Repo:
+      type: object
+      required: [key, type, layout, replicas, owner, tags, node]
+      properties:
+        key:
+          type: string
+        description:
+          type: string
+        type:
+          $ref: '#/components/schemas/RepoType'
+        layout:
+          type: string
+          default: maven-2-default
+        replicas:
+          type: integer
+        owner:
+          type: object
+          required: [name, admin]
+          properties:
+            name:
+              type: string
+            admin:
+              type: boolean
+            email:
+              type: string
+        tags:
+          type: array
+          items:
+            type: string
+        node:
+          $ref: '#/components/schemas/Node'
+    RepoType:
+      type: string
+      enum: [local, remote, virtual]
+    Node:
+      type: object
+      required: [parent]
+      properties:
+        parent:
+          $ref: '#/components/schemas/Node'
+`)
+	raw := &rawRequestBody{Content: map[string]rawMediaTypeItem{
+		"application/json": {Schema: rawSchema{Ref: "#/components/schemas/Repo"}},
+	}}
+	rb := buildRequestBody(raw, d.Components.Schemas)
+	require.NotNil(t, rb)
+	assert.Nil(t, rb.Example)
+	assert.JSONEq(t, `{
+		"key": "",
+		"type": "local",
+		"layout": "maven-2-default",
+		"replicas": 0,
+		"owner": {"name": "", "admin": false},
+		"tags": [],
+		"node": {"parent": {}}
+	}`, string(rb.SynthesizedExample))
+
+	payload, kind := rb.Payload()
+	assert.Equal(t, ExampleSynthesized, kind)
+	assert.Equal(t, rb.SynthesizedExample, payload)
+}
+
+func TestSynthesizeExample_NoneWithoutRequiredFields(t *testing.T) {
+	raw := &rawRequestBody{Content: map[string]rawMediaTypeItem{
+		"application/json": {Schema: rawSchema{Type: "object", Properties: map[string]rawSchema{"name": {Type: "string"}}}},
+	}}
+	rb := buildRequestBody(raw, nil)
+	require.NotNil(t, rb)
+	assert.Nil(t, rb.SynthesizedExample)
+	payload, kind := rb.Payload()
+	assert.Nil(t, payload)
+	assert.Empty(t, kind)
+}
+
+func TestRequestBodyPayload_PrefersDeclaredExample(t *testing.T) {
+	raw := &rawRequestBody{Content: map[string]rawMediaTypeItem{
+		"application/json": {
+			Schema:  rawSchema{Type: "object", Required: []string{"name"}, Properties: map[string]rawSchema{"name": {Type: "string"}}},
+			Example: map[string]any{"name": "my-repo"},
+		},
+	}}
+	rb := buildRequestBody(raw, nil)
+	require.NotNil(t, rb)
+	assert.Nil(t, rb.SynthesizedExample, "nothing is synthesized when the spec declares an example")
+	payload, kind := rb.Payload()
+	assert.Equal(t, ExampleDeclared, kind)
+	assert.Equal(t, json.RawMessage(`{"name":"my-repo"}`), payload)
+
+	var nilBody *RequestBody
+	payload, kind = nilBody.Payload()
+	assert.Nil(t, payload)
+	assert.Empty(t, kind)
+}
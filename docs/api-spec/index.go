@@ -0,0 +1,345 @@
+package apispec
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// The fields of an operation indexed for search, each scored with its own
+// boost (see Index.Search).
+const (
+	FieldOperationId = "operation_id"
+	FieldPath        = "path"
+	FieldSummary     = "summary"
+	FieldTag         = "tag"
+	FieldDescription = "description"
+	FieldParameter   = "parameter"
+	FieldProperty    = "property"
+	FieldResponse    = "response"
+)
+
+// indexedFields lists the Field constants, in the order their scores add up.
+var indexedFields = []string{FieldOperationId, FieldPath, FieldSummary, FieldTag, FieldDescription, FieldParameter, FieldProperty, FieldResponse}
+
+const (
+	// bm25K1 saturates a term's frequency within a field, and bm25B sets how
+	// much a field longer than average is penalized -- the usual defaults.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+	// A query term also matches the indexed terms it's a prefix of ("perm"
+	// matches "permission"), at a discount, so that a partial word still finds
+	// something as the old substring search did.
+	prefixMatchFactor = 0.5
+	minPrefixLen      = 3
+)
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "be": true, "by": true,
+	"for": true, "from": true, "in": true, "is": true, "it": true, "of": true, "on": true,
+	"or": true, "the": true, "this": true, "to": true, "with": true,
+}
+
+// Index is a BM25F inverted index over the textual fields of a set of
+// operations: operationId, path, summary, tags, description, parameter
+// names and descriptions, request-body property names and descriptions, and
+// response descriptions and properties. Terms are split on camelCase and
+// punctuation, lowercased and stemmed.
+type Index struct {
+	ops      []Operation
+	docs     []indexedDoc
+	postings map[string][]int
+	avgLen   map[string]float64
+	// terms is every indexed term, sorted, for prefix matching.
+	terms []string
+}
+
+type indexedDoc struct {
+	// tf maps a field to the frequency of each of its terms.
+	tf     map[string]map[string]int
+	length map[string]int
+}
+
+// Hit is an operation matching an Index.Search query, with its score and the
+// contribution of each query term and field to it, highest first.
+type Hit struct {
+	Operation Operation
+	Score     float64
+	Terms     []TermScore
+}
+
+// TermScore is the contribution of a query term matched in a field. Matched
+// is the indexed term it matched: its stem, or a longer term it's a prefix
+// of.
+type TermScore struct {
+	Term    string  `json:"term"`
+	Matched string  `json:"matched,omitempty"`
+	Field   string  `json:"field"`
+	Score   float64 `json:"score"`
+}
+
+var (
+	indexOnce   sync.Once
+	searchIndex *Index
+)
+
+// SearchIndex returns the index of the catalog's Operations, built on first
+// use and cached for the rest of the process like the catalog itself.
+func SearchIndex() (*Index, error) {
+	ops, err := Operations()
+	if err != nil {
+		return nil, err
+	}
+	indexOnce.Do(func() {
+		searchIndex = NewIndex(ops)
+	})
+	return searchIndex, nil
+}
+
+// NewIndex indexes ops.
+func NewIndex(ops []Operation) *Index {
+	ix := &Index{ops: ops, docs: make([]indexedDoc, len(ops)), postings: make(map[string][]int), avgLen: make(map[string]float64)}
+	for i, op := range ops {
+		doc := indexedDoc{tf: make(map[string]map[string]int), length: make(map[string]int)}
+		for field, texts := range operationFields(op) {
+			for _, text := range texts {
+				for _, token := range tokenize(text) {
+					if doc.tf[field] == nil {
+						doc.tf[field] = make(map[string]int)
+					}
+					if !doc.has(token.stem) {
+						ix.postings[token.stem] = append(ix.postings[token.stem], i)
+					}
+					doc.tf[field][token.stem]++
+					doc.length[field]++
+				}
+			}
+		}
+		for field, length := range doc.length {
+			ix.avgLen[field] += float64(length)
+		}
+		ix.docs[i] = doc
+	}
+	for field := range ix.avgLen {
+		ix.avgLen[field] /= float64(max(len(ops), 1))
+	}
+	ix.terms = make([]string, 0, len(ix.postings))
+	for term := range ix.postings {
+		ix.terms = append(ix.terms, term)
+	}
+	sort.Strings(ix.terms)
+	return ix
+}
+
+func (d indexedDoc) has(term string) bool {
+	for _, tf := range d.tf {
+		if tf[term] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Operations returns the operations the index was built from.
+func (ix *Index) Operations() []Operation {
+	return ix.ops
+}
+
+// Search scores every operation matching at least one term of query, with
+// boosts weighing each field's BM25 score (a field without a boost isn't
+// searched), and returns the hits best-first, then by path and method.
+func (ix *Index) Search(query string, boosts map[string]float64) []Hit {
+	hits := make(map[int]*Hit)
+	seen := make(map[string]bool)
+	for _, token := range tokenize(query) {
+		if seen[token.stem] {
+			continue
+		}
+		seen[token.stem] = true
+		for _, m := range ix.expand(token.stem) {
+			matched, idf := m.term, ix.idf(m.term)
+			for _, i := range ix.postings[matched] {
+				doc := ix.docs[i]
+				for _, field := range indexedFields {
+					freq := float64(doc.tf[field][matched])
+					if freq == 0 || boosts[field] == 0 {
+						continue
+					}
+					norm := 1 - bm25B + bm25B*float64(doc.length[field])/ix.avgLen[field]
+					score := m.factor * boosts[field] * idf * freq * (bm25K1 + 1) / (freq + bm25K1*norm)
+					hit := hits[i]
+					if hit == nil {
+						hit = &Hit{Operation: ix.ops[i]}
+						hits[i] = hit
+					}
+					hit.Score += score
+					hit.Terms = append(hit.Terms, TermScore{Term: token.word, Matched: matched, Field: field, Score: math.Round(score*100) / 100})
+				}
+			}
+		}
+	}
+
+	result := make([]Hit, 0, len(hits))
+	for _, hit := range hits {
+		sort.Slice(hit.Terms, func(i, j int) bool {
+			if hit.Terms[i].Score != hit.Terms[j].Score {
+				return hit.Terms[i].Score > hit.Terms[j].Score
+			}
+			return hit.Terms[i].Field < hit.Terms[j].Field
+		})
+		result = append(result, *hit)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		if result[i].Operation.Path != result[j].Operation.Path {
+			return result[i].Operation.Path < result[j].Operation.Path
+		}
+		return result[i].Operation.Method < result[j].Operation.Method
+	})
+	return result
+}
+
+// termMatch is an indexed term matched by a query term, and the factor the
+// match's score is multiplied by.
+type termMatch struct {
+	term   string
+	factor float64
+}
+
+// expand returns the indexed terms stem matches, in order.
+func (ix *Index) expand(stem string) []termMatch {
+	var matched []termMatch
+	if _, ok := ix.postings[stem]; ok {
+		matched = append(matched, termMatch{term: stem, factor: 1})
+	}
+	if len(stem) < minPrefixLen {
+		return matched
+	}
+	for i := sort.SearchStrings(ix.terms, stem); i < len(ix.terms) && strings.HasPrefix(ix.terms[i], stem); i++ {
+		if ix.terms[i] != stem {
+			matched = append(matched, termMatch{term: ix.terms[i], factor: prefixMatchFactor})
+		}
+	}
+	return matched
+}
+
+// idf is BM25's inverse document frequency, in its always-positive form.
+func (ix *Index) idf(term string) float64 {
+	n, df := float64(len(ix.docs)), float64(len(ix.postings[term]))
+	return math.Log(1 + (n-df+0.5)/(df+0.5))
+}
+
+// operationFields collects the text of each indexed field of op.
+func operationFields(op Operation) map[string][]string {
+	fields := map[string][]string{
+		FieldOperationId: {op.OperationId},
+		FieldPath:        {op.Path},
+		FieldSummary:     {op.Summary},
+		FieldTag:         op.Tags,
+		FieldDescription: {op.Description},
+	}
+	for _, p := range op.Parameters {
+		fields[FieldParameter] = append(fields[FieldParameter], p.Name, p.Description)
+	}
+	if op.RequestBody != nil {
+		fields[FieldProperty] = appendPropertyText(fields[FieldProperty], op.RequestBody.Properties)
+	}
+	for _, r := range op.Responses {
+		fields[FieldResponse] = appendPropertyText(append(fields[FieldResponse], r.Description), r.Properties)
+	}
+	return fields
+}
+
+func appendPropertyText(texts []string, properties []Property) []string {
+	for _, p := range properties {
+		texts = appendPropertyText(append(texts, p.Name, p.Description), p.Properties)
+	}
+	return texts
+}
+
+// token is a word of an indexed or query text, and the term it's indexed
+// under.
+type token struct {
+	word string
+	stem string
+}
+
+// tokenize splits text into lowercased, stemmed words on anything other than
+// letters and digits, and splits each word on its camelCase boundaries too,
+// keeping the whole word ("userName" yields "user", "name" and "usernam").
+// Stop words and single characters are dropped.
+func tokenize(text string) []token {
+	var tokens []token
+	add := func(word string) {
+		word = strings.ToLower(word)
+		if len(word) < 2 || stopWords[word] {
+			return
+		}
+		tokens = append(tokens, token{word: word, stem: stem(word)})
+	}
+	words := strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	for _, word := range words {
+		parts := splitCamelCase(word)
+		for _, part := range parts {
+			add(part)
+		}
+		if len(parts) > 1 {
+			add(word)
+		}
+	}
+	return tokens
+}
+
+// splitCamelCase splits "getUserList" into "get", "User" and "List", and
+// "APIKey" into "API" and "Key".
+func splitCamelCase(word string) []string {
+	runes := []rune(word)
+	var parts []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			continue
+		}
+		if unicode.IsLower(runes[i-1]) || (unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			parts = append(parts, string(runes[start:i]))
+			start = i
+		}
+	}
+	return append(parts, string(runes[start:]))
+}
+
+// stem is a light suffix-stripping stemmer, enough to conflate the plural,
+// past and gerund forms found in API docs: "policies" and "policy",
+// "scheduled", "scheduling" and "schedules", "tagging" and "tags".
+func stem(word string) string {
+	if len(word) <= 3 {
+		return word
+	}
+	switch {
+	case strings.HasSuffix(word, "sses"):
+		word = word[:len(word)-2]
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		word = word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		word = word[:len(word)-1]
+	}
+	for _, suffix := range []string{"ing", "ed"} {
+		base, ok := strings.CutSuffix(word, suffix)
+		if !ok || len(base) < 3 || !strings.ContainsAny(base, "aeiouy") {
+			continue
+		}
+		word = base
+		if n := len(word); n >= 4 && word[n-1] == word[n-2] && !strings.ContainsRune("aeioulsz", rune(word[n-1])) {
+			word = word[:n-1]
+		}
+		break
+	}
+	if len(word) > 4 && strings.HasSuffix(word, "e") {
+		word = word[:len(word)-1]
+	}
+	return word
+}
@@ -0,0 +1,78 @@
+package apispec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStem(t *testing.T) {
+	for word, want := range map[string]string{
+		"users":      "user",
+		"user":       "user",
+		"policies":   "policy",
+		"scheduled":  "schedul",
+		"scheduling": "schedul",
+		"schedules":  "schedul",
+		"tagging":    "tag",
+		"tags":       "tag",
+		"added":      "add",
+		"status":     "status",
+		"process":    "process",
+		"string":     "string",
+		"api":        "api",
+	} {
+		assert.Equal(t, want, stem(word), word)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	var stems []string
+	for _, tok := range tokenize("getUserAPIKeys for the /access/api/v2/users path") {
+		stems = append(stems, tok.stem)
+	}
+	assert.Equal(t, []string{"get", "user", "api", "key", "getuserapikey", "access", "api", "v2", "user", "path"}, stems)
+}
+
+func TestIndexSearch(t *testing.T) {
+	ops := []Operation{
+		{Method: "GET", Path: "/users", OperationId: "getUsers", Summary: "List users"},
+		{Method: "GET", Path: "/groups", Summary: "List groups", Parameters: []Parameter{{Name: "includeUsers", In: "query"}}},
+		{Method: "GET", Path: "/tokens", Responses: []Response{{Code: "200", Description: "The tokens", Properties: []Property{{Name: "expiresIn"}}}}},
+	}
+	index := NewIndex(ops)
+	boosts := map[string]float64{FieldOperationId: 40, FieldPath: 30, FieldSummary: 20, FieldParameter: 8, FieldResponse: 4}
+
+	hits := index.Search("users", boosts)
+	require.Len(t, hits, 2)
+	assert.Equal(t, "/users", hits[0].Operation.Path)
+	assert.Equal(t, "/groups", hits[1].Operation.Path, "a parameter name is searchable")
+	assert.Greater(t, hits[0].Score, hits[1].Score)
+	require.Len(t, hits[0].Terms, 3, "operationId, path and summary")
+	for i, term := range hits[0].Terms {
+		assert.Equal(t, "users", term.Term)
+		assert.Equal(t, "user", term.Matched)
+		if i > 0 {
+			assert.GreaterOrEqual(t, hits[0].Terms[i-1].Score, term.Score, "terms are explained highest first")
+		}
+	}
+
+	hits = index.Search("expires", boosts)
+	require.Len(t, hits, 1)
+	assert.Equal(t, FieldResponse, hits[0].Terms[0].Field)
+
+	assert.Empty(t, index.Search("expires", map[string]float64{FieldPath: 1}), "a field without a boost isn't searched")
+	assert.Empty(t, index.Search("the", boosts), "stop words are dropped")
+}
+
+func TestSearchIndex_CachesTheCatalog(t *testing.T) {
+	first, err := SearchIndex()
+	require.NoError(t, err)
+	second, err := SearchIndex()
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	ops, err := Operations()
+	require.NoError(t, err)
+	assert.Len(t, first.Operations(), len(ops))
+}
@@ -1,12 +1,14 @@
 // Package apispec exposes the operations declared in jfrog-cli's embedded
 // OpenAPI spec bundle (a small "stub" set by default, or the real "full" set
-// in JFrog's internal release build — see docs/api-spec/).
+// in JFrog's internal release build — see docs/api-spec/), merged with any
+// spec files found on disk (see sources.go).
 package apispec
 
 import (
 	"encoding/json"
 	"fmt"
 	"io/fs"
+	"path"
 	"sort"
 	"strings"
 	"sync"
@@ -28,34 +30,54 @@ type Parameter struct {
 	Description string `yaml:"description" json:"description,omitempty"`
 }
 
-// Property describes a single top-level field of an operation's JSON request
-// body. Type is either a JSON-schema primitive ("string", "boolean",
+// Property describes a single field of an operation's JSON request or
+// response body. Type is either a JSON-schema primitive ("string", "boolean",
 // "integer", "object", ...), "array<item-type>" for arrays, or the name of a
 // referenced component schema (e.g. "BuildTarget") when the property itself
-// is a $ref -- that nested schema's own fields are not flattened further.
+// is a $ref.
+//
+// Properties holds the fields of a nested object (or of an array's object
+// items), resolved through $refs down to maxSchemaDepth levels. A field whose
+// schema isn't expanded -- because it refers back to a schema being expanded
+// above it, or because the depth limit was reached -- carries that schema's
+// $ref instead, as a pointer to where its fields are described.
 type Property struct {
-	Name        string `json:"name"`
-	Type        string `json:"type"`
-	Required    bool   `json:"required"`
-	Description string `json:"description,omitempty"`
-	Default     string `json:"default,omitempty"`
+	Name        string     `json:"name"`
+	Type        string     `json:"type"`
+	Required    bool       `json:"required"`
+	Description string     `json:"description,omitempty"`
+	Default     string     `json:"default,omitempty"`
+	Properties  []Property `json:"properties,omitempty"`
+	Ref         string     `json:"$ref,omitempty"`
 }
 
 // RequestBody describes an operation's JSON request body payload.
 type RequestBody struct {
-	Required   bool       `json:"required"`
+	Required bool `json:"required"`
+	// Schema is the type hint of the body, like a Property's Type:
+	// "array<Widget>" for an array, whose items' fields are then listed in
+	// Properties.
+	Schema     string     `json:"schema,omitempty"`
 	Properties []Property `json:"properties,omitempty"`
 	// Example is the operation's declared requestBody.content.application/json.example,
 	// verbatim, when the spec provides one. yaml.v3 decodes YAML mappings into
 	// map[string]interface{} (not v2's map[interface{}]interface{}), so this
 	// round-trips through encoding/json without a custom converter.
 	Example json.RawMessage `json:"example,omitempty"`
+	// SynthesizedExample is a minimal payload generated from the schema (see
+	// synthesizeExample) when the spec declares no Example.
+	SynthesizedExample json.RawMessage `json:"synthesized_example,omitempty"`
 }
 
 // Response describes a single declared HTTP response for an operation.
+// Schema and Properties describe its application/json body, when declared:
+// Schema is the body's type hint (see Property.Type), and Properties the
+// fields of the body object, or of its items for an array body.
 type Response struct {
-	Code        string `json:"code"`
-	Description string `json:"description,omitempty"`
+	Code        string     `json:"code"`
+	Description string     `json:"description,omitempty"`
+	Schema      string     `json:"schema,omitempty"`
+	Properties  []Property `json:"properties,omitempty"`
 }
 
 // Operation describes a single OpenAPI path+method operation.
@@ -63,6 +85,7 @@ type Operation struct {
 	Method      string
 	Path        string
 	Summary     string
+	Description string
 	Tags        []string
 	OperationId string
 	Parameters  []Parameter
@@ -74,13 +97,19 @@ type Operation struct {
 	Responses []Response
 }
 
-// Metadata describes which spec bundle is embedded in this binary.
+// Metadata describes which spec bundle is embedded in this binary, and every
+// source the catalog was loaded from.
 type Metadata struct {
 	SpecBundle  string
 	SpecVersion string
+	Sources     []Source
 }
 
 type rawDoc struct {
+	Info struct {
+		Version string `yaml:"version"`
+	} `yaml:"info"`
+	Tags       []Tag                           `yaml:"tags"`
 	Paths      map[string]map[string]yaml.Node `yaml:"paths"`
 	Components struct {
 		Schemas map[string]rawSchema `yaml:"schemas"`
@@ -89,6 +118,7 @@ type rawDoc struct {
 
 type rawOperation struct {
 	Summary     string                 `yaml:"summary"`
+	Description string                 `yaml:"description"`
 	OperationId string                 `yaml:"operationId"`
 	Tags        []string               `yaml:"tags"`
 	Parameters  []Parameter            `yaml:"parameters"`
@@ -97,7 +127,8 @@ type rawOperation struct {
 }
 
 type rawResponse struct {
-	Description string `yaml:"description"`
+	Description string                      `yaml:"description"`
+	Content     map[string]rawMediaTypeItem `yaml:"content"`
 }
 
 type rawRequestBody struct {
@@ -111,16 +142,15 @@ type rawMediaTypeItem struct {
 }
 
 // rawSchema is a deliberately narrow subset of OpenAPI's Schema Object: only
-// what's needed to flatten a request body's top-level properties into
-// Property. Nested object properties are identified by referenced schema name
-// (see Property) rather than recursively flattened, to keep output compact
-// and sidestep any $ref cycles in the full (non-stub) bundle.
+// what's needed to resolve a body's properties into a Property tree (see
+// schemaResolver).
 type rawSchema struct {
 	Ref         string               `yaml:"$ref"`
 	Type        flexType             `yaml:"type"`
 	Description string               `yaml:"description"`
 	Default     any                  `yaml:"default"`
 	Required    []string             `yaml:"required"`
+	Enum        []any                `yaml:"enum"`
 	Properties  map[string]rawSchema `yaml:"properties"`
 	Items       *rawSchema           `yaml:"items"`
 }
@@ -174,17 +204,21 @@ var (
 	operations []Operation
 	parseErr   error
 	fileErrors []FileError
+	sources    []Source
+	tagDocs    []Tag
 )
 
-// Operations returns every operation across the embedded OpenAPI spec bundle.
-// Parsing happens once per process and the result is cached. A spec file that
+// Operations returns every operation across the embedded OpenAPI spec bundle
+// and the spec files on disk, where an operation from disk replaces the
+// embedded one with the same method and path (see loadSources). Parsing
+// happens once per process and the result is cached. A spec file that
 // fails to parse is skipped (logged as a warning, see Failures) rather than
 // failing every other file's operations along with it; parseErr is non-nil
 // only for a bundle-level failure (e.g. the embedded directory itself can't
 // be read).
 func Operations() ([]Operation, error) {
 	once.Do(func() {
-		operations, fileErrors, parseErr = parseAll()
+		operations, tagDocs, fileErrors, sources, parseErr = parseAll()
 	})
 	return operations, parseErr
 }
@@ -201,11 +235,14 @@ func Failures() []FileError {
 }
 
 // Info reports which spec bundle is embedded and, for full builds, the
-// rdme-admin commit it was fetched from.
+// rdme-admin commit it was fetched from, along with the sources the catalog
+// was loaded from.
 func Info() Metadata {
+	_, _ = Operations()
 	return Metadata{
 		SpecBundle:  Bundle,
 		SpecVersion: specVersion(),
+		Sources:     sources,
 	}
 }
 
@@ -216,8 +253,14 @@ func isSpecFile(name string) bool {
 	return strings.HasSuffix(name, ".yaml") && !strings.HasPrefix(name, ".") && !strings.HasPrefix(name, "_")
 }
 
-func parseAll() ([]Operation, []FileError, error) {
-	return parseDir(specFS, rootDir)
+func parseAll() ([]Operation, []Tag, []FileError, []Source, error) {
+	ops, tags, failures, err := parseDir(specFS, rootDir)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	embedded := Source{Kind: SourceEmbedded, Location: Bundle, Version: specVersion(), Operations: len(ops)}
+	ops, tags, failures, loaded := loadSources(ops, tags, failures, diskSources())
+	return ops, tags, failures, append([]Source{embedded}, loaded...), nil
 }
 
 // parseDir parses every spec file directly under dir in fsys, skipping (and
@@ -226,26 +269,29 @@ func parseAll() ([]Operation, []FileError, error) {
 // a parameter rather than the package-level specFS so this loop's
 // skip-and-continue behavior can be verified with a synthetic in-memory
 // filesystem in tests, without needing a deliberately-broken fixture in the
-// real embedded stub/full bundle.
-func parseDir(fsys fs.FS, dir string) ([]Operation, []FileError, error) {
+// real embedded stub/full bundle. The tags declared by the files are returned
+// too, for their descriptions.
+func parseDir(fsys fs.FS, dir string) ([]Operation, []Tag, []FileError, error) {
 	entries, err := fs.ReadDir(fsys, dir)
 	if err != nil {
-		return nil, nil, fmt.Errorf("apispec: reading %s: %w", dir, err)
+		return nil, nil, nil, fmt.Errorf("apispec: reading %s: %w", dir, err)
 	}
 
 	var ops []Operation
+	var tags []Tag
 	var failures []FileError
 	for _, entry := range entries {
 		if entry.IsDir() || !isSpecFile(entry.Name()) {
 			continue
 		}
-		fileOps, err := parseFile(fsys, dir+"/"+entry.Name())
+		fileOps, fileTags, err := parseFile(fsys, path.Join(dir, entry.Name()))
 		if err != nil {
 			log.Warn(fmt.Sprintf("apispec: skipping %s: parsing failed: %s", entry.Name(), err))
 			failures = append(failures, FileError{File: entry.Name(), Err: err})
 			continue
 		}
 		ops = append(ops, fileOps...)
+		tags = mergeTags(tags, fileTags)
 	}
 
 	sort.Slice(ops, func(i, j int) bool {
@@ -254,18 +300,18 @@ func parseDir(fsys fs.FS, dir string) ([]Operation, []FileError, error) {
 		}
 		return ops[i].Method < ops[j].Method
 	})
-	return ops, failures, nil
+	return ops, tags, failures, nil
 }
 
-func parseFile(fsys fs.FS, name string) ([]Operation, error) {
+func parseFile(fsys fs.FS, name string) ([]Operation, []Tag, error) {
 	data, err := fs.ReadFile(fsys, name)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var doc rawDoc
 	if err := yaml.Unmarshal(data, &doc); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var ops []Operation
@@ -277,26 +323,27 @@ func parseFile(fsys fs.FS, name string) ([]Operation, error) {
 			}
 			var op rawOperation
 			if err := node.Decode(&op); err != nil {
-				return nil, fmt.Errorf("path %s method %s: %w", p, method, err)
+				return nil, nil, fmt.Errorf("path %s method %s: %w", p, method, err)
 			}
 			ops = append(ops, Operation{
 				Method:      strings.ToUpper(method),
 				Path:        p,
 				Summary:     op.Summary,
+				Description: op.Description,
 				Tags:        op.Tags,
 				OperationId: op.OperationId,
 				Parameters:  op.Parameters,
 				RequestBody: buildRequestBody(op.RequestBody, doc.Components.Schemas),
-				Responses:   buildResponses(op.Responses),
+				Responses:   buildResponses(op.Responses, doc.Components.Schemas),
 			})
 		}
 	}
-	return ops, nil
+	return ops, doc.Tags, nil
 }
 
-// buildRequestBody flattens a JSON request body's top-level schema properties
-// into a RequestBody. Returns nil when there's no application/json content
-// (the only content type used across today's rdme-admin reference bundle).
+// buildRequestBody resolves a JSON request body's schema into a RequestBody.
+// Returns nil when there's no application/json content (the only content type
+// used across today's rdme-admin reference bundle).
 func buildRequestBody(raw *rawRequestBody, schemas map[string]rawSchema) *RequestBody {
 	if raw == nil {
 		return nil
@@ -305,32 +352,102 @@ func buildRequestBody(raw *rawRequestBody, schemas map[string]rawSchema) *Reques
 	if !ok {
 		return nil
 	}
+	resolver := schemaResolver(schemas)
+	properties, _ := resolver.properties(media.Schema, nil)
+	if properties == nil {
+		properties = []Property{}
+	}
+	rb := &RequestBody{Required: raw.Required, Schema: propertyType(media.Schema), Properties: properties, Example: buildExample(media.Example)}
+	// A referenced array schema is hinted as one, for its items' fields not
+	// to pass for the body's own.
+	if resolved, _, ref := resolver.resolve(media.Schema, nil); ref == "" && resolved.Type == "array" {
+		rb.Schema = propertyType(resolved)
+	}
+	if rb.Example == nil {
+		rb.SynthesizedExample = resolver.synthesizeExample(media.Schema)
+	}
+	return rb
+}
 
-	schema := media.Schema
-	if schema.Ref != "" {
-		if resolved, ok := schemas[schemaRefName(schema.Ref)]; ok {
-			schema = resolved
+// maxSchemaDepth bounds how many levels of nested properties are resolved,
+// keeping describe's output readable for the full bundle's deepest schemas.
+const maxSchemaDepth = 6
+
+// schemaResolver resolves schemas against a spec file's component schemas.
+type schemaResolver map[string]rawSchema
+
+// properties returns the fields of schema, or of its items when it's an
+// array, each with its own nested fields resolved recursively. expanding
+// lists the component schemas being expanded above schema, so that a schema
+// referring back to one of them (directly or not) ends the recursion with a
+// $ref rather than looping. ref is set instead of the properties when schema
+// itself is such a back-reference or sits beyond maxSchemaDepth.
+func (r schemaResolver) properties(schema rawSchema, expanding []string) (properties []Property, ref string) {
+	schema, expanding, ref = r.resolve(schema, expanding)
+	if ref != "" {
+		return nil, ref
+	}
+	if schema.Type == "array" && schema.Items != nil {
+		schema, expanding, ref = r.resolve(*schema.Items, expanding)
+		if ref != "" {
+			return nil, ref
 		}
 	}
+	if len(schema.Properties) == 0 {
+		return nil, ""
+	}
 
 	required := make(map[string]bool, len(schema.Required))
 	for _, name := range schema.Required {
 		required[name] = true
 	}
-
-	properties := make([]Property, 0, len(schema.Properties))
+	properties = make([]Property, 0, len(schema.Properties))
 	for name, prop := range schema.Properties {
-		properties = append(properties, Property{
+		property := Property{
 			Name:        name,
 			Type:        propertyType(prop),
 			Required:    required[name],
 			Description: prop.Description,
 			Default:     stringifyDefault(prop.Default),
-		})
+		}
+		if len(expanding) < maxSchemaDepth {
+			property.Properties, property.Ref = r.properties(prop, expanding)
+		} else if prop.Ref != "" || (prop.Items != nil && prop.Items.Ref != "") {
+			property.Ref = r.refOf(prop)
+		}
+		properties = append(properties, property)
 	}
 	sort.Slice(properties, func(i, j int) bool { return properties[i].Name < properties[j].Name })
+	return properties, ""
+}
+
+// resolve follows schema's $ref, if any, appending the referenced name to
+// expanding. It returns the $ref itself when it points back to a schema in
+// expanding. An inline schema counts as a level too, so that expanding's
+// length is the nesting depth.
+func (r schemaResolver) resolve(schema rawSchema, expanding []string) (rawSchema, []string, string) {
+	if schema.Ref == "" {
+		return schema, append(expanding[:len(expanding):len(expanding)], ""), ""
+	}
+	name := schemaRefName(schema.Ref)
+	for _, seen := range expanding {
+		if seen == name {
+			return rawSchema{}, expanding, schema.Ref
+		}
+	}
+	resolved, ok := r[name]
+	if !ok {
+		return rawSchema{}, expanding, ""
+	}
+	return resolved, append(expanding[:len(expanding):len(expanding)], name), ""
+}
 
-	return &RequestBody{Required: raw.Required, Properties: properties, Example: buildExample(media.Example)}
+// refOf returns the $ref of schema or of its array items.
+func (r schemaResolver) refOf(schema rawSchema) string {
+	if schema.Ref != "" {
+		return schema.Ref
+	}
+	return schema.Items.Ref
 }
 
 // buildExample marshals a requestBody media-type's declared example (decoded by
@@ -352,13 +469,18 @@ func buildExample(v any) json.RawMessage {
 // by status code ascending. String sort is sufficient for the 2/3/4/5-digit
 // numeric codes present in both the stub and full bundles today; neither uses
 // wildcard forms like "2XX".
-func buildResponses(raw map[string]rawResponse) []Response {
+func buildResponses(raw map[string]rawResponse, schemas map[string]rawSchema) []Response {
 	if len(raw) == 0 {
 		return nil
 	}
 	responses := make([]Response, 0, len(raw))
 	for code, r := range raw {
-		responses = append(responses, Response{Code: code, Description: r.Description})
+		response := Response{Code: code, Description: r.Description}
+		if media, ok := r.Content["application/json"]; ok {
+			response.Schema = propertyType(media.Schema)
+			response.Properties, _ = schemaResolver(schemas).properties(media.Schema, nil)
+		}
+		responses = append(responses, response)
 	}
 	sort.Slice(responses, func(i, j int) bool { return responses[i].Code < responses[j].Code })
 	return responses
@@ -383,6 +505,96 @@ func FindOperation(method, path string) (op Operation, ok bool) {
 	return Operation{}, false
 }
 
+// MatchOperation returns the operation matching method (case-insensitive) and
+// a concrete request path such as "/worker/api/v1/workers/my-worker", matching
+// the catalog's {param} template segments against the path's own segments.
+// params maps each template parameter name to the (still URL-escaped) segment
+// it matched. When several operations match, the one with the most literal
+// segments wins, so "/access/api/v2/users/me" is preferred over
+// "/access/api/v2/users/{username}". path must not include a query string.
+func MatchOperation(method, path string) (op Operation, params map[string]string, ok bool) {
+	ops, err := Operations()
+	if err != nil {
+		return Operation{}, nil, false
+	}
+	return matchOperation(ops, method, path)
+}
+
+// OperationByID returns the catalog's operation with the given operationId.
+// operationIds are matched exactly first, then case-insensitively.
+func OperationByID(id string) (Operation, bool) {
+	ops, err := Operations()
+	if err != nil {
+		return Operation{}, false
+	}
+	return operationByID(ops, id)
+}
+
+func operationByID(ops []Operation, id string) (Operation, bool) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return Operation{}, false
+	}
+	for _, o := range ops {
+		if o.OperationId == id {
+			return o, true
+		}
+	}
+	for _, o := range ops {
+		if strings.EqualFold(o.OperationId, id) {
+			return o, true
+		}
+	}
+	return Operation{}, false
+}
+
+func matchOperation(ops []Operation, method, path string) (Operation, map[string]string, bool) {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var best Operation
+	var bestParams map[string]string
+	bestLiterals := -1
+	for _, o := range ops {
+		if o.Method != method {
+			continue
+		}
+		params, literals, ok := matchTemplate(strings.Split(strings.Trim(o.Path, "/"), "/"), segments)
+		if ok && literals > bestLiterals {
+			best, bestParams, bestLiterals = o, params, literals
+		}
+	}
+	return best, bestParams, bestLiterals >= 0
+}
+
+// matchTemplate matches path segments against template segments, where a
+// template segment may hold a single {param} placeholder, optionally with a
+// literal prefix or suffix (e.g. "{name}.json"). It returns the matched
+// parameter values and the number of fully literal segments.
+func matchTemplate(template, segments []string) (map[string]string, int, bool) {
+	if len(template) != len(segments) {
+		return nil, 0, false
+	}
+	params := make(map[string]string)
+	literals := 0
+	for i, t := range template {
+		open, end := strings.Index(t, "{"), strings.LastIndex(t, "}")
+		if open == -1 || end < open {
+			if t != segments[i] {
+				return nil, 0, false
+			}
+			literals++
+			continue
+		}
+		prefix, suffix := t[:open], t[end+1:]
+		seg := segments[i]
+		if len(seg) <= len(prefix)+len(suffix) || !strings.HasPrefix(seg, prefix) || !strings.HasSuffix(seg, suffix) {
+			return nil, 0, false
+		}
+		params[t[open+1:end]] = seg[len(prefix) : len(seg)-len(suffix)]
+	}
+	return params, literals, true
+}
+
 // schemaRefName extracts "Foo" from a local-document ref like
 // "#/components/schemas/Foo".
 func schemaRefName(ref string) string {
@@ -393,7 +605,7 @@ func schemaRefName(ref string) string {
 }
 
 // propertyType renders a rawSchema property as a compact type hint: a $ref
-// becomes the referenced schema's name (not flattened further), an array
+// becomes the referenced schema's name, an array
 // becomes "array<item-type>", and anything else falls back to its declared
 // type or "object" when untyped.
 func propertyType(p rawSchema) string {
@@ -32,12 +32,18 @@ func TestOperations_Stub(t *testing.T) {
 
 	assert.Nil(t, getUserList.RequestBody, "a GET operation should have no request body")
 	require.Len(t, getUserList.Responses, 4, "getUserList declares 200/400/401/403")
+	codes := make([]Response, len(getUserList.Responses))
+	for i, r := range getUserList.Responses {
+		codes[i] = Response{Code: r.Code, Description: r.Description}
+	}
 	assert.Equal(t, []Response{
 		{Code: "200", Description: "Success"},
 		{Code: "400", Description: "Bad Request - Invalid input, object invalid"},
 		{Code: "401", Description: "Bad Credentials - Invalid credentials"},
 		{Code: "403", Description: "Permission Denied - Insufficient permissions"},
-	}, getUserList.Responses, "responses should be sorted by code ascending")
+	}, codes, "responses should be sorted by code ascending")
+	assert.Equal(t, "UserListResponse", getUserList.Responses[0].Schema)
+	assert.NotEmpty(t, getUserList.Responses[0].Properties, "the 200 response's schema should be resolved")
 
 	createUser, ok := byOperationId["createUser"]
 	require.True(t, ok, "createUser should be present")
@@ -136,3 +142,17 @@ func TestIsSpecFile(t *testing.T) {
 		assert.Equal(t, tt.want, isSpecFile(tt.name), "isSpecFile(%q)", tt.name)
 	}
 }
+
+func TestTags_Stub(t *testing.T) {
+	tags, err := Tags()
+	require.NoError(t, err)
+	byName := make(map[string]Tag, len(tags))
+	for _, tag := range tags {
+		byName[tag.Name] = tag
+	}
+	users, ok := byName["Users"]
+	require.True(t, ok, "the stub bundle tags its user operations 'Users'")
+	assert.Positive(t, users.Operations)
+	assert.Contains(t, users.Description, "JFrog Platform users")
+	assert.Contains(t, byName, "Access Tokens")
+}
@@ -83,6 +83,151 @@ func TestBuildRequestBody_InlineSchemaNoRef(t *testing.T) {
 	assert.True(t, rb.Properties[0].Required)
 }
 
+const nestedSchemasDoc = `
+components:
+  schemas:
+    Node:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+        owner:
+          $ref: '#/components/schemas/Owner'
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Node'
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string
+        address:
+          type: object
+          properties:
+            city:
+              type: string
+        home:
+          $ref: '#/components/schemas/Node'
+`
+
+func TestBuildRequestBody_NestedRefsAndCycles(t *testing.T) {
+	d := parseRawDoc(t, nestedSchemasDoc)
+	raw := &rawRequestBody{Content: map[string]rawMediaTypeItem{
+		"application/json": {Schema: rawSchema{Ref: "#/components/schemas/Node"}},
+	}}
+
+	rb := buildRequestBody(raw, d.Components.Schemas)
+	require.NotNil(t, rb)
+	require.Len(t, rb.Properties, 3)
+	children, id, owner := rb.Properties[0], rb.Properties[1], rb.Properties[2]
+
+	assert.Equal(t, "array<Node>", children.Type)
+	assert.Empty(t, children.Properties)
+	assert.Equal(t, "#/components/schemas/Node", children.Ref, "a self-reference ends with a $ref back-pointer")
+	assert.True(t, id.Required)
+
+	assert.Equal(t, "Owner", owner.Type)
+	assert.Empty(t, owner.Ref)
+	require.Len(t, owner.Properties, 3)
+	address, home, name := owner.Properties[0], owner.Properties[1], owner.Properties[2]
+	require.Len(t, address.Properties, 1, "an inline nested object is resolved too")
+	assert.Equal(t, "city", address.Properties[0].Name)
+	assert.Equal(t, "#/components/schemas/Node", home.Ref, "an indirect cycle ends with a $ref back-pointer")
+	assert.Equal(t, "string", name.Type)
+}
+
+func TestSchemaResolver_DepthLimit(t *testing.T) {
+	d := parseRawDoc(t, `
+components:
+  schemas:
+    Level:
+      type: object
+      properties:
+        next:
+          type: object
+          properties:
+            next:
+              type: object
+              properties:
+                next:
+                  type: object
+                  properties:
+                    next:
+                      type: object
+                      properties:
+                        next:
+                          type: object
+                          properties:
+                            next:
+                              $ref: '#/components/schemas/Leaf'
+    Leaf:
+      type: object
+      properties:
+        value:
+          type: string
+`)
+	properties, ref := schemaResolver(d.Components.Schemas).properties(rawSchema{Ref: "#/components/schemas/Level"}, nil)
+	require.Empty(t, ref)
+	depth := 0
+	for len(properties) == 1 && len(properties[0].Properties) == 1 {
+		properties = properties[0].Properties
+		depth++
+	}
+	assert.Equal(t, maxSchemaDepth-1, depth)
+	require.Len(t, properties, 1)
+	assert.Equal(t, "Leaf", properties[0].Type)
+	assert.Equal(t, "#/components/schemas/Leaf", properties[0].Ref, "a $ref beyond the depth limit is kept as a pointer")
+}
+
+func TestBuildRequestBody_Array(t *testing.T) {
+	d := parseRawDoc(t, nestedSchemasDoc+`
+    Owners:
+      type: array
+      items:
+        $ref: '#/components/schemas/Owner'
+`)
+	inline := buildRequestBody(&rawRequestBody{Required: true, Content: map[string]rawMediaTypeItem{
+		"application/json": {Schema: rawSchema{Type: "array", Items: &rawSchema{Ref: "#/components/schemas/Owner"}}},
+	}}, d.Components.Schemas)
+	require.NotNil(t, inline)
+	assert.Equal(t, "array<Owner>", inline.Schema)
+	require.Len(t, inline.Properties, 3, "an array body is described by its items' fields")
+
+	referenced := buildRequestBody(&rawRequestBody{Content: map[string]rawMediaTypeItem{
+		"application/json": {Schema: rawSchema{Ref: "#/components/schemas/Owners"}},
+	}}, d.Components.Schemas)
+	require.NotNil(t, referenced)
+	assert.Equal(t, "array<Owner>", referenced.Schema, "a referenced array schema is hinted as an array")
+	assert.Equal(t, inline.Properties, referenced.Properties)
+
+	object := buildRequestBody(&rawRequestBody{Content: map[string]rawMediaTypeItem{
+		"application/json": {Schema: rawSchema{Ref: "#/components/schemas/Owner"}},
+	}}, d.Components.Schemas)
+	require.NotNil(t, object)
+	assert.Equal(t, "Owner", object.Schema)
+}
+
+func TestBuildResponses_Schemas(t *testing.T) {
+	d := parseRawDoc(t, nestedSchemasDoc)
+	responses := buildResponses(map[string]rawResponse{
+		"200": {Description: "OK", Content: map[string]rawMediaTypeItem{
+			"application/json": {Schema: rawSchema{Type: "array", Items: &rawSchema{Ref: "#/components/schemas/Owner"}}},
+		}},
+		"404": {Description: "Not found"},
+	}, d.Components.Schemas)
+
+	require.Len(t, responses, 2)
+	assert.Equal(t, "array<Owner>", responses[0].Schema)
+	require.Len(t, responses[0].Properties, 3, "an array body is described by its items' fields")
+	home := responses[0].Properties[1]
+	assert.Equal(t, "home", home.Name)
+	require.NotEmpty(t, home.Properties, "Node isn't being expanded above home, so it's resolved")
+	assert.Empty(t, responses[1].Schema)
+	assert.Empty(t, responses[1].Properties)
+}
+
 func TestPropertyType(t *testing.T) {
 	tests := []struct {
 		name string
@@ -91,7 +236,7 @@ func TestPropertyType(t *testing.T) {
 	}{
 		{"primitive string", rawSchema{Type: "string"}, "string"},
 		{"untyped falls back to object", rawSchema{}, "object"},
-		{"nested $ref is not flattened", rawSchema{Ref: "#/components/schemas/BuildTarget"}, "BuildTarget"},
+		{"$ref is named by its schema", rawSchema{Ref: "#/components/schemas/BuildTarget"}, "BuildTarget"},
 		{"array of primitives", rawSchema{Type: "array", Items: &rawSchema{Type: "string"}}, "array<string>"},
 		{"array of refs", rawSchema{Type: "array", Items: &rawSchema{Ref: "#/components/schemas/PatternFilter"}}, "array<PatternFilter>"},
 		{"array with no items info", rawSchema{Type: "array"}, "array<object>"},
@@ -43,7 +43,7 @@ paths:
 `)},
 	}
 
-	ops, failures, err := parseDir(fsys, "spec")
+	ops, _, failures, err := parseDir(fsys, "spec")
 	require.NoError(t, err, "one bad file should not fail the whole bundle")
 
 	require.Len(t, ops, 1, "the good file's operation should still be present")
@@ -66,7 +66,7 @@ paths:
 `)},
 	}
 
-	_, failures, err := parseDir(fsys, "spec")
+	_, _, failures, err := parseDir(fsys, "spec")
 	require.NoError(t, err)
 	assert.Empty(t, failures)
 }
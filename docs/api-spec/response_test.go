@@ -8,8 +8,8 @@ import (
 )
 
 func TestBuildResponses_NilWhenEmpty(t *testing.T) {
-	assert.Nil(t, buildResponses(nil))
-	assert.Nil(t, buildResponses(map[string]rawResponse{}))
+	assert.Nil(t, buildResponses(nil, nil))
+	assert.Nil(t, buildResponses(map[string]rawResponse{}, nil))
 }
 
 func TestBuildResponses_SortedByCode(t *testing.T) {
@@ -18,7 +18,7 @@ func TestBuildResponses_SortedByCode(t *testing.T) {
 		"200": {Description: "Success"},
 		"400": {Description: "Bad Request"},
 	}
-	responses := buildResponses(raw)
+	responses := buildResponses(raw, nil)
 	require.Len(t, responses, 3)
 	assert.Equal(t, []Response{
 		{Code: "200", Description: "Success"},
@@ -28,7 +28,7 @@ func TestBuildResponses_SortedByCode(t *testing.T) {
 }
 
 func TestBuildResponses_MissingDescriptionIsEmpty(t *testing.T) {
-	responses := buildResponses(map[string]rawResponse{"204": {}})
+	responses := buildResponses(map[string]rawResponse{"204": {}}, nil)
 	require.Len(t, responses, 1)
 	assert.Equal(t, "204", responses[0].Code)
 	assert.Empty(t, responses[0].Description)
@@ -80,3 +80,37 @@ func TestFindOperation(t *testing.T) {
 	_, ok = FindOperation("GET", "/not/a/real/path")
 	assert.False(t, ok)
 }
+
+func TestMatchOperation(t *testing.T) {
+	ops := []Operation{
+		{Method: "GET", Path: "/access/api/v2/users", OperationId: "list"},
+		{Method: "GET", Path: "/access/api/v2/users/{username}", OperationId: "get"},
+		{Method: "GET", Path: "/access/api/v2/users/me", OperationId: "me"},
+		{Method: "DELETE", Path: "/access/api/v2/users/{username}", OperationId: "delete"},
+		{Method: "GET", Path: "/api/builds/{name}.json", OperationId: "build"},
+	}
+
+	op, params, ok := matchOperation(ops, "get", "/access/api/v2/users/admin")
+	require.True(t, ok)
+	assert.Equal(t, "get", op.OperationId)
+	assert.Equal(t, map[string]string{"username": "admin"}, params)
+
+	op, _, ok = matchOperation(ops, "GET", "/access/api/v2/users/me")
+	require.True(t, ok)
+	assert.Equal(t, "me", op.OperationId, "a literal segment should win over a {param} template")
+
+	op, _, ok = matchOperation(ops, "DELETE", "/access/api/v2/users/admin/")
+	require.True(t, ok, "a trailing slash should not prevent a match")
+	assert.Equal(t, "delete", op.OperationId)
+
+	op, params, ok = matchOperation(ops, "GET", "/api/builds/nightly.json")
+	require.True(t, ok)
+	assert.Equal(t, "build", op.OperationId)
+	assert.Equal(t, map[string]string{"name": "nightly"}, params)
+
+	_, _, ok = matchOperation(ops, "GET", "/access/api/v2/users/admin/groups")
+	assert.False(t, ok, "extra segments should not match")
+
+	_, _, ok = matchOperation(ops, "POST", "/access/api/v2/users")
+	assert.False(t, ok)
+}
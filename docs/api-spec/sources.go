@@ -0,0 +1,217 @@
+package apispec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v3"
+)
+
+// SpecDirEnv names a directory of extra OpenAPI spec files, merged over the
+// embedded bundle in place of the default <JFrog home>/api-specs directory.
+const SpecDirEnv = "JFROG_CLI_API_SPEC_DIR"
+
+// The kinds of Source, from lowest to highest precedence.
+const (
+	SourceEmbedded = "embedded"
+	SourceSynced   = "synced"
+	SourceLocal    = "local"
+)
+
+const (
+	specsDirName  = "api-specs"
+	syncedDirName = "synced"
+	syncedFile    = "platform.yaml"
+	syncInfoFile  = "sync.json"
+)
+
+// Source describes one of the places the catalog's operations were loaded
+// from: the embedded bundle, the spec downloaded by 'jf api docs sync', or a
+// local directory of spec files.
+type Source struct {
+	Kind string `json:"kind"`
+	// Location is the bundle name for the embedded source, and a directory
+	// otherwise.
+	Location string `json:"location"`
+	Version  string `json:"version,omitempty"`
+	// URL is where a synced spec was downloaded from.
+	URL        string `json:"url,omitempty"`
+	SyncedAt   string `json:"synced_at,omitempty"`
+	Operations int    `json:"operations"`
+}
+
+// syncInfo is what SaveSynced records next to the synced spec.
+type syncInfo struct {
+	URL      string `json:"url"`
+	Version  string `json:"version,omitempty"`
+	SyncedAt string `json:"synced_at"`
+}
+
+// SpecsDir returns the directory holding the local spec files: $JFROG_CLI_API_SPEC_DIR
+// when set, and <JFrog home>/api-specs otherwise.
+func SpecsDir() (string, error) {
+	if dir := os.Getenv(SpecDirEnv); dir != "" {
+		return dir, nil
+	}
+	home, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, specsDirName), nil
+}
+
+// SyncedDir returns the directory 'jf api docs sync' caches the platform's
+// spec in. It stays under the JFrog home even when $JFROG_CLI_API_SPEC_DIR is
+// set, so that a synced spec is never mixed with hand-maintained files.
+func SyncedDir() (string, error) {
+	home, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, specsDirName, syncedDirName), nil
+}
+
+// diskSources lists the spec directories that exist, in precedence order. A
+// missing default directory is the common case and is skipped silently, but
+// a missing $JFROG_CLI_API_SPEC_DIR is worth a warning.
+func diskSources() []Source {
+	var found []Source
+	if dir, err := SyncedDir(); err == nil {
+		if info, err := readSyncInfo(dir); err == nil {
+			found = append(found, Source{Kind: SourceSynced, Location: dir, Version: info.Version, URL: info.URL, SyncedAt: info.SyncedAt})
+		}
+	}
+	dir, err := SpecsDir()
+	if err != nil {
+		log.Warn("apispec: can't locate the local spec directory:", err.Error())
+		return found
+	}
+	if _, err = os.Stat(dir); err != nil {
+		if os.Getenv(SpecDirEnv) != "" {
+			log.Warn(fmt.Sprintf("apispec: ignoring %s=%s: %s", SpecDirEnv, dir, err))
+		}
+		return found
+	}
+	return append(found, Source{Kind: SourceLocal, Location: dir})
+}
+
+// loadSources parses each source's directory and merges its operations over
+// ops, in order: an operation with the same method and path as an earlier one
+// replaces it. A directory that can't be read is skipped with a warning.
+func loadSources(ops []Operation, tags []Tag, failures []FileError, extra []Source) ([]Operation, []Tag, []FileError, []Source) {
+	var loaded []Source
+	for _, source := range extra {
+		sourceOps, sourceTags, sourceFailures, err := parseDir(os.DirFS(source.Location), ".")
+		if err != nil {
+			log.Warn(fmt.Sprintf("apispec: skipping %s: %s", source.Location, err))
+			continue
+		}
+		for _, failure := range sourceFailures {
+			failure.File = filepath.Join(source.Location, failure.File)
+			failures = append(failures, failure)
+		}
+		source.Operations = len(sourceOps)
+		ops = mergeOperations(ops, sourceOps)
+		tags = mergeTags(tags, sourceTags)
+		loaded = append(loaded, source)
+	}
+	return ops, tags, failures, loaded
+}
+
+func mergeOperations(base, overrides []Operation) []Operation {
+	if len(overrides) == 0 {
+		return base
+	}
+	key := func(o Operation) string { return o.Method + " " + o.Path }
+	replaced := make(map[string]bool, len(overrides))
+	for _, o := range overrides {
+		replaced[key(o)] = true
+	}
+	merged := make([]Operation, 0, len(base)+len(overrides))
+	for _, o := range base {
+		if !replaced[key(o)] {
+			merged = append(merged, o)
+		}
+	}
+	merged = append(merged, overrides...)
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].Path != merged[j].Path {
+			return merged[i].Path < merged[j].Path
+		}
+		return merged[i].Method < merged[j].Method
+	})
+	return merged
+}
+
+func readSyncInfo(dir string) (*syncInfo, error) {
+	data, err := os.ReadFile(filepath.Join(dir, syncInfoFile))
+	if err != nil {
+		return nil, err
+	}
+	var info syncInfo
+	if err = json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("apispec: %s: %w", syncInfoFile, err)
+	}
+	return &info, nil
+}
+
+// SaveSynced validates spec, an OpenAPI document in YAML or JSON downloaded
+// from url, and caches it in SyncedDir, replacing any previously synced spec.
+// The version recorded is the document's info.version.
+func SaveSynced(spec []byte, url string) (Source, error) {
+	var doc rawDoc
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return Source{}, fmt.Errorf("apispec: the downloaded spec is not a valid OpenAPI document: %w", err)
+	}
+	dir, err := SyncedDir()
+	if err != nil {
+		return Source{}, err
+	}
+	// The spec is staged next to the cache and parsed exactly as it will be
+	// loaded, so that a bad download never replaces a good cache.
+	if err = os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+		return Source{}, err
+	}
+	staging, err := os.MkdirTemp(filepath.Dir(dir), ".synced-*")
+	if err != nil {
+		return Source{}, err
+	}
+	defer func() {
+		_ = os.RemoveAll(staging)
+	}()
+	if err = os.WriteFile(filepath.Join(staging, syncedFile), spec, 0o644); err != nil {
+		return Source{}, err
+	}
+	ops, _, failures, err := parseDir(os.DirFS(staging), ".")
+	if err != nil {
+		return Source{}, err
+	}
+	if len(failures) > 0 {
+		return Source{}, fmt.Errorf("apispec: the downloaded spec can't be parsed: %w", failures[0].Err)
+	}
+	if len(ops) == 0 {
+		return Source{}, fmt.Errorf("apispec: the downloaded spec declares no operations")
+	}
+
+	info := syncInfo{URL: url, Version: doc.Info.Version, SyncedAt: time.Now().UTC().Format(time.RFC3339)}
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return Source{}, err
+	}
+	if err = os.WriteFile(filepath.Join(staging, syncInfoFile), append(data, '\n'), 0o644); err != nil {
+		return Source{}, err
+	}
+	if err = os.RemoveAll(dir); err != nil {
+		return Source{}, err
+	}
+	if err = os.Rename(staging, dir); err != nil {
+		return Source{}, err
+	}
+	return Source{Kind: SourceSynced, Location: dir, Version: info.Version, URL: url, SyncedAt: info.SyncedAt, Operations: len(ops)}, nil
+}
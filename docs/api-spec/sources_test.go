@@ -0,0 +1,130 @@
+package apispec
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const widgetsSpec = `
+info:
+  version: 7.100.0
+paths:
+  /widgets:
+    get:
+      operationId: listWidgetsOverride
+      summary: List widgets (platform)
+      responses:
+        '200':
+          description: OK
+  /gadgets:
+    post:
+      operationId: createGadget
+      responses:
+        '201':
+          description: Created
+`
+
+func writeSpec(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+}
+
+func TestLoadSources_MergesAndOverrides(t *testing.T) {
+	base := []Operation{
+		{Method: "GET", Path: "/widgets", OperationId: "listWidgets"},
+		{Method: "DELETE", Path: "/widgets", OperationId: "deleteWidgets"},
+	}
+	synced := t.TempDir()
+	writeSpec(t, synced, "platform.yaml", widgetsSpec)
+	local := t.TempDir()
+	writeSpec(t, local, "custom.yaml", `
+paths:
+  /gadgets:
+    post:
+      operationId: createGadgetLocal
+      responses:
+        '201':
+          description: Created
+`)
+	writeSpec(t, local, "broken.yaml", "paths: [")
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	ops, _, failures, loaded := loadSources(base, nil, nil, []Source{
+		{Kind: SourceSynced, Location: synced},
+		{Kind: SourceLocal, Location: local},
+		{Kind: SourceLocal, Location: missing},
+	})
+
+	ids := make(map[string]string, len(ops))
+	for _, o := range ops {
+		ids[o.Method+" "+o.Path] = o.OperationId
+	}
+	assert.Equal(t, map[string]string{
+		"DELETE /widgets": "deleteWidgets",
+		"GET /widgets":    "listWidgetsOverride",
+		"POST /gadgets":   "createGadgetLocal",
+	}, ids, "a later source replaces an operation with the same method and path")
+	assert.Equal(t, "/gadgets", ops[0].Path, "merged operations stay sorted by path")
+
+	require.Len(t, loaded, 2, "an unreadable directory is skipped")
+	assert.Equal(t, 2, loaded[0].Operations)
+	assert.Equal(t, 1, loaded[1].Operations)
+	require.Len(t, failures, 1)
+	assert.Equal(t, filepath.Join(local, "broken.yaml"), failures[0].File)
+}
+
+func TestDiskSources(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("JFROG_CLI_HOME_DIR", home)
+	t.Setenv(SpecDirEnv, "")
+	assert.Empty(t, diskSources(), "no spec directory exists yet")
+
+	writeSpec(t, filepath.Join(home, "api-specs"), "custom.yaml", widgetsSpec)
+	sources := diskSources()
+	require.Len(t, sources, 1)
+	assert.Equal(t, Source{Kind: SourceLocal, Location: filepath.Join(home, "api-specs")}, sources[0])
+
+	specDir := t.TempDir()
+	t.Setenv(SpecDirEnv, specDir)
+	sources = diskSources()
+	require.Len(t, sources, 1)
+	assert.Equal(t, specDir, sources[0].Location, "$JFROG_CLI_API_SPEC_DIR replaces the default directory")
+}
+
+func TestSaveSynced(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("JFROG_CLI_HOME_DIR", home)
+	t.Setenv(SpecDirEnv, "")
+
+	source, err := SaveSynced([]byte(widgetsSpec), "https://acme.jfrog.io/my/api/openapi.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, SourceSynced, source.Kind)
+	assert.Equal(t, "7.100.0", source.Version)
+	assert.Equal(t, 2, source.Operations)
+	assert.Equal(t, filepath.Join(home, "api-specs", "synced"), source.Location)
+
+	sources := diskSources()
+	require.Len(t, sources, 2, "the synced spec comes first, then the local directory holding it")
+	assert.Equal(t, SourceSynced, sources[0].Kind)
+	assert.Equal(t, "7.100.0", sources[0].Version)
+	assert.Equal(t, "https://acme.jfrog.io/my/api/openapi.yaml", sources[0].URL)
+	_, _, _, loaded := loadSources(nil, nil, nil, sources)
+	assert.Equal(t, 2, loaded[0].Operations)
+	assert.Equal(t, 0, loaded[1].Operations, "the synced subdirectory isn't read twice")
+
+	_, err = SaveSynced([]byte("paths: {}"), "https://acme.jfrog.io/x")
+	assert.ErrorContains(t, err, "declares no operations")
+	_, err = SaveSynced([]byte("<html>login</html>\n  - x"), "https://acme.jfrog.io/x")
+	assert.Error(t, err)
+	info, err := readSyncInfo(source.Location)
+	require.NoError(t, err)
+	assert.Equal(t, "7.100.0", info.Version, "a rejected download leaves the previous cache in place")
+	entries, err := os.ReadDir(filepath.Join(home, "api-specs"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no staging directory is left behind")
+}
@@ -0,0 +1,66 @@
+package apispec
+
+import (
+	"sort"
+	"strings"
+)
+
+// Tag describes one of the areas the catalog's operations are grouped in.
+// Name and Description come from a spec's top-level tags list, and
+// Operations counts the operations carrying the tag.
+type Tag struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description,omitempty"`
+	Operations  int    `yaml:"-" json:"operations"`
+}
+
+// Tags returns every tag used by at least one operation of the catalog,
+// sorted by name, with its declared description when any spec file declares
+// one. Like Operations, it's served from the catalog parsed once per process.
+func Tags() ([]Tag, error) {
+	ops, err := Operations()
+	if err != nil {
+		return nil, err
+	}
+	return countTags(ops, tagDocs), nil
+}
+
+func countTags(ops []Operation, declared []Tag) []Tag {
+	descriptions := make(map[string]string, len(declared))
+	for _, t := range declared {
+		descriptions[t.Name] = t.Description
+	}
+	counts := make(map[string]int)
+	for _, op := range ops {
+		for _, name := range op.Tags {
+			counts[name]++
+		}
+	}
+	tags := make([]Tag, 0, len(counts))
+	for name, count := range counts {
+		tags = append(tags, Tag{Name: name, Description: descriptions[name], Operations: count})
+	}
+	sort.Slice(tags, func(i, j int) bool { return strings.ToLower(tags[i].Name) < strings.ToLower(tags[j].Name) })
+	return tags
+}
+
+// mergeTags adds the tag declarations of more to tags. A tag declared again
+// keeps its position, and its description is replaced by a non-empty one.
+func mergeTags(tags, more []Tag) []Tag {
+	for _, t := range more {
+		found := false
+		for i := range tags {
+			if tags[i].Name == t.Name {
+				if t.Description != "" {
+					tags[i].Description = t.Description
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			tags = append(tags, Tag{Name: t.Name, Description: t.Description})
+		}
+	}
+	return tags
+}
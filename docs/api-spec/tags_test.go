@@ -0,0 +1,31 @@
+package apispec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountTags(t *testing.T) {
+	ops := []Operation{
+		{Method: "GET", Path: "/a", Tags: []string{"users"}},
+		{Method: "POST", Path: "/a", Tags: []string{"users", "Groups"}},
+		{Method: "GET", Path: "/b"},
+	}
+	declared := []Tag{{Name: "users", Description: "User management."}, {Name: "Unused", Description: "Never used."}}
+
+	assert.Equal(t, []Tag{
+		{Name: "Groups", Operations: 1},
+		{Name: "users", Description: "User management.", Operations: 2},
+	}, countTags(ops, declared), "undeclared tags are listed, unused declarations aren't, and names sort case-insensitively")
+}
+
+func TestMergeTags(t *testing.T) {
+	tags := []Tag{{Name: "Users", Description: "Old."}, {Name: "Groups", Description: "Groups."}}
+	merged := mergeTags(tags, []Tag{{Name: "Users", Description: "New."}, {Name: "Groups"}, {Name: "Tokens"}})
+	assert.Equal(t, []Tag{
+		{Name: "Users", Description: "New."},
+		{Name: "Groups", Description: "Groups."},
+		{Name: "Tokens"},
+	}, merged)
+}
@@ -2,7 +2,7 @@ package api
 
 import "github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
 
-var Usage = []string{"api <endpoint-path>"}
+var Usage = []string{"api <endpoint-path>", "api --op <operation-id> [--param <name>=<value>]..."}
 
 func GetDescription() string {
 	return "Invoke a JFrog Platform HTTP API using the configured server URL and credentials (hostname and token are not passed manually; use 'jf config' or --url / --access-token / --server-id as usual). REST API reference: " + coreutils.JFrogHelpUrl + "jfrog-platform-documentation/rest-apis. Run 'jf api docs search <query>' to look up an endpoint locally, offline, from this binary's embedded OpenAPI spec."
@@ -10,7 +10,7 @@ func GetDescription() string {
 
 func GetArguments() string {
 	return `	endpoint-path
-		API path on the platform host (for example: /access/api/v1/users or /artifactory/api/repositories). The configured platform base URL is prepended automatically.
+		API path on the platform host (for example: /access/api/v1/users or /artifactory/api/repositories). The configured platform base URL is prepended automatically. Omitted with --op, which takes the path from the OpenAPI catalog.
 
 EXAMPLES
   # List users (Access API)
@@ -45,11 +45,56 @@ EXAMPLES
   # One Model GraphQL
   $ jf api /onemodel/api/v1/graphql -X POST -H "Content-Type: application/json" --input ./graphql-query.json
 
+  # Check the request against the embedded OpenAPI catalog before sending it (fails locally on a missing or mistyped field)
+  $ jf api /access/api/v2/users -X POST -d '{"username":"newuser","email":"newuser@example.com"}' -H "Content-Type: application/json" --validate
+
+  # Print the request as a curl command to share, without sending it (the token is read from $JF_ACCESS_TOKEN)
+  $ jf api /access/api/v2/users -X POST --input ./user.json -H "Content-Type: application/json" --print-curl
+  $ jf api /artifactory/api/repositories --export go
+
+  # Record the exchange to a HAR file, then replay it offline (for example in CI) without a server
+  $ jf api "/access/api/v1/tokens?offset=0&limit=100" --paginate --record ./tokens.har
+  $ jf api "/access/api/v1/tokens?offset=0&limit=100" --paginate --replay ./tokens.har --url https://example.jfrog.io --access-token none
+
+  # Upload a file as multipart/form-data, streamed from disk
+  $ jf api /artifactory/api/some/upload/endpoint -X POST --form description=nightly --form file=@./bundle.tgz
+
+  # Download a large artifact to a file with a progress bar, without holding it in memory
+  $ jf api /artifactory/generic-local/images/disk.img --output ./disk.img
+
+  # Upload a large file as the raw request body (--input is streamed when --output is set)
+  $ jf api /artifactory/generic-local/images/disk.img -X PUT --input ./disk.img --output -
+
+  # Call an operation by its operationId (from 'jf api docs search'), filling in its path and query parameters
+  $ jf api --op getGroupDetails --param name=readers
+  $ jf api --op getUserList --param status=enabled --param limit=10
+
+  # Run many requests (one JSON request per line) concurrently over one connection pool
+  $ jf api batch ./requests.ndjson --threads 8
+
   # Set a request timeout (seconds)
   $ jf api /artifactory/api/repositories --timeout 10
 
+  # Select fields from the JSON response without jq (JSONPath, or the equivalent jq-style '.tokens[].token_id')
+  $ jf api /access/api/v1/tokens --query '$.tokens[*].token_id' --raw
+  $ jf api --op getGroupDetails --param name=readers
+  $ jf api /artifactory/api/repositories --query "$[?(@.type == 'LOCAL')].key"
+
+  # Retry up to 5 times on network errors and 429/502/503/504 responses, waiting 2s, 4s, 8s, ... (or as long as Retry-After asks)
+  $ jf api /artifactory/api/repositories --retries 5 --retry-wait 2s
+
+  # Fetch every page of a list endpoint (Link rel="next" header, or offset/limit and page_num query parameters) and merge them
+  $ jf api "/access/api/v1/tokens?offset=0&limit=100" --paginate
+
+  # Print each page as one JSON line instead of merging, giving up after 60 seconds in total
+  $ jf api "/lifecycle/api/v2/release_bundle/names?offset=0&limit=50" --paginate --ndjson --timeout 60
+
 OUTPUT
   The response body is written to standard output. The HTTP status code is written to standard error as a single line. Non-2xx responses still print the body and exit with status 1.
+  With --query, a successful JSON response is replaced by the selected value (for a path made only of field names and indexes, or null when absent) or by the array of all matches (for wildcards, slices, filters and recursive descent); --raw prints each value on its own line, strings unquoted. Error responses are never filtered.
+  With --print-curl or --export, nothing is sent: the curl, HTTPie or Go snippet is written to standard output instead, with credentials replaced by environment variable references.
+  With --output, a successful response body is streamed to the file (written in place only once complete) or, for "-", to standard output, as is; an error response is printed as above and the file is left untouched.
+  With --paginate, JSON array pages (or objects holding a single array field, such as {"tokens":[...]}) are merged into one result shaped like the first page; --ndjson prints each page as a single line instead. A non-2xx page stops the walk and is reported as above. The walk also stops at a page holding the same items as the previous one, and fails after --max-pages pages (1000 by default).
 
 REFERENCES
    Binary Management (Artifactory):  https://docs.jfrog.com/artifactory/reference/
@@ -81,13 +126,24 @@ Common patterns:
   $ jf api /artifactory/api/repositories -X POST -H "Content-Type: application/json" --input ./repo.json
   $ jf api /artifactory/api/repositories/my-repo -X DELETE
   $ jf api /access/api/v2/users -X POST -d '{"username":"newuser","email":"u@example.com","password":"S3cret!"}' -H "Content-Type: application/json"
+  $ jf api "/access/api/v1/tokens?offset=0&limit=100" --paginate
+  $ jf api /access/api/v1/tokens --query '$.tokens[*].token_id' --raw
 
 Gotchas:
 - The endpoint path must start with /; the platform base URL is prepended.
 - -d/--data and --input are mutually exclusive.
 - HTTP status goes to stderr (one line), body to stdout. Non-2xx exits with status 1 but still prints the body.
+- --paginate follows Link rel="next" headers first; otherwise it advances offset (by limit) or page_num in the request's own query string, so include them in the path (e.g. "?offset=0&limit=100") and quote the path in the shell. The walk stops on an empty or short page.
+- --validate checks the method, path, required query parameters and the top-level JSON body fields against the embedded OpenAPI catalog, and fails without sending anything on a mismatch; it's also a hard error when the catalog has no matching operation. 'jf api docs validate <method> <path> -d <json>' runs the same checks offline.
+- --op <operationId> replaces the endpoint path: the method and path come from the catalog operation (the operationId printed by 'jf api docs search'), with each {placeholder} and query parameter filled in from --param name=value (URL-escaped for you; repeat a query parameter's --param to send it several times). A missing required path or query parameter, or a --param the operation doesn't declare, fails locally without sending anything. Header parameters aren't filled in: pass them with -H. -X is optional with --op, and must match the operation's method when given.
+- --print-curl and --export=<curl|httpie|go> print the request instead of sending it. The Authorization header is never printed: the snippet reads $JF_ACCESS_TOKEN (or $JF_USER/$JF_PASSWORD for basic auth, $JF_AUTHORIZATION for a raw -H Authorization value) instead. Likewise, the values of credential headers passed with -H, such as Cookie or X-JFrog-Art-Api, are read from $JF_<HEADER> (e.g. $JF_X_JFROG_ART_API).
+- --record appends each attempt to a HAR file (the Authorization header and credential headers such as Cookie or X-JFrog-Art-Api, Set-Cookie headers, and the fields of query strings and JSON or form bodies whose names end with password, token, secret or a credential key, such as access_token or apiKey but not token_id, redacted); --replay answers from it instead of the network, matching method, path and query string (not host), serving repeated identical requests in recorded order. A server URL is still required with --replay, but it isn't contacted.
+- --form and --output switch to streaming mode, for bodies too large to hold in memory: --form key=@path streams a file part from disk, and --output streams the response to a file with a progress bar. --input is streamed from disk only in this mode, so add '--output -' to upload a large file as the raw body. --paginate, --query, --raw, --retries, --record, --replay, --validate and the export flags are rejected in streaming mode, and --timeout bounds the whole transfer.
+- --query supports a JSONPath subset ($, .name, ['name'], [*], [n], [a:b], .., and [?(@.field op literal)] filters) plus jq-style paths such as '.tokens[].token_id'. Quote the expression in the shell.
+- --retries only retries GET, HEAD, PUT, DELETE and OPTIONS requests; add --retry-all-methods to retry POST/PATCH too. The status and exit code of the last attempt are reported.
 - Some APIs require trailing slashes or specific Accept headers; check the API reference before scripting.
-- The bare, slash-less path 'docs' (e.g. 'jf api docs') routes to 'jf api docs search' instead of issuing an HTTP call. This does not affect the leading-slash form: 'jf api -X GET /docs' still reaches the platform normally, since no real JFrog REST path is bare '/docs'.
+- For many requests, 'jf api batch <file>' runs an NDJSON or YAML list of requests concurrently over one connection pool.
+- The bare, slash-less paths 'batch' and 'docs' (e.g. 'jf api docs') route to the 'jf api batch' and 'jf api docs' subcommands instead of issuing an HTTP call. This does not affect the leading-slash form: 'jf api -X GET /docs' still reaches the platform normally, since no real JFrog REST path is bare '/docs'.
 
-Related: jf api docs search, jf api docs describe, jf c add, jf rt, jf c show`
+Related: jf api batch, jf api docs search, jf api docs describe, jf api docs validate, jf c add, jf rt, jf c show`
 }
@@ -0,0 +1,49 @@
+package apibatch
+
+var Usage = []string{"api batch <file> [--threads <n>] [--fail-fast]"}
+
+func GetDescription() string {
+	return "Run many JFrog Platform HTTP API requests from a file, concurrently, over a single authenticated connection pool, and print one NDJSON result line per request."
+}
+
+func GetArguments() string {
+	return `	file
+		Request descriptors to run: one JSON object per line (NDJSON), or a YAML list when the file name ends with .yaml or .yml. Use "-" to read NDJSON from standard input. Each request has a "path" (required, relative to the platform base URL), and optionally a "method" (default GET), "headers" (a map of header names to values), a "body" (sent verbatim when it's a string, as JSON otherwise) and an "id" echoed in its result.
+
+EXAMPLES
+  # requests.ndjson:
+  #   {"id":"read","method":"PUT","path":"/access/api/v2/permissions/read/repository","body":{"actions":{"users":{"alice":["READ"]}}},"headers":{"Content-Type":"application/json"}}
+  #   {"method":"GET","path":"/artifactory/api/storage/libs-release/app.jar?properties"}
+  $ jf api batch requests.ndjson --threads 8
+
+  # The same requests as a YAML list
+  $ jf api batch requests.yaml
+
+  # Stop at the first failing request
+  $ generate-requests | jf api batch - --fail-fast
+
+OUTPUT
+  One JSON line per request, in completion order: "index" (the request's 1-based position in the file), "id", "method", "path", "status", "duration_ms", and the response "body" (as is for a JSON body, as a string otherwise) or an "error" when no response was received. The command exits with status 1 when any request fails or returns a non-2xx status.`
+}
+
+func GetAIDescription() string {
+	return `Run many JFrog Platform REST requests from an NDJSON or YAML file concurrently, reusing one configuration, connection pool and usage report. Prints one NDJSON result line per request.
+
+When to use:
+- Bulk admin operations (hundreds of permission updates, property sets, user changes) where one 'jf api' invocation per call would be slow.
+
+Prerequisites:
+- A configured server (jf c add or jf login) or explicit --url / --access-token / --server-id.
+
+Common patterns:
+  $ jf api batch requests.ndjson --threads 8
+  $ jf api batch requests.yaml --fail-fast
+
+Gotchas:
+- Each request needs a "path"; "method" defaults to GET. A string "body" is sent verbatim, any other value as JSON. Set "Content-Type" in "headers" when the endpoint needs it.
+- Results are printed as they complete, not in file order; match them by "index" or "id".
+- With --fail-fast, requests not yet started are skipped and have no result line; requests already in flight are aborted and reported with an "error".
+- Exit status is 1 if any request failed or returned a non-2xx status.
+
+Related: jf api`
+}
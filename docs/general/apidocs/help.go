@@ -1,13 +1,13 @@
 package apidocs
 
-var Usage = []string{"api docs search <query> [command options]", "api docs describe <method> <path> [command options]"}
+var Usage = []string{"api docs search <query> [command options]", "api docs tags [command options]", "api docs list [command options]", "api docs describe <method> <path> [command options]", "api docs validate <method> <path> [command options]", "api docs diff <spec-a> <spec-b> [command options]", "api docs sync [command options]"}
 
 func GetDescription() string {
-	return "Discover JFrog Platform REST API operations. Run 'jf api docs search <query>' to find a candidate endpoint, then 'jf api docs describe <method> <path>' to see its full shape, before using 'jf api <path>'."
+	return "Discover JFrog Platform REST API operations. Run 'jf api docs search <query>' to find a candidate endpoint, then 'jf api docs describe <method> <path>' to see its full shape, and 'jf api docs validate <method> <path>' to check a request body against it, before using 'jf api <path>'. Run 'jf api docs tags' and 'jf api docs list --tag <tag>' to browse the catalog by area. Run 'jf api docs sync' to add the configured platform's own spec to the embedded catalog, and 'jf api docs diff <spec-a> <spec-b>' to find the breaking changes between two specs."
 }
 
 func GetAIDescription() string {
-	return `Namespace for API-discovery subcommands. Run 'jf api docs search <query>' to look up a REST endpoint by keyword, then 'jf api docs describe <method> <path>' to see its parameters/request body/response codes, before guessing at 'jf api <path>'.
+	return `Namespace for API-discovery subcommands. Run 'jf api docs search <query>' to look up a REST endpoint by keyword, then 'jf api docs describe <method> <path>' to see its parameters/request body/response codes, and 'jf api docs validate <method> <path> -d <json>' to check a payload offline, before guessing at 'jf api <path>'. Without a keyword, 'jf api docs tags' lists the catalog's areas and 'jf api docs list --tag <tag>' their operations. 'jf api docs sync' caches the configured platform's own spec, and extra spec files are read from $JFROG_CLI_API_SPEC_DIR (default <JFrog home>/api-specs), so the catalog can cover endpoints missing from the embedded bundle. 'jf api docs diff <spec-a> <spec-b>' reports the endpoints added, removed or changed between two specs, and fails on breaking changes.
 
-See 'jf api docs search --help' and 'jf api docs describe --help' for the full set of options.`
+See 'jf api docs search --help', 'jf api docs tags --help', 'jf api docs list --help', 'jf api docs describe --help', 'jf api docs validate --help', 'jf api docs diff --help' and 'jf api docs sync --help' for the full set of options.`
 }
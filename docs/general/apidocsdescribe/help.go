@@ -27,7 +27,7 @@ EXAMPLES
   $ jf api docs describe GET /access/api/v2/users --format table
 
 OUTPUT
-  JSON by default (this command exists primarily for agent consumption); pass --format table for a human-readable table instead. The result includes the operation's method, path, summary, tags, "parameters" (path/query/header, required ones marked), "request_body" (top-level fields with name/type/required/description/default, plus an "example" payload when the spec declares one), "responses" (status code + description for each declared response), and a "jf_api" field with a ready-to-run 'jf api' invocation. When method+path isn't found in the embedded catalog, the command exits non-zero with an error naming the spec bundle searched and recommending 'jf api docs search' to find the exact method/path.`
+  JSON by default (this command exists primarily for agent consumption); pass --format table for a human-readable table instead. The result includes the operation's method, path, summary, tags, "parameters" (path/query/header, required ones marked), "request_body" (its "schema" type, such as array<Widget> for an array whose items' fields are listed, the fields with name/type/required/description/default, nested objects' fields under "properties", plus an "example" payload when the spec declares one, or else a "synthesized_example" generated from the schema's required fields, defaults and enums), "responses" (status code + description for each declared response, and the JSON body's "schema" type and nested "properties" when declared), and a "jf_api" field with a ready-to-run 'jf api' invocation, whose -d payload is the declared or synthesized example ("jf_api_data" says which). When method+path isn't found in the embedded catalog, the command exits non-zero with an error naming the spec bundle searched and recommending 'jf api docs search' to find the exact method/path.`
 }
 
 func GetAIDescription() string {
@@ -53,7 +53,7 @@ Gotchas:
 - path must match the catalog exactly, including any literal {param} placeholders (e.g. "{workerKey}", not a real key) — copy it verbatim from 'jf api docs search' results rather than guessing.
 - Not found (wrong method, wrong path, or the stub bundle lacks the operation) is a hard error (non-zero exit), unlike 'jf api docs search', which returns an empty match list with exit 0.
 - request_body's "example" field is only present when the underlying spec declares one; its absence doesn't mean the operation has no valid payload — check "properties" either way.
-- A request body property that is itself a nested object is reported by its type name (e.g. "PermissionResource") or "object" rather than being recursively flattened — only top-level fields are listed.
+- Nested objects are resolved through $refs into a "properties" tree (an indented list in --format table), down to 6 levels. A field that refers back to a schema already being expanded above it, or that sits past that depth, is not expanded: it carries a "$ref" (e.g. "#/components/schemas/PermissionResource") pointing to the schema describing it instead.
 
 Related: jf api docs search, jf api, jf api --ai-help`
 }
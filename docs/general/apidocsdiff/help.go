@@ -0,0 +1,49 @@
+package apidocsdiff
+
+var Usage = []string{"api docs diff <spec-a> <spec-b> [command options]"}
+
+func GetDescription() string {
+	return "Compare two OpenAPI specs and report the operations added and removed, and the changes of the request (required parameters and request-body properties) of the operations in both. Exits with a non-zero code when any change is breaking, so it can gate a platform or CLI upgrade in CI. Local and offline: no server configuration or network call is involved."
+}
+
+func GetArguments() string {
+	return `	spec-a
+		The old spec: a spec file, a directory of spec files, or 'embedded' for the spec bundle embedded in this jf binary.
+
+	spec-b
+		The new spec, in the same forms as spec-a.
+
+EXAMPLES
+  # What changed between the embedded bundle and the spec cached by 'jf api docs sync'
+  $ jf api docs diff embedded ~/.jfrog/api-specs/synced
+
+  # Two exported spec files, as a table
+  $ jf api docs diff platform-7.90.yaml platform-7.100.yaml --format table
+
+OUTPUT
+  JSON by default; pass --format table for a human-readable table instead. The result includes each spec's "location" and operation count, "breaking_changes", and the "added", "removed" and "changed" operations. Each changed operation lists its "changes", with a "kind" (parameter-added, parameter-removed, parameter-required, path-parameter-renamed, request-body-added, property-added, property-removed, property-required, property-type-changed, ...), the parameter ("in:name") or property (dotted for nested fields) "name", a "detail" and whether it's "breaking".
+
+BREAKING CHANGES
+  A removed operation, a removed parameter or request-body property, a new required parameter or property, a parameter, property or request body that became required, and a property whose type changed. A renamed path placeholder (e.g. {repoKey} to {key}) isn't breaking: the operations are still matched. Responses and descriptions aren't compared.`
+}
+
+func GetAIDescription() string {
+	return `Compare two OpenAPI specs (spec files, directories of spec files, or 'embedded' for the bundle in this jf binary) and report added/removed operations and request changes, failing with a non-zero exit code on breaking changes.
+
+When to use:
+- Before upgrading the platform or jf, to check whether the API calls a script depends on changed.
+- In CI, to gate an upgrade on the absence of breaking changes.
+
+Prerequisites: none. This command is fully local/offline; run 'jf api docs sync' first to diff against the configured platform's spec.
+
+Common patterns:
+  $ jf api docs diff embedded ~/.jfrog/api-specs/synced
+  $ jf api docs diff old.yaml new.yaml --format table
+
+Gotchas:
+- Operations are matched by method and path, so a renamed path shows as one removed and one added operation. Renaming a path placeholder (e.g. {repoKey} to {key}) only reports a non-breaking path-parameter-renamed change.
+- Only requests are compared: response and description changes aren't reported.
+- A spec file that fails to parse is an error, not skipped.
+
+Related: jf api docs sync, jf api docs describe`
+}
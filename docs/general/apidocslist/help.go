@@ -0,0 +1,41 @@
+package apidocslist
+
+var Usage = []string{"api docs list [--tag <tag>] [--format table|json]"}
+
+func GetDescription() string {
+	return "List the OpenAPI operations known to this jf binary, optionally only those of one tag, with each operation's method, path, summary and operationId. Local and offline: no server configuration or network call is involved."
+}
+
+func GetArguments() string {
+	return `	None.
+
+EXAMPLES
+  # List the operations of a tag, as printed by 'jf api docs tags'
+  $ jf api docs list --tag Users
+
+  # The whole catalog, as a table
+  $ jf api docs list --format table
+
+OUTPUT
+  JSON by default; pass --format table for a human-readable table instead. The result includes the spec bundle searched and its "spec_sources", the "tag" filter, a "total" count and an "operations" list sorted by path then method, each with "method", "path", "summary" and "operation_id". Pass an operation's method and path to 'jf api docs describe' for its full shape. An unknown tag is an error.`
+}
+
+func GetAIDescription() string {
+	return `List the OpenAPI operations known to this jf binary (method, path, summary, operationId), optionally only those carrying one tag. Use it to browse an area of the API when you don't have a search keyword.
+
+When to use:
+- After 'jf api docs tags', to enumerate every operation of an area.
+- To get the exact method and path to pass to 'jf api docs describe'.
+
+Prerequisites: none. This command is fully local/offline.
+
+Common patterns:
+  $ jf api docs list --tag "Access Tokens"
+  $ jf api docs list --format table
+
+Gotchas:
+- --tag takes an exact tag name (case-insensitive), unlike 'jf api docs search --tag', which matches part of a tag name.
+- Without --tag, the whole catalog is listed, which can be long for a full bundle.
+
+Related: jf api docs tags, jf api docs describe, jf api docs search`
+}
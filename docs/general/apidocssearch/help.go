@@ -1,6 +1,6 @@
 package apidocssearch
 
-var Usage = []string{"api docs search <query> [--tag <product>] [--method <http-method>] [--limit <n>] [--format table|json]"}
+var Usage = []string{"api docs search <query> [--tag <product>] [--method <http-method>] [--limit <n>] [--explain] [--format table|json]"}
 
 func GetDescription() string {
 	return "Search the OpenAPI operations embedded in this jf binary for a keyword, and print ranked matches with a ready-to-run 'jf api' command for each. Local and offline: no server configuration or network call is involved."
@@ -8,7 +8,7 @@ func GetDescription() string {
 
 func GetArguments() string {
 	return `	query
-		Keyword to search for. Matched (case-insensitive, contains or fuzzy) against each operation's path, summary, tags, and operationId.
+		Keywords to search for. Ranked (BM25, case-insensitive, stemmed, with partial words matching the words they start) against each operation's operationId, path, summary, tags, description, parameters, request-body properties and responses, in decreasing order of weight. An operation matching none of them falls back to fuzzy (typo-tolerant) matching.
 
 EXAMPLES
   # Find operations related to users
@@ -23,11 +23,14 @@ EXAMPLES
   # Cap the number of results
   $ jf api docs search repository --limit 3
 
+  # Show why each operation matched
+  $ jf api docs search retention --explain --format table
+
   # Human-readable table instead of the default JSON
   $ jf api docs search user --format table
 
 OUTPUT
-  JSON by default (this command exists primarily for agent consumption); pass --format table for a human-readable table instead. Each match includes the operation's method, path, summary, tags, a relevance score, and a "jf_api" field with a ready-to-run 'jf api' invocation for that operation. When the operation takes path/query parameters, they're listed under "parameters" (required ones marked). When it takes a JSON request body, its top-level fields (name, type, required, description, default) are listed under "request_body", and "jf_api" already includes a minimal -d '{...}' skeleton covering just the required fields — fill in real values before running it. Table view shows this as compact PARAMS/BODY columns ("*" marks a required field). An empty result set still reports which spec bundle was searched (spec_bundle) — a "stub" bundle may simply be missing the operation. Exits 0 even when no matches are found. "total_matches" and "truncated" report the full match count and whether --limit cut it down; when truncated, a warning is also printed to stderr (not stdout, so it never corrupts the JSON body or table).`
+  JSON by default (this command exists primarily for agent consumption); pass --format table for a human-readable table instead. Each match includes the operation's method, path, summary, tags, a relevance score (100 for the best match), and a "jf_api" field with a ready-to-run 'jf api' invocation for that operation. When the operation takes path/query parameters, they're listed under "parameters" (required ones marked). When it takes a JSON request body, its fields (name, type, required, description, default) are listed under "request_body", and "jf_api" already includes a -d '{...}' payload: the spec's declared example, or else a minimal skeleton synthesized from the schema covering just the required fields — "jf_api_data" says which ("declared" or "synthesized"). Fill in real values before running it. Table view shows this as compact PARAMS/BODY columns ("*" marks a required field). With --explain, each match also lists under "explanation" (a WHY column in table view) the score of each query term in each field it matched: "term", the indexed word it "matched" when that's a stem or a longer word, "field" (operation_id, path, summary, tag, description, parameter, property, response, or fuzzy) and "score". An empty result set still reports which spec bundle was searched (spec_bundle) — a "stub" bundle may simply be missing the operation. Exits 0 even when no matches are found. "total_matches" and "truncated" report the full match count and whether --limit cut it down; when truncated, a warning is also printed to stderr (not stdout, so it never corrupts the JSON body or table).`
 }
 
 func GetAIDescription() string {
@@ -44,13 +47,15 @@ Common patterns:
   $ jf api docs search user
   $ jf api docs search token --tag Users --method GET
   $ jf api docs search repository --limit 3 --format json
+  $ jf api docs search "cron retention" --explain
 
 Gotchas:
 - The embedded spec bundle may be a small "stub" subset in this build, not the full JFrog REST API surface. An empty match list includes spec_bundle so you know whether that's the likely cause.
 - Output is JSON by default (unconditionally, unlike most other jf commands' --ai-help-gated JSON defaults); pass --format table for a human-readable table instead.
 - Filters (--tag, --method) are hard excludes, applied before ranking/scoring.
-- A query with no contains-match anywhere falls back to fuzzy (typo-tolerant) matching, gated by a similarity floor to avoid coincidental false positives (e.g. "evidence" vs "environments"). Advanced: override the floor (0-1, default 0.6) with $JFROG_CLI_API_DOCS_SEARCH_FUZZY_MIN.
-- A match's "jf_api" one-liner only fills in required request-body fields with type-appropriate placeholders (e.g. "" for string, false for boolean) -- inspect the full "request_body"/"parameters" fields for optional ones, descriptions, and defaults before running it for real.
+- Every word of the query counts, and operations are ranked by how many of them they match and where: a word found only in a request-body property or response description still matches, but ranks below one found in the path or summary. Pass --explain to see why each operation matched.
+- An operation matching no query word falls back to fuzzy (typo-tolerant) matching, gated by a similarity floor to avoid coincidental false positives (e.g. "evidence" vs "environments"). Advanced: override the floor (0-1, default 0.6) with $JFROG_CLI_API_DOCS_SEARCH_FUZZY_MIN.
+- When "jf_api_data" is "synthesized", the "jf_api" one-liner only fills in required request-body fields (nested ones included) with their default, their first enum value, or a type-appropriate placeholder (e.g. "" for string, false for boolean, [] for an array) -- inspect the full "request_body"/"parameters" fields for optional ones, descriptions, and defaults before running it for real.
 - A request body property that is itself a nested object is reported by its type name (e.g. "PermissionResource") or "object" rather than being recursively flattened -- only top-level fields are listed.
 - Results are capped at --limit (default 10). Check "truncated"/"total_matches" in the JSON body if you need to know whether more results exist -- the truncation warning goes to stderr, which you may not be capturing.
 
@@ -0,0 +1,48 @@
+package apidocssync
+
+var Usage = []string{"api docs sync [command options]"}
+
+func GetDescription() string {
+	return "Download an OpenAPI spec served by the configured JFrog Platform, at the path given with --spec-path, and cache it, so that 'jf api docs search', 'describe' and 'validate' also cover the endpoints of that platform's version, including newer or custom ones missing from the spec bundle embedded in this jf binary."
+}
+
+func GetArguments() string {
+	return `	None.
+
+EXAMPLES
+  # Cache the spec served by the default configured server
+  $ jf api docs sync --spec-path /my/api/openapi.yaml
+
+  # Cache the spec served by another configured server
+  $ jf api docs sync --server-id my-server --spec-path /my/api/openapi.yaml
+
+OUTPUT
+  A single line on standard error with the number of operations cached, the spec's version (its info.version) and the cache directory. Later 'jf api docs' commands list the synced spec under "spec_sources" in their JSON output.
+
+SPEC SOURCES
+  The catalog merges, from lowest to highest precedence: the embedded bundle, the spec cached by 'jf api docs sync' (in <JFrog home>/api-specs/synced), and the *.yaml OpenAPI files in $JFROG_CLI_API_SPEC_DIR or, when it's not set, <JFrog home>/api-specs. An operation with the same method and path as a lower-precedence one replaces it.`
+}
+
+func GetAIDescription() string {
+	return `Download an OpenAPI spec served by the configured JFrog Platform, at the path given with --spec-path, and cache it locally, extending the catalog used by 'jf api docs search', 'jf api docs describe' and 'jf api docs validate' with that platform's own endpoints.
+
+When to use:
+- 'jf api docs search' doesn't find an endpoint that the platform is known to have (newer platform version, workers, plugins).
+- Before validating requests against a specific platform version.
+
+Prerequisites:
+- A configured server (jf c add or jf login) or explicit --url / --access-token / --server-id.
+- The path of the OpenAPI document served by the platform, passed with --spec-path: there's no default path.
+
+Common patterns:
+  $ jf api docs sync --spec-path /my/api/openapi.yaml
+  $ jf api docs sync --server-id my-server --spec-path /my/api/openapi.yaml
+
+Gotchas:
+- The spec must be a single OpenAPI document (YAML or JSON): a bundle of several files referring to each other isn't supported.
+- Only one synced spec is cached at a time; syncing again (from any server) replaces it. A download that can't be parsed leaves the previous cache in place.
+- Hand-written spec files can be dropped in $JFROG_CLI_API_SPEC_DIR (or <JFrog home>/api-specs) instead; they take precedence over both the synced and the embedded specs.
+- The "spec_sources" field of 'jf api docs search' and 'describe' shows which sources are active and how many operations each contributed.
+
+Related: jf api docs search, jf api docs describe, jf api docs validate`
+}
@@ -0,0 +1,42 @@
+package apidocstags
+
+var Usage = []string{"api docs tags [--format table|json]"}
+
+func GetDescription() string {
+	return "List the tags (product areas such as \"Users\" or \"Access Tokens\") that group the OpenAPI operations known to this jf binary, with each tag's operation count and description. Local and offline: no server configuration or network call is involved."
+}
+
+func GetArguments() string {
+	return `	None.
+
+EXAMPLES
+  # List every tag as JSON
+  $ jf api docs tags
+
+  # Human-readable table instead of the default JSON
+  $ jf api docs tags --format table
+
+  # Then list a tag's operations
+  $ jf api docs list --tag "Access Tokens" --format table
+
+OUTPUT
+  JSON by default; pass --format table for a human-readable table instead. The result includes the spec bundle searched and its "spec_sources", and a "tags" list sorted by name, each with "name", "operations" (how many operations carry the tag) and "description" when a spec declares one.`
+}
+
+func GetAIDescription() string {
+	return `List the tags (product areas) of the OpenAPI operations known to this jf binary, with an operation count and description for each. The starting point for browsing the catalog when you don't have a search keyword yet.
+
+When to use:
+- To discover which areas of the JFrog Platform API the catalog covers, before 'jf api docs list --tag <tag>'.
+
+Prerequisites: none. This command is fully local/offline.
+
+Common patterns:
+  $ jf api docs tags
+  $ jf api docs tags --format table
+
+Gotchas:
+- The embedded bundle may be a small "stub" subset (see spec_bundle); 'jf api docs sync' adds the configured platform's own operations and tags.
+
+Related: jf api docs list, jf api docs search, jf api docs describe`
+}
@@ -0,0 +1,52 @@
+package apidocsvalidate
+
+var Usage = []string{"api docs validate <method> <path> [--data <json> | --input <file>] [--format table|json]"}
+
+func GetDescription() string {
+	return "Check a request against the OpenAPI operations embedded in this jf binary, without sending it: the path must match a catalog operation, required query parameters must be present, and a JSON body must have the operation's required properties with the declared types. Local and offline: no server configuration or network call is involved."
+}
+
+func GetArguments() string {
+	return `	method
+		HTTP method of the request (GET, POST, PUT, DELETE, ...). Case-insensitive.
+
+	path
+		Concrete request path, as it would be passed to 'jf api', optionally with a query string. Templated catalog segments (e.g. {workerKey}) are matched against the path's own values.
+
+EXAMPLES
+  # Check a request body before creating a user
+  $ jf api docs validate POST /access/api/v2/users -d '{"username":"newuser","email":"newuser@example.com"}'
+
+  # Check a body stored in a file
+  $ jf api docs validate POST /access/api/v2/users --input ./user.json
+
+  # A concrete path is matched against the catalog's {param} templates
+  $ jf api docs validate DELETE /worker/api/v1/workers/my-worker
+
+  # Human-readable table instead of the default JSON
+  $ jf api docs validate POST /access/api/v2/users --input ./user.json --format table
+
+OUTPUT
+  JSON by default; pass --format table for a human-readable table instead. The result includes the request's method and path, the matched catalog "operation" path, "valid", and an "issues" list, each with "in" (path, query or body), "name" and "message". The command exits non-zero when any issue is found, or when no catalog operation matches the method and path.`
+}
+
+func GetAIDescription() string {
+	return `Check a 'jf api' request (method, path, query string and JSON body) against the OpenAPI operations embedded in this jf binary, without sending it. Reports missing required body properties, wrongly typed primitive values, missing required query parameters and unreplaced {param} placeholders.
+
+When to use:
+- Before a POST/PUT/PATCH call, to catch a malformed payload locally instead of from an HTTP 400.
+- To check a generated payload in a script or agent loop without touching the server.
+
+Prerequisites: none. This command is fully local/offline — no server configuration, credentials, or network call.
+
+Common patterns:
+  $ jf api docs validate POST /access/api/v2/users -d '{"username":"u","email":"u@example.com"}'
+  $ jf api docs validate POST /access/api/v2/users --input ./user.json --format table
+
+Gotchas:
+- The same checks run in 'jf api <path> --validate', which then sends the request only if it passed.
+- Only top-level body properties are checked; nested objects, unknown properties and null values are accepted as is.
+- An operation missing from the embedded bundle (spec_bundle may be a small "stub" subset) is a hard error rather than a pass.
+
+Related: jf api docs describe, jf api docs search, jf api --validate`
+}
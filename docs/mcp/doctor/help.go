@@ -0,0 +1,54 @@
+package doctor
+
+var Usage = []string{"mcp doctor"}
+
+func GetDescription() string {
+	return "Diagnose the connection of AI agents to the JFrog MCP server, and print a pass/fail checklist."
+}
+
+func GetAIDescription() string {
+	return `Diagnose, end to end, why an AI agent can't connect to the JFrog MCP server. The checks run in order and each one reports pass, warn, fail or skip, with a fix for warnings and failures:
+- MCP endpoint URL: the endpoint resolves the same way as in 'jf mcp show'.
+- Proxy: the HTTP(S)_PROXY and NO_PROXY variables that apply to the endpoint.
+- DNS: the endpoint's host name resolves.
+- TLS: the handshake succeeds, using the configured client certificate, and the server certificate is trusted and not about to expire.
+- MCP endpoint: the endpoint answers, without a 404 or a server error.
+- OAuth protected resource metadata: the endpoint advertises its authorization server at /.well-known/oauth-protected-resource.
+- OAuth authorization server metadata: the authorization server publishes its endpoints, PKCE S256 support and dynamic client registration.
+- Agent configuration: every agent config file that exists parses and contains the JFrog entry, pointing at the endpoint.
+
+When to use:
+- An agent reports that the JFrog MCP server failed to connect or to authorize.
+- Verifying a machine's setup after 'jf mcp install'.
+
+Prerequisites:
+- A configured JFrog Platform server (jf c add or jf login), or --mcp-url.
+
+Common patterns:
+  $ jf mcp doctor
+  $ jf mcp doctor --agent=cursor
+  $ jf mcp doctor --format=json
+
+Gotchas:
+- The command exits with an error when a check fails, so scripts can rely on its exit code. Warnings don't fail it.
+- Without --agent, only the agent config files that exist are checked. With --agent, a missing entry fails.
+- The network checks run from this machine, with jf's proxy and TLS settings. An agent configured with other settings can still fail.
+
+Related: jf mcp show, jf mcp list, jf mcp install`
+}
+
+func GetArguments() string {
+	return `	EXAMPLES
+  # Check the connection to the current server's MCP endpoint, and every agent config
+  $ jf mcp doctor
+
+  # Check only Cursor's configuration
+  $ jf mcp doctor --agent cursor
+
+  # Print the checklist as JSON
+  $ jf mcp doctor --format json
+
+NOTES
+  Each check reports pass, warn, fail or skip. The command fails when any
+  check fails.`
+}
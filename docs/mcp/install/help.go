@@ -1,33 +1,40 @@
 package install
 
-var Usage = []string{"mcp install --agent <cursor|claude>"}
+var Usage = []string{"mcp install --agent <cursor|claude|vscode|windsurf|gemini|codex|zed|jetbrains>"}
 
 func GetDescription() string {
-	return "Configure an AI agent (cursor or claude) to connect to the remote JFrog MCP server."
+	return "Configure an AI agent (Cursor, Claude Code, VS Code, Windsurf, Gemini CLI, Codex, Zed or JetBrains AI) to connect to the remote JFrog MCP server."
 }
 
 func GetAIDescription() string {
-	return `Add the remote JFrog MCP server to an AI agent's configuration so the agent can call JFrog Platform tools. Writes the MCP entry for Cursor or Claude Code; authentication is completed via OAuth in the agent itself, so no credentials are written to the agent config.
+	return `Add the remote JFrog MCP server to an AI agent's configuration so the agent can call JFrog Platform tools. Writes the MCP entry in the agent's own config file, key and format (for example 'servers' in .vscode/mcp.json for VS Code, or a TOML [mcp_servers] table for Codex); authentication is completed via OAuth in the agent itself, so no credentials are written to the agent config.
 
 When to use:
-- Onboarding Cursor or Claude Code to the JFrog Platform MCP integration.
+- Onboarding Cursor, Claude Code, VS Code, Windsurf, Gemini CLI, Codex, Zed or JetBrains AI to the JFrog Platform MCP integration.
 - Adding the MCP server at project scope (default) or user scope (--global).
+- Registering the local MCP server ('jf mcp serve --stdio') with --local, for agents that can't reach the remote endpoint.
 
 Prerequisites:
 - A configured JFrog Platform server (jf c add or jf login), or pass --server-id / --url.
-- The target agent (Cursor or Claude Code) installed locally.
+- The target agent installed locally.
 
 Common patterns:
   $ jf mcp install --agent=cursor
   $ jf mcp install --agent=claude --global
+  $ jf mcp install --agent=vscode
+  $ jf mcp install --agent=windsurf --global
   $ jf mcp install --agent=cursor --dry-run
+  $ jf mcp install --agent=claude --local
 
 Gotchas:
 - Before writing config, the command verifies the MCP server is reachable; pass --skip-check to bypass that probe.
+- With --local, the agent launches this jf executable with 'mcp serve --stdio' (and --server-id, if passed), which uses the JFrog CLI server credentials: there is no readiness check and no OAuth step.
 - After installing, complete the OAuth authorization in the agent (e.g. run /mcp in Claude Code, or approve the server in Cursor) to activate the connection.
-- Default scope is the current project; --global writes the user-level agent config instead.
+- Default scope is the current project; --global writes the user-level agent config instead. Windsurf has no project-level config, so it requires --global.
+- Only the JFrog entry is edited in place: comments, trailing commas and key order in the rest of the agent config are kept. Before a global config is modified, it is backed up to <file>.<timestamp>.bak.
+- Run 'jf mcp show --agent=<agent>' to see which file, key and entry would be written.
 
-Related: jf mcp show, jf mcp uninstall`
+Related: jf mcp show, jf mcp list, jf mcp uninstall`
 }
 
 func GetArguments() string {
@@ -38,6 +45,15 @@ func GetArguments() string {
   # Configure Claude Code globally (user-level)
   $ jf mcp install --agent claude --global
 
+  # Configure VS Code (Copilot) for the current project
+  $ jf mcp install --agent vscode
+
+  # Configure Codex globally, in ~/.codex/config.toml
+  $ jf mcp install --agent codex --global
+
+  # Register the local MCP server in Claude Code, for the 'prod' server
+  $ jf mcp install --agent claude --local --server-id prod
+
   # Preview the configuration without writing it
   $ jf mcp install --agent cursor --dry-run
 
@@ -45,6 +61,14 @@ NOTES
   Before writing any configuration, the command verifies that the MCP server is
   reachable. Use --skip-check to bypass this verification.
 
+  Supported agents: cursor, claude, vscode, windsurf (global only), gemini,
+  codex, zed and jetbrains. Each agent's entry is written to its own config
+  file, under its own key and in its own format (JSON, JSONC or TOML).
+
+  Only the JFrog entry of the config file is edited; comments and formatting
+  elsewhere are preserved. A global config file is first backed up to
+  <file>.<timestamp>.bak.
+
   Authentication is completed via OAuth in the agent itself; no credentials are
   written to the agent configuration. After installation, complete the OAuth
   authorization as required by the agent (for example, run /mcp in Claude Code,
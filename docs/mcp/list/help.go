@@ -0,0 +1,46 @@
+package list
+
+var Usage = []string{"mcp list"}
+
+func GetDescription() string {
+	return "List the JFrog MCP server entries configured in every supported AI agent, at project and global scope."
+}
+
+func GetAIDescription() string {
+	return `List the JFrog MCP server entries found in the project and global configuration of every supported AI agent (Cursor, Claude Code, VS Code, Windsurf, Gemini CLI, Codex, Zed and JetBrains AI). For each entry, report the agent, scope, config file, entry name and MCP URL, and whether the URL is the current server's MCP endpoint (as 'jf mcp show' resolves it), another configured server's, or no configured server's (stale).
+
+When to use:
+- Auditing which agent configs on the machine point at which JFrog MCP endpoint.
+- Finding stale entries left behind after a platform URL changed, or duplicate entries an agent would load twice.
+
+Prerequisites:
+- None to list entries. A configured JFrog Platform server (jf c add or jf login) is needed to tell current entries from stale ones.
+
+Common patterns:
+  $ jf mcp list
+  $ jf mcp list --format=json
+  $ jf mcp list --project-dir=../other-repo
+
+Gotchas:
+- An entry is listed when it is named after JFrog or points at the MCP endpoint of a configured server; other MCP servers are ignored.
+- An entry is a duplicate when the same agent has more than one JFrog entry across its project and global configs.
+- Config files that fail to parse are skipped with a warning.
+
+Related: jf mcp install, jf mcp uninstall, jf mcp show`
+}
+
+func GetArguments() string {
+	return `	EXAMPLES
+  # List the JFrog MCP entries of the current project and the user's agents
+  $ jf mcp list
+
+  # List them as JSON
+  $ jf mcp list --format json
+
+NOTES
+  Each entry's status is 'current' when it points at the current server's MCP
+  endpoint, 'server <id>' when it points at another configured server's, and
+  'stale' when it points at no configured server's. Entries are flagged
+  'duplicate' when the same agent has more than one JFrog entry across its
+  project and global configs.`
+}
@@ -0,0 +1,47 @@
+package serve
+
+var Usage = []string{"mcp serve --stdio"}
+
+func GetDescription() string {
+	return "Run a local MCP server over stdio that exposes JFrog CLI commands as tools."
+}
+
+func GetAIDescription() string {
+	return `Run a local MCP (Model Context Protocol) server that speaks JSON-RPC over stdin and stdout, for AI agents that can launch a local process but can't reach the platform's remote /mcp endpoint (air-gapped runners, proxies that strip server-sent events). The agent launches it; it isn't meant to be run by hand.
+
+Tools:
+- api_request: call a JFrog Platform REST API endpoint, as 'jf api' does.
+- api_docs_search / api_docs_describe: search and describe the embedded REST API catalog, as 'jf api docs search/describe' do.
+- rt_search: search Artifactory for artifacts by wildcard pattern, as 'jf rt search' does.
+- build_info: get a published build-info, or list a build's runs.
+
+When to use:
+- Registered in an agent by 'jf mcp install --agent <agent> --local', which writes the command line that launches it.
+
+Prerequisites:
+- A configured JFrog Platform server (jf c add or jf login). The tools use its credentials; pass --server-id to use another than the default.
+
+Common patterns:
+  $ jf mcp serve --stdio
+  $ jf mcp serve --stdio --server-id=my-platform
+
+Gotchas:
+- Stdout carries the protocol; logs go to stderr.
+- The server configuration is never offered interactively, since stdin carries the protocol: configure a server first.
+
+Related: jf mcp install, jf api`
+}
+
+func GetArguments() string {
+	return `	EXAMPLES
+  # Serve the JFrog tools over stdio, with the default server configuration
+  $ jf mcp serve --stdio
+
+  # Register the local server in Cursor instead of running it by hand
+  $ jf mcp install --agent cursor --local
+
+NOTES
+  The server exposes the api_request, api_docs_search, api_docs_describe,
+  rt_search and build_info tools, running with the credentials of the JFrog
+  CLI server configuration. Stdout carries the protocol; logs go to stderr.`
+}
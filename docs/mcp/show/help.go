@@ -12,6 +12,7 @@ func GetAIDescription() string {
 When to use:
 - Looking up the MCP endpoint to configure an agent manually (rather than via 'jf mcp install').
 - Verifying which platform / server the MCP integration resolves to before installing.
+- Checking which file, key and entry 'jf mcp install' writes for an agent (--agent).
 
 Prerequisites:
 - A configured JFrog Platform server (jf c add or jf login), or pass --server-id / --url.
@@ -19,6 +20,7 @@ Prerequisites:
 Common patterns:
   $ jf mcp show
   $ jf mcp show --server-id=my-platform --format=json
+  $ jf mcp show --agent=vscode --global
 
 Gotchas:
 - The endpoint defaults to <platform-url>/mcp; override it with --mcp-url or the JFROG_CLI_MCP_URL environment variable.
@@ -35,6 +37,9 @@ func GetArguments() string {
   # Print the MCP endpoint for a specific server, as JSON
   $ jf mcp show --server-id my-platform --format json
 
+  # Show where and how the entry is written for Codex, at user level
+  $ jf mcp show --agent codex --global
+
 NOTES
   The endpoint is derived as <platform-url>/mcp. It can be overridden with the
   --mcp-url flag or the JFROG_CLI_MCP_URL environment variable.
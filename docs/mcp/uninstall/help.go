@@ -1,28 +1,30 @@
 package uninstall
 
-var Usage = []string{"mcp uninstall --agent <cursor|claude>"}
+var Usage = []string{"mcp uninstall --agent <cursor|claude|vscode|windsurf|gemini|codex|zed|jetbrains>"}
 
 func GetDescription() string {
 	return "Remove the JFrog MCP server entry previously added to an AI agent's configuration."
 }
 
 func GetAIDescription() string {
-	return `Remove the JFrog MCP server entry previously added to an AI agent's configuration (Cursor or Claude Code). Only the JFrog entry is removed; other configured MCP servers are left untouched.
+	return `Remove the JFrog MCP server entry previously added to an AI agent's configuration (Cursor, Claude Code, VS Code, Windsurf, Gemini CLI, Codex, Zed or JetBrains AI). Only the JFrog entry is removed; other configured MCP servers are left untouched.
 
 When to use:
 - Disconnecting an agent from the JFrog Platform MCP integration.
 - Cleaning up before re-installing against a different platform or scope.
 
 Prerequisites:
-- The agent (Cursor or Claude Code) with a JFrog MCP entry previously added by 'jf mcp install'.
+- An agent with a JFrog MCP entry previously added by 'jf mcp install'.
 
 Common patterns:
   $ jf mcp uninstall --agent=cursor
   $ jf mcp uninstall --agent=claude --global
+  $ jf mcp uninstall --agent=codex
 
 Gotchas:
 - Match the scope used at install time: a --global install is removed with --global; a project install is removed from the project config.
 - If the entry was installed under a non-default name, pass --name to target it.
+- The entry is removed in place, keeping the rest of the file's comments and formatting. A global config is first backed up to <file>.<timestamp>.bak.
 
 Related: jf mcp install, jf mcp show`
 }
@@ -35,6 +37,9 @@ func GetArguments() string {
   # Remove it from Claude Code's global configuration
   $ jf mcp uninstall --agent claude --global
 
+  # Remove it from the project's .vscode/mcp.json
+  $ jf mcp uninstall --agent vscode
+
 NOTES
   Only the JFrog MCP server entry is removed; other configured MCP servers are
   left untouched. Use --name to target an entry written under a non-default name.`
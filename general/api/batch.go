@@ -0,0 +1,302 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	commonCliUtils "github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
+	coreconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-cli/utils/usage"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	flagThreads  = "threads"
+	flagFailFast = "fail-fast"
+)
+
+// batchRequest is a single request descriptor of a `jf api batch` file.
+type batchRequest struct {
+	ID      string            `json:"id,omitempty" yaml:"id"`
+	Method  string            `json:"method,omitempty" yaml:"method"`
+	Path    string            `json:"path" yaml:"path"`
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers"`
+	// Body is sent verbatim when it's a string, and as JSON otherwise.
+	Body any `json:"body,omitempty" yaml:"body"`
+}
+
+// batchResult is the NDJSON line written for each request of a batch.
+type batchResult struct {
+	// Index is the request's 1-based position in the batch file.
+	Index      int    `json:"index"`
+	ID         string `json:"id,omitempty"`
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	Status     int    `json:"status,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+	// Body holds a JSON response body as is, and any other body as a string.
+	Body  json.RawMessage `json:"body,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+func (r *batchResult) failed() bool {
+	return r.Error != "" || isErrorStatus(r.Status)
+}
+
+// BatchCommand implements `jf api batch <file>`: it runs every request of an
+// NDJSON or YAML file over a single HTTP client, with a bounded pool of
+// workers, and writes one NDJSON result line per request.
+func BatchCommand(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	serverDetails, err := cliutils.CreateServerDetailsWithConfigOffer(c, true, commonCliUtils.Platform)
+	if err != nil {
+		return err
+	}
+	var flagsUsed []string
+	for _, f := range c.Command.Flags {
+		if name := f.GetName(); c.IsSet(name) {
+			flagsUsed = append(flagsUsed, name)
+		}
+	}
+	return runBatchCmd(c, serverDetails, os.Stdout, flagsUsed)
+}
+
+func runBatchCmd(c commandContext, serverDetails *coreconfig.ServerDetails, stdOut io.Writer, flagsUsed []string) error {
+	if serverDetails.GetUrl() == "" {
+		return errorutils.CheckErrorf("no JFrog Platform URL specified, either via the --url flag or as part of the server configuration")
+	}
+	threads, err := commonCliUtils.GetThreadsCount(c.String(flagThreads))
+	if err != nil {
+		return err
+	}
+	if threads < 1 {
+		return errorutils.CheckErrorf("--threads must be a positive number, got %d", threads)
+	}
+	requests, err := readBatchFile(c.Args().First())
+	if err != nil {
+		return err
+	}
+
+	waitUsageReport := usage.StartReport("jf api batch", flagsUsed, serverDetails)
+	defer usage.WaitForReport("jf api batch", waitUsageReport, usage.DefaultReportTimeout)
+
+	authDetails, err := serverDetails.CreateAccessAuthConfig()
+	if err != nil {
+		return err
+	}
+	baseDetails := authDetails.CreateHttpClientDetails()
+
+	// Cancelled on the first failure with --fail-fast: requests that haven't
+	// started yet are skipped, and those in flight are aborted.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, err := newPlatformHttpClient(ctx, serverDetails, 0, false)
+	if err != nil {
+		return err
+	}
+	ex := &exchanger{ctx: ctx, client: client}
+	failFast := c.Bool(flagFailFast)
+
+	var (
+		mu       sync.Mutex
+		writeErr error
+		done     int
+		failures int
+	)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(threads, len(requests)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result := runBatchRequest(ex, serverDetails.GetUrl(), i+1, requests[i], &baseDetails)
+				line, err := json.Marshal(result)
+				mu.Lock()
+				done++
+				if result.failed() {
+					failures++
+					if failFast {
+						cancel()
+					}
+				}
+				if err == nil {
+					_, err = stdOut.Write(append(line, '\n'))
+				}
+				if err != nil && writeErr == nil {
+					writeErr = errorutils.CheckError(err)
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range requests {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if writeErr != nil {
+		return writeErr
+	}
+	if failures > 0 {
+		skipped := ""
+		if done < len(requests) {
+			skipped = fmt.Sprintf(" (%d skipped by --fail-fast)", len(requests)-done)
+		}
+		return errorutils.CheckErrorf("%d of %d batch requests failed%s", failures, len(requests), skipped)
+	}
+	log.Info(fmt.Sprintf("jf api batch: all %d requests succeeded.", len(requests)))
+	return nil
+}
+
+func runBatchRequest(ex *exchanger, platformURL string, index int, req batchRequest, baseDetails *httputils.HttpClientDetails) *batchResult {
+	method := strings.ToUpper(strings.TrimSpace(req.Method))
+	if method == "" {
+		method = http.MethodGet
+	}
+	result := &batchResult{Index: index, ID: req.ID, Method: method, Path: req.Path}
+	fullURL, err := joinPlatformAPIURL(platformURL, req.Path)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	body, err := batchRequestBody(req.Body)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	details := baseDetails.Clone()
+	for k, v := range req.Headers {
+		details.AddHeader(k, v)
+	}
+
+	start := time.Now()
+	resp, respBody, err := ex.send(method, fullURL, body, details)
+	result.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Status = resp.StatusCode
+	result.Body = batchResultBody(respBody)
+	return result
+}
+
+func batchRequestBody(body any) ([]byte, error) {
+	switch b := body.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(b), nil
+	default:
+		data, err := json.Marshal(b)
+		return data, errorutils.CheckError(err)
+	}
+}
+
+// batchResultBody keeps a JSON body as is, compacted to fit on the result's
+// line, and quotes any other body as a JSON string.
+func batchResultBody(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	var compacted bytes.Buffer
+	if json.Valid(body) && json.Compact(&compacted, body) == nil {
+		return compacted.Bytes()
+	}
+	quoted, err := json.Marshal(string(body))
+	if err != nil {
+		return nil
+	}
+	return quoted
+}
+
+// readBatchFile reads the request descriptors of a batch file: a YAML list of
+// requests for a .yaml or .yml file, and one JSON request per line otherwise
+// (blank lines are skipped). "-" reads NDJSON from standard input.
+func readBatchFile(path string) ([]batchRequest, error) {
+	var requests []batchRequest
+	var err error
+	if path == "-" {
+		requests, err = parseBatchNdjson(os.Stdin)
+	} else {
+		var f *os.File
+		if f, err = os.Open(path); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml":
+			requests, err = parseBatchYaml(f)
+		default:
+			requests, err = parseBatchNdjson(f)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(requests) == 0 {
+		return nil, errorutils.CheckErrorf("no requests found in %s", path)
+	}
+	for i, req := range requests {
+		if strings.TrimSpace(req.Path) == "" {
+			return nil, errorutils.CheckErrorf("request %d in %s has no path", i+1, path)
+		}
+	}
+	return requests, nil
+}
+
+func parseBatchNdjson(r io.Reader) ([]batchRequest, error) {
+	var requests []batchRequest
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.UseNumber()
+		var req batchRequest
+		if err := decoder.Decode(&req); err != nil {
+			return nil, errorutils.CheckErrorf("line %d of the batch file is not a valid request: %s", line, err.Error())
+		}
+		requests = append(requests, req)
+	}
+	return requests, errorutils.CheckError(scanner.Err())
+}
+
+func parseBatchYaml(r io.Reader) ([]batchRequest, error) {
+	var requests []batchRequest
+	if err := yaml.NewDecoder(r).Decode(&requests); err != nil && err != io.EOF {
+		return nil, errorutils.CheckErrorf("the batch file is not a valid YAML list of requests: %s", err.Error())
+	}
+	return requests, nil
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeBatchFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestReadBatchFile_Ndjson(t *testing.T) {
+	path := writeBatchFile(t, "requests.ndjson", `{"id":"a","method":"put","path":"/x","headers":{"Content-Type":"application/json"},"body":{"n":1.50}}
+
+{"path":"/y","body":"raw text"}
+`)
+	requests, err := readBatchFile(path)
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+	assert.Equal(t, "a", requests[0].ID)
+	assert.Equal(t, map[string]string{"Content-Type": "application/json"}, requests[0].Headers)
+
+	body, err := batchRequestBody(requests[0].Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"n":1.50}`, string(body), "numbers are sent exactly as written")
+
+	body, err = batchRequestBody(requests[1].Body)
+	require.NoError(t, err)
+	assert.Equal(t, "raw text", string(body))
+}
+
+func TestReadBatchFile_Yaml(t *testing.T) {
+	path := writeBatchFile(t, "requests.yml", `
+- method: POST
+  path: /access/api/v2/users
+  body:
+    username: newuser
+    groups: [readers]
+- path: /artifactory/api/repositories
+`)
+	requests, err := readBatchFile(path)
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+	body, err := batchRequestBody(requests[0].Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"username":"newuser","groups":["readers"]}`, string(body))
+}
+
+func TestReadBatchFile_Invalid(t *testing.T) {
+	_, err := readBatchFile(writeBatchFile(t, "bad.ndjson", "{\"path\":\"/x\"}\nnot json\n"))
+	assert.ErrorContains(t, err, "line 2")
+
+	_, err = readBatchFile(writeBatchFile(t, "nopath.ndjson", `{"method":"GET"}`))
+	assert.ErrorContains(t, err, "request 1")
+
+	_, err = readBatchFile(writeBatchFile(t, "empty.yaml", ""))
+	assert.ErrorContains(t, err, "no requests")
+}
+
+func TestBatchResultBody(t *testing.T) {
+	assert.Nil(t, batchResultBody(nil))
+	assert.Equal(t, `{"a":[1,2]}`, string(batchResultBody([]byte("{\n  \"a\": [1, 2]\n}"))))
+	assert.Equal(t, `"plain \"text\""`, string(batchResultBody([]byte(`plain "text"`))))
+}
+
+func readBatchResults(t *testing.T, out []byte) []batchResult {
+	t.Helper()
+	var results []batchResult
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		var r batchResult
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r), scanner.Text())
+		results = append(results, r)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Index < results[j].Index })
+	return results
+}
+
+func TestApiBatch(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(t, "Bearer my-token", r.Header.Get("Authorization"))
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("not found"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"method":"` + r.Method + `","echo":` + jsonQuote(string(body)) + `,"h":"` + r.Header.Get("X-Test") + `"}`))
+	}))
+	t.Cleanup(srv.Close)
+	serverDetails := &coreConfig.ServerDetails{Url: srv.URL, AccessToken: "my-token"}
+
+	var lines []string
+	for i := 0; i < 6; i++ {
+		lines = append(lines, `{"id":"ok","method":"POST","path":"/ok","headers":{"X-Test":"yes"},"body":{"n":1}}`)
+	}
+	lines = append(lines, `{"id":"missing","path":"/missing"}`)
+	path := writeBatchFile(t, "requests.ndjson", strings.Join(lines, "\n"))
+
+	ctx := newMockContext(&commandArgs{path: path})
+	ctx.setString(flagThreads, "3")
+	var stdOut bytes.Buffer
+	err := runBatchCmd(ctx, serverDetails, &stdOut, nil)
+	assert.ErrorContains(t, err, "1 of 7 batch requests failed")
+
+	results := readBatchResults(t, stdOut.Bytes())
+	require.Len(t, results, 7)
+	for i, r := range results[:6] {
+		assert.Equal(t, i+1, r.Index)
+		assert.Equal(t, http.StatusOK, r.Status)
+		assert.JSONEq(t, `{"method":"POST","echo":"{\"n\":1}","h":"yes"}`, string(r.Body))
+	}
+	assert.Equal(t, "missing", results[6].ID)
+	assert.Equal(t, "GET", results[6].Method)
+	assert.Equal(t, http.StatusNotFound, results[6].Status)
+	assert.Equal(t, `"not found"`, string(results[6].Body))
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3), "no more than --threads requests run at once")
+	assert.Greater(t, maxInFlight.Load(), int32(1), "requests run concurrently")
+}
+
+func TestApiBatch_FailFast(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	t.Cleanup(srv.Close)
+	serverDetails := &coreConfig.ServerDetails{Url: srv.URL, AccessToken: "my-token"}
+
+	path := writeBatchFile(t, "requests.ndjson", strings.Repeat("{\"path\":\"/bad\"}\n", 20))
+	ctx := newMockContext(&commandArgs{path: path})
+	ctx.setString(flagThreads, "1")
+	ctx.setBool(flagFailFast, true)
+	var stdOut bytes.Buffer
+	err := runBatchCmd(ctx, serverDetails, &stdOut, nil)
+	assert.ErrorContains(t, err, "skipped by --fail-fast")
+	assert.Less(t, calls.Load(), int32(20))
+}
+
+func TestApiBatch_InvalidThreads(t *testing.T) {
+	serverDetails := newTestServer(t)
+	ctx := newMockContext(&commandArgs{path: writeBatchFile(t, "r.ndjson", `{"path":"/x"}`)})
+	ctx.setString(flagThreads, "0")
+	var stdOut bytes.Buffer
+	assert.ErrorContains(t, runBatchCmd(ctx, serverDetails, &stdOut, nil), "--threads")
+}
+
+func jsonQuote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
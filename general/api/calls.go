@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	coreconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The functions below give other commands, such as the tools of the local MCP
+// server ('jf mcp serve'), jf api's requests and catalog lookups, with their
+// results returned rather than printed.
+
+// requestTimeout bounds each call of Request. The local MCP server answers one
+// message at a time, so a platform request that never completes would block
+// it, pings included.
+const requestTimeout = time.Minute
+
+// SearchDocs returns the JSON result of 'jf api docs search query', with the
+// limit best matches.
+func SearchDocs(query, tag, method string, limit int) ([]byte, error) {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	result, err := searchDocs(query, tag, method, limit, false)
+	if err != nil {
+		return nil, err
+	}
+	return marshalResult(result)
+}
+
+// DescribeOperation returns the JSON result of 'jf api docs describe method
+// path'.
+func DescribeOperation(method, path string) ([]byte, error) {
+	result, err := describeOperation(method, normalizeApiPath(path))
+	if err != nil {
+		return nil, err
+	}
+	return marshalResult(result)
+}
+
+// Request sends an authenticated request to the platform, as 'jf api' does,
+// and returns the response's status code and body. A body is sent as JSON.
+// The request fails when it takes longer than requestTimeout.
+func Request(ctx context.Context, serverDetails *coreconfig.ServerDetails, method, path string, body []byte) (int, []byte, error) {
+	if serverDetails.GetUrl() == "" {
+		return 0, nil, errorutils.CheckErrorf("no JFrog Platform URL specified, either via the --url flag or as part of the server configuration")
+	}
+	fullURL, err := joinPlatformAPIURL(serverDetails.GetUrl(), path)
+	if err != nil {
+		return 0, nil, err
+	}
+	authDetails, err := serverDetails.CreateAccessAuthConfig()
+	if err != nil {
+		return 0, nil, err
+	}
+	details := authDetails.CreateHttpClientDetails()
+	if len(body) > 0 && !hasHeaderFold(details.Headers, "Content-Type") {
+		if details.Headers == nil {
+			details.Headers = make(map[string]string)
+		}
+		details.Headers["Content-Type"] = "application/json"
+	}
+	client, err := newPlatformHttpClient(ctx, serverDetails, requestTimeout, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	ex := &exchanger{ctx: ctx, client: client}
+	resp, respBody, err := ex.exchange(method, fullURL, body, &details)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, respBody, nil
+}
+
+func marshalResult(result any) ([]byte, error) {
+	data, err := json.MarshalIndent(result, "", "  ")
+	return data, errorutils.CheckError(err)
+}
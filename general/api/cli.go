@@ -1,9 +1,12 @@
 package api
 
 import (
+	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -38,7 +41,8 @@ const (
 
 // Command runs an authenticated HTTP request against the configured JFrog Platform base URL.
 func Command(c *cli.Context) error {
-	if c.NArg() != 1 {
+	// With --op, the endpoint path comes from the catalog instead.
+	if c.NArg() != 1 && (c.String(flagOp) == "" || c.NArg() != 0) {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
 	}
 
@@ -67,29 +71,99 @@ func runApiCmd(c commandContext, serverDetails *coreconfig.ServerDetails, stdOut
 	waitUsageReport := usage.StartReport("jf api", flagsUsed, serverDetails)
 	defer usage.WaitForReport("jf api", waitUsageReport, timeout)
 
-	pathArg := c.Args().First()
+	method, pathArg, err := requestTarget(c)
+	if err != nil {
+		return err
+	}
 	fullURL, err := joinPlatformAPIURL(serverDetails.GetUrl(), pathArg)
 	if err != nil {
 		return err
 	}
 
-	method := httpMethodOrDefault(c)
-	body, err := resolveRequestBody(c)
-	if err != nil {
+	if err = validateStreamFlags(c); err != nil {
 		return err
 	}
+	var body []byte
+	if !streamed(c) {
+		if body, err = resolveRequestBody(c); err != nil {
+			return err
+		}
+	}
+	if c.Bool(flagValidate) {
+		result, err := validateRequest(method, pathArg, body)
+		if err != nil {
+			return err
+		}
+		if !result.Valid {
+			return errorutils.CheckErrorf("%s", result.report())
+		}
+		log.Debug("jf api: the request matches", result.Method, result.Operation, "in the embedded OpenAPI spec bundle.")
+	}
 
 	details, err := buildRequestDetails(serverDetails, c)
 	if err != nil {
 		return err
 	}
+	exportAs, err := exportFormat(c)
+	if err != nil {
+		return err
+	}
+	if exportAs != "" {
+		return writeExport(stdOut, exportAs, newExportedRequest(method, fullURL, body, details, serverDetails.InsecureTls))
+	}
+
+	if err = validatePaginateFlags(c); err != nil {
+		return err
+	}
+	paginate := c.Bool(flagPaginate)
+	retry, err := newRetryPolicy(c, method)
+	if err != nil {
+		return err
+	}
+	filter, err := newOutputFilter(c)
+	if err != nil {
+		return err
+	}
+	recorder, replayer, err := newCassette(c)
+	if err != nil {
+		return err
+	}
 
-	client, err := newPlatformHttpClient(serverDetails, timeout)
+	// With --paginate, --timeout bounds the whole walk rather than each page:
+	// the deadline is carried by the client's context, so a page requested
+	// after it has passed fails immediately instead of starting a new round trip.
+	ctx := context.Background()
+	if paginate && timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	client, err := newPlatformHttpClient(ctx, serverDetails, timeout, retry.enabled())
 	if err != nil {
 		return err
 	}
+	ex := &exchanger{ctx: ctx, client: client, verbose: c.Bool(flagVerbose), retry: retry, recorder: recorder, replayer: replayer}
 
-	return exchangeAndPrint(client, c, method, fullURL, body, details, stdOut)
+	if streamed(c) {
+		streamBody, err := newStreamBody(c)
+		if err != nil {
+			return err
+		}
+		return streamAndPrint(ex, method, fullURL, streamBody, details, c.String(flagOutput), stdOut)
+	}
+	if paginate {
+		err = paginateAndPrint(ex, c, method, fullURL, body, details, filter, stdOut)
+	} else {
+		err = exchangeAndPrint(ex, method, fullURL, body, details, filter, stdOut)
+	}
+	// The exchanges are recorded even when the last one failed: that's often
+	// the one worth capturing.
+	if recorder != nil {
+		if flushErr := recorder.flush(); err == nil {
+			err = flushErr
+		}
+	}
+	return err
 }
 
 func httpMethodOrDefault(c commandContext) string {
@@ -113,41 +187,118 @@ func buildRequestDetails(serverDetails *coreconfig.ServerDetails, c commandConte
 	return details, nil
 }
 
-func newPlatformHttpClient(serverDetails *coreconfig.ServerDetails, timeout time.Duration) (*httpclient.HttpClient, error) {
+// newPlatformHttpClient builds the client shared by every request of a jf api
+// invocation. When jf api runs its own retry loop (--retries), the client's
+// built-in retries are disabled so that each attempt is made, waited for and
+// logged exactly once.
+func newPlatformHttpClient(ctx context.Context, serverDetails *coreconfig.ServerDetails, timeout time.Duration, ownRetries bool) (*httpclient.HttpClient, error) {
 	builder := httpclient.ClientBuilder().
+		SetContext(ctx).
 		SetInsecureTls(serverDetails.InsecureTls).
 		SetClientCertPath(serverDetails.ClientCertPath).
 		SetClientCertKeyPath(serverDetails.ClientCertKeyPath)
 	if timeout > 0 {
 		builder = builder.SetOverallRequestTimeout(timeout)
 	}
+	if ownRetries {
+		builder = builder.SetRetries(0)
+	}
 	return builder.Build()
 }
 
-func exchangeAndPrint(client *httpclient.HttpClient, c commandContext, method, fullURL string, body []byte, details *httputils.HttpClientDetails, stdOut io.Writer) error {
-	if c.Bool(flagVerbose) {
+// exchanger sends the requests of a single jf api invocation over one client,
+// applying the --retries policy and the --verbose logging to every attempt.
+// With --record, every attempt is also added to the recorder; with --replay,
+// responses come from the replayer and nothing is sent.
+type exchanger struct {
+	ctx      context.Context
+	client   *httpclient.HttpClient
+	verbose  bool
+	retry    retryPolicy
+	recorder *harRecorder
+	replayer *harReplayer
+}
+
+func exchangeAndPrint(ex *exchanger, method, fullURL string, body []byte, details *httputils.HttpClientDetails, filter *outputFilter, stdOut io.Writer) error {
+	resp, respBody, err := ex.exchange(method, fullURL, body, details)
+	if err != nil {
+		return err
+	}
+	return printResponse(resp, respBody, method, fullURL, filter, stdOut)
+}
+
+// exchange sends a request, retrying it as allowed by the retry policy, and
+// returns the final response together with its fully read body. It logs the
+// status line (and, under --verbose, the full request and response of every
+// attempt) but leaves writing the body to the caller, so a paginated walk can
+// decide per page whether to print, merge or stop.
+func (ex *exchanger) exchange(method, fullURL string, body []byte, details *httputils.HttpClientDetails) (*http.Response, []byte, error) {
+	for attempt := 1; ; attempt++ {
+		resp, respBody, err := ex.send(method, fullURL, body, details)
+		wait, retry := ex.retry.next(ex.ctx, attempt, resp, err)
+		if !retry {
+			if err != nil {
+				log.Error("jf api: request failed:", err)
+				return nil, nil, err
+			}
+			log.Info("Http Status:", resp.StatusCode)
+			return resp, respBody, nil
+		}
+		log.Warn(fmt.Sprintf("jf api: attempt %d of %d %s, retrying in %s", attempt, ex.retry.retries+1, attemptOutcome(resp, err), wait))
+		if err = sleepContext(ex.ctx, wait); err != nil {
+			return nil, nil, errorutils.CheckError(err)
+		}
+	}
+}
+
+// send makes a single attempt.
+func (ex *exchanger) send(method, fullURL string, body []byte, details *httputils.HttpClientDetails) (*http.Response, []byte, error) {
+	if ex.verbose {
 		writeVerboseRequest(method, fullURL, details)
 	}
 
-	resp, respBody, _, err := client.Send(method, fullURL, body, true, true, *details, "")
+	if ex.replayer != nil {
+		resp, respBody, err := ex.replayer.respond(method, fullURL)
+		if err == nil && ex.verbose {
+			writeVerboseResponse(resp, respBody)
+		}
+		return resp, respBody, err
+	}
+
+	started := time.Now()
+	resp, respBody, _, err := ex.client.Send(method, fullURL, body, true, true, *details, "")
 	if err != nil {
-		log.Error("jf api: request failed:", err)
-		return err
+		return nil, nil, err
 	}
 	if resp == nil {
-		return errorutils.CheckErrorf("empty response from server")
+		return nil, nil, errorutils.CheckErrorf("empty response from server")
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
-	if c.Bool(flagVerbose) {
+	if ex.verbose {
 		writeVerboseResponse(resp, respBody)
 	}
+	if ex.recorder != nil {
+		ex.recorder.add(started, time.Since(started), method, fullURL, body, details, resp, respBody)
+	}
+	return resp, respBody, nil
+}
 
-	log.Info("Http Status:", resp.StatusCode)
+func isErrorStatus(statusCode int) bool {
+	return statusCode < 200 || statusCode > 399
+}
 
-	isError := resp.StatusCode < 200 || resp.StatusCode > 399
+func printResponse(resp *http.Response, respBody []byte, method, fullURL string, filter *outputFilter, stdOut io.Writer) error {
+	isError := isErrorStatus(resp.StatusCode)
+	if !isError {
+		filtered, err := filter.render(respBody, false)
+		if err != nil {
+			return err
+		}
+		respBody = filtered
+	}
 
 	// Opt-in (JFROG_CLI_ERROR_OUTPUT_FORMAT=json, or --format=json auto-promote):
 	// for HTTP error responses, emit the response as a structured JSON object on
@@ -162,13 +313,8 @@ func exchangeAndPrint(client *httpclient.HttpClient, c commandContext, method, f
 		return cli.NewExitError("", 1)
 	}
 
-	if _, err = stdOut.Write(respBody); err != nil {
-		return errorutils.CheckError(err)
-	}
-	if len(respBody) == 0 || respBody[len(respBody)-1] != '\n' {
-		if _, err = stdOut.Write([]byte("\n")); err != nil {
-			return errorutils.CheckError(err)
-		}
+	if err := writeBody(stdOut, respBody); err != nil {
+		return err
 	}
 
 	if isError {
@@ -180,6 +326,20 @@ func exchangeAndPrint(client *httpclient.HttpClient, c commandContext, method, f
 	return nil
 }
 
+// writeBody writes body to stdOut, followed by a newline unless body already
+// ends with one.
+func writeBody(stdOut io.Writer, body []byte) error {
+	if _, err := stdOut.Write(body); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if len(body) == 0 || body[len(body)-1] != '\n' {
+		if _, err := stdOut.Write([]byte("\n")); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
+
 func joinPlatformAPIURL(platformBase, path string) (string, error) {
 	base := strings.TrimSuffix(strings.TrimSpace(platformBase), "/")
 	p := strings.TrimSpace(path)
@@ -280,17 +440,33 @@ func hasHeaderFold(h map[string]string, name string) bool {
 	return false
 }
 
+// sensitiveHeaders are the request headers, besides Authorization, whose
+// values are credentials whatever their names suggest.
+var sensitiveHeaders = []string{"cookie", "proxy-authorization", "x-jfrog-art-api"}
+
+// isSensitiveHeader reports whether a request header other than Authorization
+// carries a credential, such as a cookie, an API key or an access token.
+func isSensitiveHeader(name string) bool {
+	lower := strings.ToLower(name)
+	return slices.Contains(sensitiveHeaders, lower) || strings.HasSuffix(lower, "-api-key") || isHarSensitiveName(lower)
+}
+
+// redactHeaders hides the value of the Authorization header but for its
+// scheme, and the values of the other sensitive headers entirely.
 func redactHeaders(h map[string]string) map[string]string {
 	if len(h) == 0 {
 		return nil
 	}
 	out := make(map[string]string, len(h))
 	for k, v := range h {
-		if strings.EqualFold(k, "Authorization") {
+		switch {
+		case strings.EqualFold(k, "Authorization"):
 			out[k] = redactedAuthValue(v)
-			continue
+		case isSensitiveHeader(k) && v != "":
+			out[k] = "***"
+		default:
+			out[k] = v
 		}
-		out[k] = v
 	}
 	return out
 }
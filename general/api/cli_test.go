@@ -602,11 +602,29 @@ func TestApiDispatch_BareDocsRoutesToSubcommand(t *testing.T) {
 }
 
 type commandArgs struct {
-	path    string
-	method  string
-	headers map[string]string
-	verbose bool
-	timeout int
+	path     string
+	method   string
+	data     string
+	headers  map[string]string
+	verbose  bool
+	timeout  int
+	paginate bool
+	ndjson   bool
+	maxPages int
+	retries  int
+	// retryWait is passed verbatim as --retry-wait, e.g. "1ms".
+	retryWait       string
+	retryAllMethods bool
+	query           string
+	raw             bool
+	validate        bool
+	export          string
+	record          string
+	replay          string
+	form            []string
+	output          string
+	op              string
+	params          []string
 }
 
 type mockContext struct {
@@ -633,6 +651,9 @@ func newMockContext(cmdArgs *commandArgs) *mockContext {
 	if cmdArgs.method != "" {
 		mc.setString(flagMethod, cmdArgs.method)
 	}
+	if cmdArgs.data != "" {
+		mc.setString(flagData, cmdArgs.data)
+	}
 	if cmdArgs.headers != nil {
 		var headers []string
 		for k, v := range cmdArgs.headers {
@@ -646,6 +667,54 @@ func newMockContext(cmdArgs *commandArgs) *mockContext {
 	if cmdArgs.timeout > 0 {
 		mc.setInt(flagTimeout, cmdArgs.timeout)
 	}
+	if cmdArgs.paginate {
+		mc.setBool(flagPaginate, true)
+	}
+	if cmdArgs.ndjson {
+		mc.setBool(flagNdjson, true)
+	}
+	if cmdArgs.maxPages != 0 {
+		mc.setInt(flagMaxPages, cmdArgs.maxPages)
+	}
+	if cmdArgs.retries > 0 {
+		mc.setInt(flagRetries, cmdArgs.retries)
+	}
+	if cmdArgs.retryWait != "" {
+		mc.setString(flagRetryWait, cmdArgs.retryWait)
+	}
+	if cmdArgs.retryAllMethods {
+		mc.setBool(flagRetryAllMethods, true)
+	}
+	if cmdArgs.query != "" {
+		mc.setString(flagQuery, cmdArgs.query)
+	}
+	if cmdArgs.raw {
+		mc.setBool(flagRaw, true)
+	}
+	if cmdArgs.validate {
+		mc.setBool(flagValidate, true)
+	}
+	if cmdArgs.export != "" {
+		mc.setString(flagExport, cmdArgs.export)
+	}
+	if cmdArgs.record != "" {
+		mc.setString(flagRecord, cmdArgs.record)
+	}
+	if cmdArgs.replay != "" {
+		mc.setString(flagReplay, cmdArgs.replay)
+	}
+	if cmdArgs.form != nil {
+		mc.setStringSlice(flagForm, cmdArgs.form)
+	}
+	if cmdArgs.output != "" {
+		mc.setString(flagOutput, cmdArgs.output)
+	}
+	if cmdArgs.op != "" {
+		mc.setString(flagOp, cmdArgs.op)
+	}
+	if cmdArgs.params != nil {
+		mc.setStringSlice(flagParam, cmdArgs.params)
+	}
 	return mc
 }
 
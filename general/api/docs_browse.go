@@ -0,0 +1,159 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	commonCliUtils "github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
+	coreformat "github.com/jfrog/jfrog-cli-core/v2/common/format"
+	apispec "github.com/jfrog/jfrog-cli/docs/api-spec"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+// tagsResult is the JSON/table rendering payload for `jf api docs tags`.
+type tagsResult struct {
+	SpecBundle  string           `json:"spec_bundle"`
+	SpecVersion string           `json:"spec_version"`
+	SpecSources []apispec.Source `json:"spec_sources"`
+	Tags        []apispec.Tag    `json:"tags"`
+}
+
+// listedOperation is a single operation of `jf api docs list`: just enough to
+// pick one and pass its method and path to `jf api docs describe`.
+type listedOperation struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	Summary     string `json:"summary,omitempty"`
+	OperationId string `json:"operation_id,omitempty"`
+}
+
+// listResult is the JSON/table rendering payload for `jf api docs list`.
+type listResult struct {
+	SpecBundle  string            `json:"spec_bundle"`
+	SpecVersion string            `json:"spec_version"`
+	SpecSources []apispec.Source  `json:"spec_sources"`
+	Tag         string            `json:"tag,omitempty"`
+	Total       int               `json:"total"`
+	Operations  []listedOperation `json:"operations"`
+}
+
+// TagsCommand implements `jf api docs tags`: every tag of the catalog with
+// its operation count and description, as the entry point for browsing the
+// catalog with `jf api docs list --tag`.
+func TagsCommand(c *cli.Context) error {
+	return runTagsCmd(c, os.Stdout)
+}
+
+func runTagsCmd(c *cli.Context, stdOut io.Writer) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	tags, err := apispec.Tags()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	info := apispec.Info()
+	result := tagsResult{SpecBundle: info.SpecBundle, SpecVersion: info.SpecVersion, SpecSources: info.Sources, Tags: tags}
+
+	outputFormat, err := commonCliUtils.GetOutputFormat(c, coreformat.Json)
+	if err != nil {
+		return err
+	}
+	switch outputFormat {
+	case coreformat.Json:
+		return renderBrowseJSON("tags", result)
+	case coreformat.Table:
+		tw := tabwriter.NewWriter(stdOut, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "TAG\tOPERATIONS\tDESCRIPTION")
+		for _, t := range result.Tags {
+			_, _ = fmt.Fprintf(tw, "%s\t%d\t%s\n", t.Name, t.Operations, firstLine(t.Description))
+		}
+		return tw.Flush()
+	default:
+		return errorutils.CheckErrorf("unsupported format '%s' for api docs tags. Accepted values: table, json", outputFormat)
+	}
+}
+
+// ListCommand implements `jf api docs list [--tag <tag>]`: the operations of
+// the catalog, or of a single tag, in catalog order (by path, then method).
+func ListCommand(c *cli.Context) error {
+	return runListCmd(c, os.Stdout)
+}
+
+func runListCmd(c *cli.Context, stdOut io.Writer) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	ops, err := apispec.Operations()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	tag := strings.TrimSpace(c.String(flagTag))
+	info := apispec.Info()
+	result := listResult{SpecBundle: info.SpecBundle, SpecVersion: info.SpecVersion, SpecSources: info.Sources, Tag: tag, Operations: []listedOperation{}}
+	for _, op := range ops {
+		if tag != "" && !hasTagFold(op.Tags, tag) {
+			continue
+		}
+		result.Operations = append(result.Operations, listedOperation{Method: op.Method, Path: op.Path, Summary: op.Summary, OperationId: op.OperationId})
+	}
+	result.Total = len(result.Operations)
+	if tag != "" && result.Total == 0 {
+		return errorutils.CheckErrorf("no operations are tagged %q in the %q OpenAPI spec bundle. Run 'jf api docs tags' to list the available tags.", tag, info.SpecBundle)
+	}
+
+	outputFormat, err := commonCliUtils.GetOutputFormat(c, coreformat.Json)
+	if err != nil {
+		return err
+	}
+	switch outputFormat {
+	case coreformat.Json:
+		return renderBrowseJSON("list", result)
+	case coreformat.Table:
+		tw := tabwriter.NewWriter(stdOut, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, "METHOD\tPATH\tOPERATION ID\tSUMMARY")
+		for _, op := range result.Operations {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", op.Method, op.Path, op.OperationId, op.Summary)
+		}
+		return tw.Flush()
+	default:
+		return errorutils.CheckErrorf("unsupported format '%s' for api docs list. Accepted values: table, json", outputFormat)
+	}
+}
+
+// hasTagFold reports whether tags holds tag, case-insensitively. Unlike
+// search's --tag (see hasTag), list takes an exact tag name, as printed by
+// `jf api docs tags`.
+func hasTagFold(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstLine keeps a multi-line description on a single table row.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
+
+// renderBrowseJSON writes result as indented JSON via the shared client
+// logger -- same pattern as renderJSON in docs_search.go.
+func renderBrowseJSON(command string, result any) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return errorutils.CheckErrorf("failed to marshal api docs %s result: %s", command, err.Error())
+	}
+	log.Output(clientUtils.IndentJson(data))
+	return nil
+}
@@ -0,0 +1,125 @@
+//go:build !full
+
+// These tests assert values from the stub fixtures and don't apply to a full
+// build -- same rationale as docs_describe_test.go.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	clientlog "github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+// newBrowseApp builds a minimal cli.App running run with the flag set of the
+// "tags" and "list" subcommands -- same technique as newDescribeApp.
+func newBrowseApp(run func(*cli.Context, *bytes.Buffer) error, stdOut *bytes.Buffer, capturedErr *error) *cli.App {
+	app := cli.NewApp()
+	app.Flags = []cli.Flag{
+		cli.StringFlag{Name: "format"},
+		cli.StringFlag{Name: "tag"},
+	}
+	app.Action = func(c *cli.Context) error {
+		*capturedErr = run(c, stdOut)
+		return nil
+	}
+	return app
+}
+
+func runTags(c *cli.Context, stdOut *bytes.Buffer) error { return runTagsCmd(c, stdOut) }
+func runList(c *cli.Context, stdOut *bytes.Buffer) error { return runListCmd(c, stdOut) }
+
+// runBrowseJSON runs args and decodes the JSON written to the logger.
+func runBrowseJSON(t *testing.T, run func(*cli.Context, *bytes.Buffer) error, args ...string) map[string]any {
+	t.Helper()
+	var out bytes.Buffer
+	prevLogger := clientlog.GetLogger()
+	t.Cleanup(func() { clientlog.SetLogger(prevLogger) })
+	clientlog.SetLogger(clientlog.NewLoggerWithFlags(clientlog.INFO, &out, 0))
+
+	var stdOut bytes.Buffer
+	var runErr error
+	require.NoError(t, newBrowseApp(run, &stdOut, &runErr).Run(append([]string{"cmd"}, args...)))
+	require.NoError(t, runErr)
+
+	var result map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	return result
+}
+
+func TestRunTagsCmd_JSON(t *testing.T) {
+	result := runBrowseJSON(t, runTags)
+	assert.Equal(t, "stub", result["spec_bundle"])
+	tags, ok := result["tags"].([]any)
+	require.True(t, ok)
+	var names []string
+	for _, tag := range tags {
+		entry := tag.(map[string]any)
+		names = append(names, entry["name"].(string))
+		if entry["name"] == "Users" {
+			assert.Positive(t, entry["operations"])
+			assert.NotEmpty(t, entry["description"])
+		}
+	}
+	assert.Contains(t, names, "Users")
+	assert.Contains(t, names, "Access Tokens")
+}
+
+func TestRunTagsCmd_Table(t *testing.T) {
+	var stdOut bytes.Buffer
+	var runErr error
+	require.NoError(t, newBrowseApp(runTags, &stdOut, &runErr).Run([]string{"cmd", "--format", "table"}))
+	require.NoError(t, runErr)
+	assert.Contains(t, stdOut.String(), "TAG")
+	assert.Contains(t, stdOut.String(), "Access Tokens")
+}
+
+func TestRunListCmd_ByTag(t *testing.T) {
+	result := runBrowseJSON(t, runList, "--tag", "users")
+	assert.Equal(t, "users", result["tag"])
+	ops, ok := result["operations"].([]any)
+	require.True(t, ok)
+	require.NotEmpty(t, ops)
+	assert.Equal(t, float64(len(ops)), result["total"])
+	for _, op := range ops {
+		assert.Contains(t, op.(map[string]any)["path"], "/users", "--tag should only keep the tag's operations")
+	}
+}
+
+func TestRunListCmd_All(t *testing.T) {
+	all := runBrowseJSON(t, runList)
+	users := runBrowseJSON(t, runList, "--tag", "Users")
+	assert.Greater(t, all["total"], users["total"])
+	assert.Nil(t, all["tag"])
+}
+
+func TestRunListCmd_Table(t *testing.T) {
+	var stdOut bytes.Buffer
+	var runErr error
+	require.NoError(t, newBrowseApp(runList, &stdOut, &runErr).Run([]string{"cmd", "--tag", "Users", "--format", "table"}))
+	require.NoError(t, runErr)
+	assert.Contains(t, stdOut.String(), "METHOD")
+	assert.Contains(t, stdOut.String(), "/access/api/v2/users")
+}
+
+func TestRunListCmd_UnknownTag(t *testing.T) {
+	var stdOut bytes.Buffer
+	var runErr error
+	require.NoError(t, newBrowseApp(runList, &stdOut, &runErr).Run([]string{"cmd", "--tag", "No Such Tag"}))
+	require.Error(t, runErr)
+	assert.Contains(t, runErr.Error(), "jf api docs tags")
+}
+
+func TestRunBrowseCmds_RejectArguments(t *testing.T) {
+	for _, run := range []func(*cli.Context, *bytes.Buffer) error{runTags, runList} {
+		var stdOut bytes.Buffer
+		var runErr error
+		require.NoError(t, newBrowseApp(run, &stdOut, &runErr).Run([]string{"cmd", "extra"}))
+		assert.Error(t, runErr)
+	}
+}
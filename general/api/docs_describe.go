@@ -22,6 +22,7 @@ import (
 type describeResult struct {
 	SpecBundle  string               `json:"spec_bundle"`
 	SpecVersion string               `json:"spec_version"`
+	SpecSources []apispec.Source     `json:"spec_sources"`
 	Method      string               `json:"method"`
 	Path        string               `json:"path"`
 	Summary     string               `json:"summary,omitempty"`
@@ -30,6 +31,7 @@ type describeResult struct {
 	RequestBody *apispec.RequestBody `json:"request_body,omitempty"`
 	Responses   []apispec.Response   `json:"responses,omitempty"`
 	JfApi       string               `json:"jf_api"`
+	JfApiData   string               `json:"jf_api_data,omitempty"`
 }
 
 // DescribeCommand implements `jf api docs describe <method> <path>`. It returns
@@ -51,10 +53,34 @@ func runDescribeCmd(c *cli.Context, stdOut io.Writer) error {
 	method := c.Args().Get(0)
 	path := normalizeApiPath(c.Args().Get(1))
 
+	result, err := describeOperation(method, path)
+	if err != nil {
+		return err
+	}
+
+	// JSON is the unconditional default -- this command exists primarily for
+	// agent consumption, matching `jf api docs search`'s convention.
+	outputFormat, err := commonCliUtils.GetOutputFormat(c, coreformat.Json)
+	if err != nil {
+		return err
+	}
+
+	switch outputFormat {
+	case coreformat.Json:
+		return renderDescribeJSON(result)
+	case coreformat.Table:
+		return renderDescribeTable(result, stdOut)
+	default:
+		return errorutils.CheckErrorf("unsupported format '%s' for api docs describe. Accepted values: table, json", outputFormat)
+	}
+}
+
+// describeOperation looks up the catalog operation of method and path.
+func describeOperation(method, path string) (describeResult, error) {
 	info := apispec.Info()
 	op, ok := apispec.FindOperation(method, path)
 	if !ok {
-		return errorutils.CheckErrorf(
+		return describeResult{}, errorutils.CheckErrorf(
 			"no operation found for %s %s in the embedded %q OpenAPI spec bundle. "+
 				"Run 'jf api docs search <query>' to find the exact method/path first -- "+
 				"the bundle may be incomplete (see spec_bundle), or the path may need to "+
@@ -65,6 +91,7 @@ func runDescribeCmd(c *cli.Context, stdOut io.Writer) error {
 	result := describeResult{
 		SpecBundle:  info.SpecBundle,
 		SpecVersion: info.SpecVersion,
+		SpecSources: info.Sources,
 		Method:      op.Method,
 		Path:        op.Path,
 		Summary:     op.Summary,
@@ -73,23 +100,9 @@ func runDescribeCmd(c *cli.Context, stdOut io.Writer) error {
 		RequestBody: op.RequestBody,
 		Responses:   op.Responses,
 		JfApi:       jfApiOneLiner(op),
+		JfApiData:   jfApiData(op),
 	}
-
-	// JSON is the unconditional default -- this command exists primarily for
-	// agent consumption, matching `jf api docs search`'s convention.
-	outputFormat, err := commonCliUtils.GetOutputFormat(c, coreformat.Json)
-	if err != nil {
-		return err
-	}
-
-	switch outputFormat {
-	case coreformat.Json:
-		return renderDescribeJSON(result)
-	case coreformat.Table:
-		return renderDescribeTable(result, stdOut)
-	default:
-		return errorutils.CheckErrorf("unsupported format '%s' for api docs describe. Accepted values: table, json", outputFormat)
-	}
+	return result, nil
 }
 
 // normalizeApiPath prepends a leading "/" when missing, same convention as
@@ -125,10 +138,26 @@ func renderDescribeTable(result describeResult, w io.Writer) error {
 	_, _ = fmt.Fprintf(tw, "TAGS\t%s\n", strings.Join(result.Tags, ","))
 	_, _ = fmt.Fprintf(tw, "PARAMETERS\t%s\n", formatParams(result.Parameters))
 	_, _ = fmt.Fprintf(tw, "REQUEST BODY\t%s\n", formatRequestBody(result.RequestBody))
-	if result.RequestBody != nil && len(result.RequestBody.Example) > 0 {
-		_, _ = fmt.Fprintf(tw, "REQUEST BODY EXAMPLE\t%s\n", string(result.RequestBody.Example))
+	if result.RequestBody != nil {
+		if strings.HasPrefix(result.RequestBody.Schema, "array<") {
+			_, _ = fmt.Fprintf(tw, "REQUEST BODY TYPE\t%s\n", result.RequestBody.Schema)
+		}
+		writeSchemaRows(tw, "REQUEST BODY SCHEMA", result.RequestBody.Properties)
+		if payload, kind := result.RequestBody.Payload(); payload != nil {
+			label := "REQUEST BODY EXAMPLE"
+			if kind == apispec.ExampleSynthesized {
+				label += " (synthesized)"
+			}
+			_, _ = fmt.Fprintf(tw, "%s\t%s\n", label, string(payload))
+		}
 	}
 	_, _ = fmt.Fprintf(tw, "RESPONSES\t%s\n", formatResponses(result.Responses))
+	for _, r := range result.Responses {
+		if r.Schema != "" {
+			_, _ = fmt.Fprintf(tw, "RESPONSE %s\t%s\n", r.Code, r.Schema)
+			writeSchemaRows(tw, "", r.Properties)
+		}
+	}
 	_, _ = fmt.Fprintf(tw, "JF API\t%s\n", result.JfApi)
 	return tw.Flush()
 }
@@ -149,3 +178,27 @@ func formatResponses(responses []apispec.Response) string {
 	}
 	return strings.Join(parts, ", ")
 }
+
+// writeSchemaRows writes a property tree as indented "name: type" rows, the
+// first one labeled with label. Required fields are marked with "*", and a
+// field that isn't expanded points to its schema's $ref.
+func writeSchemaRows(w io.Writer, label string, properties []apispec.Property) {
+	for _, line := range formatSchemaTree(properties, 0) {
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", label, line)
+		label = ""
+	}
+}
+
+func formatSchemaTree(properties []apispec.Property, depth int) []string {
+	var lines []string
+	indent := strings.Repeat("  ", depth)
+	for _, p := range properties {
+		line := indent + requiredMark(p.Name, p.Required) + ": " + p.Type
+		if p.Ref != "" {
+			line += " (see " + p.Ref + ")"
+		}
+		lines = append(lines, line)
+		lines = append(lines, formatSchemaTree(p.Properties, depth+1)...)
+	}
+	return lines
+}
@@ -77,6 +77,8 @@ func TestRunDescribeCmd_KnownPostOperation(t *testing.T) {
 	require.True(t, ok)
 	assert.Contains(t, jfApi, "-X POST")
 	assert.Contains(t, jfApi, "-d '")
+	assert.Equal(t, "synthesized", result["jf_api_data"], "createUser declares no example")
+	assert.NotEmpty(t, requestBody["synthesized_example"])
 }
 
 func TestRunDescribeCmd_CaseInsensitiveMethod(t *testing.T) {
@@ -126,6 +128,39 @@ func TestRunDescribeCmd_TableOutput(t *testing.T) {
 	assert.Contains(t, stdOut.String(), "REQUEST BODY")
 	assert.Contains(t, stdOut.String(), "RESPONSES")
 	assert.Contains(t, stdOut.String(), "JF API")
+	assert.Contains(t, stdOut.String(), "REQUEST BODY SCHEMA")
+	assert.Contains(t, stdOut.String(), "username*: string")
+}
+
+func TestRunDescribeCmd_ResponseSchema(t *testing.T) {
+	result := runDescribeJSON(t, "GET", "/access/api/v1/roles")
+	responses, ok := result["responses"].([]any)
+	require.True(t, ok)
+	ok200, ok := responses[0].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "200", ok200["code"])
+	assert.Equal(t, "array<GlobalRole>", ok200["schema"])
+	properties, ok := ok200["properties"].([]any)
+	require.True(t, ok)
+	assert.NotEmpty(t, properties)
+
+	var stdOut bytes.Buffer
+	var runErr error
+	app := newDescribeApp(&stdOut, &runErr)
+	require.NoError(t, app.Run([]string{"cmd", "--format", "table", "GET", "/access/api/v1/roles"}))
+	require.NoError(t, runErr)
+	assert.Contains(t, stdOut.String(), "RESPONSE 200")
+	assert.Contains(t, stdOut.String(), "type: GlobalRoleType")
+}
+
+func TestFormatSchemaTree(t *testing.T) {
+	lines := formatSchemaTree([]apispec.Property{
+		{Name: "owner", Type: "Owner", Required: true, Properties: []apispec.Property{
+			{Name: "name", Type: "string"},
+			{Name: "home", Type: "Node", Ref: "#/components/schemas/Node"},
+		}},
+	}, 0)
+	assert.Equal(t, []string{"owner*: Owner", "  name: string", "  home: Node (see #/components/schemas/Node)"}, lines)
 }
 
 // TestRunDescribeCmd_DefaultsToJSON verifies JSON is the default output format
@@ -155,7 +190,7 @@ func TestRunDescribeCmd_DefaultsToJSON(t *testing.T) {
 // jf_api one-liner must match search's one-liner for the same operation (both
 // call the shared jfApiOneLiner helper).
 func TestSearchThenDescribe_EndToEnd(t *testing.T) {
-	matches := filterAndScore(stubOps(t), "user", "", "")
+	matches := filterAndScore(stubIndex(t), "user", "", "")
 	require.NotEmpty(t, matches)
 	top := matches[0]
 
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	commonCliUtils "github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
+	coreformat "github.com/jfrog/jfrog-cli-core/v2/common/format"
+	apispec "github.com/jfrog/jfrog-cli/docs/api-spec"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+// diffedSpec names one side of `jf api docs diff`.
+type diffedSpec struct {
+	Location   string `json:"location"`
+	Operations int    `json:"operations"`
+}
+
+// diffResult is the JSON/table rendering payload for `jf api docs diff`.
+type diffResult struct {
+	Before          diffedSpec `json:"before"`
+	After           diffedSpec `json:"after"`
+	BreakingChanges int        `json:"breaking_changes"`
+	apispec.SpecDiff
+}
+
+// DiffCommand implements `jf api docs diff <spec-a> <spec-b>`: the operations
+// added and removed between two specs, and the changes of the request of the
+// operations in both. It fails when any change is breaking, so that it can
+// gate a platform or CLI upgrade in CI.
+func DiffCommand(c *cli.Context) error {
+	return runDiffCmd(c, os.Stdout)
+}
+
+func runDiffCmd(c *cli.Context, stdOut io.Writer) error {
+	if c.NArg() != 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	before, err := loadDiffedSpec(c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+	after, err := loadDiffedSpec(c.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	diff := apispec.Diff(before, after)
+	result := diffResult{
+		Before:          diffedSpec{Location: c.Args().Get(0), Operations: len(before)},
+		After:           diffedSpec{Location: c.Args().Get(1), Operations: len(after)},
+		BreakingChanges: diff.BreakingChanges(),
+		SpecDiff:        diff,
+	}
+
+	outputFormat, err := commonCliUtils.GetOutputFormat(c, coreformat.Json)
+	if err != nil {
+		return err
+	}
+	switch outputFormat {
+	case coreformat.Json:
+		err = renderDiffJSON(result)
+	case coreformat.Table:
+		err = renderDiffTable(result, stdOut)
+	default:
+		return errorutils.CheckErrorf("unsupported format '%s' for api docs diff. Accepted values: table, json", outputFormat)
+	}
+	if err != nil {
+		return err
+	}
+	if result.BreakingChanges > 0 {
+		return errorutils.CheckErrorf("%d breaking change(s) found between %s and %s", result.BreakingChanges, result.Before.Location, result.After.Location)
+	}
+	return nil
+}
+
+func loadDiffedSpec(location string) ([]apispec.Operation, error) {
+	ops, err := apispec.LoadSpec(location)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to load the OpenAPI spec '%s' (pass a spec file, a directory of spec files, or '%s' for the embedded bundle): %s", location, apispec.EmbeddedSpec, err.Error())
+	}
+	return ops, nil
+}
+
+func renderDiffJSON(result diffResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return errorutils.CheckErrorf("failed to marshal api docs diff result: %s", err.Error())
+	}
+	log.Output(clientUtils.IndentJson(data))
+	return nil
+}
+
+func renderDiffTable(result diffResult, w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CHANGE\tMETHOD\tPATH\tNAME\tDETAIL\tBREAKING")
+	for _, op := range result.Added {
+		_, _ = fmt.Fprintf(tw, "operation-added\t%s\t%s\t-\tnew operation\tno\n", op.Method, op.Path)
+	}
+	for _, op := range result.Removed {
+		_, _ = fmt.Fprintf(tw, "operation-removed\t%s\t%s\t-\toperation removed\tyes\n", op.Method, op.Path)
+	}
+	for _, op := range result.Changed {
+		for _, change := range op.Changes {
+			name := change.Name
+			if name == "" {
+				name = "-"
+			}
+			breaking := "no"
+			if change.Breaking {
+				breaking = "yes"
+			}
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", change.Kind, op.Method, op.Path, name, change.Detail, breaking)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d added, %d removed, %d changed operation(s); %d breaking change(s)\n", len(result.Added), len(result.Removed), len(result.Changed), result.BreakingChanges)
+	return err
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apispec "github.com/jfrog/jfrog-cli/docs/api-spec"
+	clientlog "github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+const diffOldSpec = `
+paths:
+  /widgets:
+    get:
+      summary: List widgets
+      parameters:
+        - name: limit
+          in: query
+    delete:
+      summary: Delete widgets
+`
+
+const diffNewSpec = `
+paths:
+  /widgets:
+    get:
+      summary: List widgets
+      parameters:
+        - name: limit
+          in: query
+          required: true
+    post:
+      summary: Create a widget
+`
+
+const diffCompatibleSpec = `
+paths:
+  /widgets:
+    get:
+      summary: List widgets
+      parameters:
+        - name: limit
+          in: query
+        - name: offset
+          in: query
+    delete:
+      summary: Delete widgets
+    post:
+      summary: Create a widget
+`
+
+func writeDiffSpec(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "spec.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+	return file
+}
+
+// runDiff runs `jf api docs diff` with args, returning the command's error
+// and what it wrote to the logger and to stdOut.
+func runDiff(t *testing.T, args ...string) (runErr error, logged, stdOut *bytes.Buffer) {
+	t.Helper()
+	logged, stdOut = &bytes.Buffer{}, &bytes.Buffer{}
+	prevLogger := clientlog.GetLogger()
+	t.Cleanup(func() { clientlog.SetLogger(prevLogger) })
+	clientlog.SetLogger(clientlog.NewLoggerWithFlags(clientlog.INFO, logged, 0))
+
+	app := cli.NewApp()
+	app.Flags = []cli.Flag{cli.StringFlag{Name: "format"}}
+	app.Action = func(c *cli.Context) error {
+		runErr = runDiffCmd(c, stdOut)
+		return nil
+	}
+	require.NoError(t, app.Run(append([]string{"cmd"}, args...)))
+	return runErr, logged, stdOut
+}
+
+func TestRunDiffCmd_BreakingChangesFail(t *testing.T) {
+	runErr, logged, _ := runDiff(t, writeDiffSpec(t, diffOldSpec), writeDiffSpec(t, diffNewSpec))
+	require.Error(t, runErr, "breaking changes must exit non-zero")
+	assert.Contains(t, runErr.Error(), "2 breaking change(s)")
+
+	var result diffResult
+	require.NoError(t, json.Unmarshal(logged.Bytes(), &result), "the report is written before failing")
+	assert.Equal(t, 2, result.Before.Operations)
+	assert.Equal(t, 2, result.BreakingChanges)
+	assert.Equal(t, []apispec.OperationRef{{Method: "POST", Path: "/widgets", Summary: "Create a widget"}}, result.Added)
+	assert.Equal(t, []apispec.OperationRef{{Method: "DELETE", Path: "/widgets", Summary: "Delete widgets"}}, result.Removed)
+	require.Len(t, result.Changed, 1)
+	assert.Equal(t, apispec.ChangeParameterRequired, result.Changed[0].Changes[0].Kind)
+}
+
+func TestRunDiffCmd_CompatibleChangesPass(t *testing.T) {
+	runErr, _, stdOut := runDiff(t, "--format", "table", writeDiffSpec(t, diffOldSpec), writeDiffSpec(t, diffCompatibleSpec))
+	require.NoError(t, runErr)
+	assert.Contains(t, stdOut.String(), "operation-added")
+	assert.Contains(t, stdOut.String(), "query:offset")
+	assert.Contains(t, stdOut.String(), "1 added, 0 removed, 1 changed operation(s); 0 breaking change(s)")
+}
+
+func TestRunDiffCmd_RenamedPathParameterPasses(t *testing.T) {
+	const spec = `
+paths:
+  /repositories/{%s}:
+    get:
+      summary: Get a repository
+      parameters:
+        - name: %s
+          in: path
+          required: true
+`
+	runErr, logged, _ := runDiff(t, writeDiffSpec(t, fmt.Sprintf(spec, "repoKey", "repoKey")), writeDiffSpec(t, fmt.Sprintf(spec, "key", "key")))
+	require.NoError(t, runErr)
+	var result diffResult
+	require.NoError(t, json.Unmarshal(logged.Bytes(), &result))
+	assert.Empty(t, result.Added)
+	assert.Empty(t, result.Removed)
+	require.Len(t, result.Changed, 1)
+	assert.Equal(t, []apispec.Change{{Kind: apispec.ChangePathParameterRenamed, Name: "path:key", Detail: "path parameter renamed from repoKey"}}, result.Changed[0].Changes)
+}
+
+func TestRunDiffCmd_EmbeddedAgainstItself(t *testing.T) {
+	runErr, logged, _ := runDiff(t, apispec.EmbeddedSpec, apispec.EmbeddedSpec)
+	require.NoError(t, runErr)
+	var result diffResult
+	require.NoError(t, json.Unmarshal(logged.Bytes(), &result))
+	assert.Positive(t, result.After.Operations)
+	assert.Empty(t, result.Added)
+	assert.Empty(t, result.Removed)
+	assert.Empty(t, result.Changed)
+}
+
+func TestRunDiffCmd_BadArguments(t *testing.T) {
+	runErr, _, _ := runDiff(t, apispec.EmbeddedSpec)
+	assert.Error(t, runErr)
+
+	runErr, _, _ = runDiff(t, apispec.EmbeddedSpec, filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, runErr)
+	assert.Contains(t, runErr.Error(), "missing.yaml")
+}
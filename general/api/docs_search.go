@@ -1,9 +1,11 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"os"
 	"sort"
@@ -23,8 +25,9 @@ import (
 )
 
 const (
-	flagTag   = "tag"
-	flagLimit = "limit"
+	flagTag     = "tag"
+	flagLimit   = "limit"
+	flagExplain = "explain"
 )
 
 // envFuzzySimilarityMin overrides fuzzySimilarityMin for experimentation --
@@ -33,16 +36,21 @@ const (
 // of JFROG_CLI_AI_HELP.
 const envFuzzySimilarityMin = "JFROG_CLI_API_DOCS_SEARCH_FUZZY_MIN"
 
-// Per-field contains-match weights. They sum (an operation matching in two
-// fields ranks above one matching in only its strongest field) and are capped
-// at 100. Fuzzy-fallback scores are confined to fuzzyScoreMax and below, so an
-// exact/substring hit on even the weakest field (tag) always outranks a pure
-// fuzzy hit.
+// Per-field boosts of the search index's BM25 scores (see apispec.Index).
+// Field scores sum (an operation matching in two fields ranks above one
+// matching in only its strongest field), and the best match's total is scaled
+// to 100, the others in proportion down to fuzzyScoreMax+1. Fuzzy-fallback
+// scores are confined to fuzzyScoreMax and below, so an indexed hit on even
+// the weakest field always outranks a pure fuzzy hit.
 const (
 	weightOperationId = 40
 	weightPath        = 30
 	weightSummary     = 20
 	weightTag         = 10
+	weightParameter   = 8
+	weightDescription = 6
+	weightProperty    = 6
+	weightResponse    = 4
 	fuzzyScoreMax     = 9
 	// fuzzySimilarityMin is deliberately high: whole-string Levenshtein
 	// similarity between two semantically unrelated but coincidentally
@@ -56,6 +64,17 @@ const (
 	defaultLimit       = 10
 )
 
+var fieldBoosts = map[string]float64{
+	apispec.FieldOperationId: weightOperationId,
+	apispec.FieldPath:        weightPath,
+	apispec.FieldSummary:     weightSummary,
+	apispec.FieldTag:         weightTag,
+	apispec.FieldParameter:   weightParameter,
+	apispec.FieldDescription: weightDescription,
+	apispec.FieldProperty:    weightProperty,
+	apispec.FieldResponse:    weightResponse,
+}
+
 // match is a single scored, ranked apispec.Operation ready for rendering.
 type match struct {
 	Method  string   `json:"method"`
@@ -64,19 +83,28 @@ type match struct {
 	Tags    []string `json:"tags"`
 	Score   int      `json:"score"`
 	JfApi   string   `json:"jf_api"`
+	// JfApiData is "declared" or "synthesized" when JfApi carries a -d payload
+	// taken from the spec's example or generated from its schema.
+	JfApiData string `json:"jf_api_data,omitempty"`
 	// Parameters and RequestBody are the payload/parameter data an agent needs
 	// to actually call this operation, not just find it -- omitted when absent
 	// (e.g. Parameters for a path with none, RequestBody for GET/DELETE).
 	Parameters  []apispec.Parameter  `json:"parameters,omitempty"`
 	RequestBody *apispec.RequestBody `json:"request_body,omitempty"`
+	// Explanation lists what the score is made of, with --explain: each
+	// query term's contribution per field, or the fuzzy fallback's.
+	Explanation []apispec.TermScore `json:"explanation,omitempty"`
 }
 
 // searchResult is the JSON/table rendering payload for `jf api docs search`.
 type searchResult struct {
-	SpecBundle  string  `json:"spec_bundle"`
-	SpecVersion string  `json:"spec_version"`
-	Query       string  `json:"query"`
-	Matches     []match `json:"matches"`
+	SpecBundle  string `json:"spec_bundle"`
+	SpecVersion string `json:"spec_version"`
+	// SpecSources lists where the catalog was loaded from: the embedded
+	// bundle, then any synced or local spec files merged over it.
+	SpecSources []apispec.Source `json:"spec_sources"`
+	Query       string           `json:"query"`
+	Matches     []match          `json:"matches"`
 	// TotalMatches is the match count before --limit truncation; Truncated is
 	// true when it exceeds len(Matches). Both are unconditional (unlike
 	// Message) so a caller parsing only the JSON body -- not the stderr
@@ -110,21 +138,53 @@ func runSearchCmd(c *cli.Context, stdOut io.Writer) error {
 		limit = defaultLimit
 	}
 
-	ops, err := apispec.Operations()
+	explain := c.Bool(flagExplain)
+	result, err := searchDocs(query, tag, method, limit, explain)
 	if err != nil {
-		return errorutils.CheckError(err)
+		return err
 	}
 
-	matches := filterAndScore(ops, query, tag, method)
+	// JSON is the default output format -- this command exists primarily for
+	// agent consumption; --format table is available for humans who want it.
+	outputFormat, err := commonCliUtils.GetOutputFormat(c, coreformat.Json)
+	if err != nil {
+		return err
+	}
+
+	switch outputFormat {
+	case coreformat.Json:
+		return renderJSON(result)
+	case coreformat.Table:
+		return renderTable(result, explain, stdOut)
+	default:
+		return errorutils.CheckErrorf("unsupported format '%s' for api docs search. Accepted values: table, json", outputFormat)
+	}
+}
+
+// searchDocs ranks the catalog's operations against query, keeping the
+// limit best. Explanations are kept only with explain.
+func searchDocs(query, tag, method string, limit int, explain bool) (searchResult, error) {
+	index, err := apispec.SearchIndex()
+	if err != nil {
+		return searchResult{}, errorutils.CheckError(err)
+	}
+
+	matches := filterAndScore(index, query, tag, method)
 	totalMatches := len(matches)
 	if len(matches) > limit {
 		matches = matches[:limit]
 	}
+	if !explain {
+		for i := range matches {
+			matches[i].Explanation = nil
+		}
+	}
 
 	info := apispec.Info()
 	result := searchResult{
 		SpecBundle:   info.SpecBundle,
 		SpecVersion:  info.SpecVersion,
+		SpecSources:  info.Sources,
 		Query:        query,
 		Matches:      matches,
 		TotalMatches: totalMatches,
@@ -146,41 +206,49 @@ func runSearchCmd(c *cli.Context, stdOut io.Writer) error {
 				"increase --limit or narrow with --tag/--method to see the rest.",
 			len(matches), totalMatches, limit))
 	}
-
-	// JSON is the default output format -- this command exists primarily for
-	// agent consumption; --format table is available for humans who want it.
-	outputFormat, err := commonCliUtils.GetOutputFormat(c, coreformat.Json)
-	if err != nil {
-		return err
-	}
-
-	switch outputFormat {
-	case coreformat.Json:
-		return renderJSON(result)
-	case coreformat.Table:
-		return renderTable(result, stdOut)
-	default:
-		return errorutils.CheckErrorf("unsupported format '%s' for api docs search. Accepted values: table, json", outputFormat)
-	}
+	return result, nil
 }
 
 // filterAndScore applies the --tag/--method hard filters, scores every
 // remaining operation against query, drops zero-scoring operations, and
 // returns matches sorted best-first (score desc, then path/method asc).
-func filterAndScore(ops []apispec.Operation, query, tag, method string) []match {
+//
+// An empty query matches every operation with the maximum score (a
+// convenience "list everything" behavior, not a documented feature). An
+// operation the index doesn't match falls back to fuzzy matching (see
+// fuzzyScore).
+func filterAndScore(index *apispec.Index, query, tag, method string) []match {
 	q := strings.ToLower(strings.TrimSpace(query))
 	tagFilter := strings.ToLower(strings.TrimSpace(tag))
 	methodFilter := strings.ToUpper(strings.TrimSpace(method))
 
+	hits := make(map[string]apispec.Hit)
+	best := 0.0
+	if q != "" {
+		for _, hit := range index.Search(q, fieldBoosts) {
+			hits[hit.Operation.Method+" "+hit.Operation.Path] = hit
+			best = max(best, hit.Score)
+		}
+	}
+
 	var matches []match
-	for _, op := range ops {
+	for _, op := range index.Operations() {
 		if methodFilter != "" && op.Method != methodFilter {
 			continue
 		}
 		if tagFilter != "" && !hasTag(op.Tags, tagFilter) {
 			continue
 		}
-		score := scoreOperation(op, q)
+		var score int
+		var explanation []apispec.TermScore
+		if hit, ok := hits[op.Method+" "+op.Path]; ok {
+			score = scaleScore(hit.Score, best)
+			explanation = hit.Terms
+		} else if q == "" {
+			score = 100
+		} else if score = fuzzyScore(op, q); score > 0 {
+			explanation = []apispec.TermScore{{Term: q, Field: "fuzzy", Score: float64(score)}}
+		}
 		if score == 0 {
 			continue
 		}
@@ -191,8 +259,10 @@ func filterAndScore(ops []apispec.Operation, query, tag, method string) []match
 			Tags:        op.Tags,
 			Score:       score,
 			JfApi:       jfApiOneLiner(op),
+			JfApiData:   jfApiData(op),
 			Parameters:  op.Parameters,
 			RequestBody: op.RequestBody,
+			Explanation: explanation,
 		})
 	}
 
@@ -208,44 +278,13 @@ func filterAndScore(ops []apispec.Operation, query, tag, method string) []match
 	return matches
 }
 
-// scoreOperation returns 0-100. An empty query matches every operation with
-// the maximum score (a convenience "list everything" behavior, not a
-// documented feature). A non-empty query that neither contains-matches nor
-// clears the fuzzy similarity threshold on any field scores 0 (excluded).
-func scoreOperation(op apispec.Operation, q string) int {
-	if q == "" {
-		return weightOperationId + weightPath + weightSummary + weightTag
-	}
-
-	score := 0
-	matchedAnyField := false
-	if strings.Contains(strings.ToLower(op.OperationId), q) {
-		score += weightOperationId
-		matchedAnyField = true
-	}
-	if strings.Contains(strings.ToLower(op.Path), q) {
-		score += weightPath
-		matchedAnyField = true
-	}
-	if strings.Contains(strings.ToLower(op.Summary), q) {
-		score += weightSummary
-		matchedAnyField = true
-	}
-	for _, t := range op.Tags {
-		if strings.Contains(strings.ToLower(t), q) {
-			score += weightTag
-			matchedAnyField = true
-			break
-		}
-	}
-	if matchedAnyField {
-		return min(score, 100)
-	}
-
-	return fuzzyScore(op, q)
+// scaleScore maps an index hit's BM25 score into (fuzzyScoreMax, 100],
+// relative to the best hit's.
+func scaleScore(score, best float64) int {
+	return fuzzyScoreMax + 1 + int(math.Round(score/best*(100-fuzzyScoreMax-1)))
 }
 
-// fuzzyScore is only consulted when no field contains q as a substring. It
+// fuzzyScore is only consulted when the index doesn't match an operation. It
 // returns a value in [0, fuzzyScoreMax], where 0 means "too dissimilar to
 // count as a match at all" (excludes the operation from results).
 func fuzzyScore(op apispec.Operation, q string) int {
@@ -299,9 +338,10 @@ func hasTag(tags []string, tagFilter string) bool {
 
 // jfApiOneLiner is a ready-to-run `jf api` invocation for op: the method is
 // omitted for GET, since that's runApiCmd's own default. When op has a
-// request body with required fields, a minimal placeholder JSON payload is
-// appended via -d so the command is runnable (after filling in real values),
-// not just a bare skeleton the caller has to guess the shape of.
+// request body example, declared by the spec or synthesized from its schema
+// (see apispec.RequestBody.Payload), it's appended via -d so the command is
+// runnable (after filling in real values), not just a bare skeleton the caller
+// has to guess the shape of.
 func jfApiOneLiner(op apispec.Operation) string {
 	var b strings.Builder
 	b.WriteString("jf api ")
@@ -310,51 +350,22 @@ func jfApiOneLiner(op apispec.Operation) string {
 		b.WriteString(" -X ")
 		b.WriteString(op.Method)
 	}
-	if skeleton := requestBodySkeleton(op.RequestBody); skeleton != "" {
-		b.WriteString(` -H "Content-Type: application/json" -d '`)
-		b.WriteString(skeleton)
-		b.WriteString(`'`)
-	}
-	return b.String()
-}
-
-// requestBodySkeleton renders a minimal JSON object covering just rb's
-// required fields with type-appropriate placeholder values, or "" when rb is
-// nil or has no required fields (an all-optional body isn't worth guessing at
-// in a one-liner -- the full field list is still in the match's request_body).
-func requestBodySkeleton(rb *apispec.RequestBody) string {
-	if rb == nil {
-		return ""
-	}
-	var fields []string
-	for _, p := range rb.Properties {
-		if p.Required {
-			fields = append(fields, fmt.Sprintf("%q:%s", p.Name, placeholderJSONValue(p.Type)))
+	if payload, _ := op.RequestBody.Payload(); payload != nil {
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, payload); err == nil {
+			payload = compacted.Bytes()
 		}
+		b.WriteString(` -H "Content-Type: application/json" -d `)
+		b.WriteString(shellQuote(string(payload)))
 	}
-	if len(fields) == 0 {
-		return ""
-	}
-	return "{" + strings.Join(fields, ",") + "}"
+	return b.String()
 }
 
-// placeholderJSONValue returns a type-appropriate placeholder JSON literal.
-// A referenced schema name (e.g. "BuildTarget") isn't a JSON-schema
-// primitive, so it falls back to the empty-string placeholder like any other
-// unrecognized type.
-func placeholderJSONValue(propType string) string {
-	switch {
-	case propType == "boolean":
-		return "false"
-	case propType == "integer" || propType == "number":
-		return "0"
-	case propType == "array" || strings.HasPrefix(propType, "array<"):
-		return "[]"
-	case propType == "object":
-		return "{}"
-	default:
-		return `""`
-	}
+// jfApiData reports where the -d payload of op's jfApiOneLiner comes from:
+// apispec.ExampleDeclared, apispec.ExampleSynthesized, or "" when there's none.
+func jfApiData(op apispec.Operation) string {
+	_, kind := op.RequestBody.Payload()
+	return kind
 }
 
 // renderJSON writes result as indented JSON via the shared client logger --
@@ -369,23 +380,50 @@ func renderJSON(result searchResult) error {
 }
 
 // renderTable writes result as a tabwriter-rendered table to w, mirroring
-// printTokenTable's construction style in general/token/cli.go.
-func renderTable(result searchResult, w io.Writer) error {
+// printTokenTable's construction style in general/token/cli.go. With explain,
+// a WHY column summarizes each match's explanation.
+func renderTable(result searchResult, explain bool, w io.Writer) error {
 	if len(result.Matches) == 0 {
 		_, err := fmt.Fprintf(w, "%s (spec_bundle=%s)\n", result.Message, result.SpecBundle)
 		return errorutils.CheckError(err)
 	}
 
 	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
-	_, _ = fmt.Fprintln(tw, "METHOD\tPATH\tSUMMARY\tTAGS\tSCORE\tPARAMS\tBODY\tJF API")
+	header := "METHOD\tPATH\tSUMMARY\tTAGS\tSCORE\tPARAMS\tBODY\tJF API"
+	if explain {
+		header += "\tWHY"
+	}
+	_, _ = fmt.Fprintln(tw, header)
 	for _, m := range result.Matches {
-		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s",
 			m.Method, m.Path, m.Summary, strings.Join(m.Tags, ","), m.Score,
 			formatParams(m.Parameters), formatRequestBody(m.RequestBody), m.JfApi)
+		if explain {
+			_, _ = fmt.Fprintf(tw, "\t%s", formatExplanation(m.Explanation))
+		}
+		_, _ = fmt.Fprintln(tw)
 	}
 	return tw.Flush()
 }
 
+// formatExplanation renders an explanation as "term@field=score" entries,
+// with the indexed term a query term matched when it's another word
+// ("perm→permission@path=12.5").
+func formatExplanation(terms []apispec.TermScore) string {
+	if len(terms) == 0 {
+		return "-"
+	}
+	entries := make([]string, len(terms))
+	for i, t := range terms {
+		term := t.Term
+		if t.Matched != "" && t.Matched != t.Term {
+			term += "→" + t.Matched
+		}
+		entries[i] = fmt.Sprintf("%s@%s=%.2f", term, t.Field, t.Score)
+	}
+	return strings.Join(entries, ", ")
+}
+
 // formatParams and formatRequestBody render a compact, single-line summary of
 // field names for the table view (a "*" suffix marks a required field) --
 // the JSON view carries the full type/description/default detail instead.
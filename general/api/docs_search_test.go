@@ -28,12 +28,29 @@ func stubOps(t *testing.T) []apispec.Operation {
 	return ops
 }
 
+func stubIndex(t *testing.T) *apispec.Index {
+	t.Helper()
+	index, err := apispec.SearchIndex()
+	require.NoError(t, err)
+	return index
+}
+
+// scoreOf is op's score for query, searched on its own; 0 when it doesn't
+// match.
+func scoreOf(op apispec.Operation, query string) int {
+	matches := filterAndScore(apispec.NewIndex([]apispec.Operation{op}), query, "", "")
+	if len(matches) == 0 {
+		return 0
+	}
+	return matches[0].Score
+}
+
 func hasPath(matches []match, path string) bool {
 	return slices.ContainsFunc(matches, func(m match) bool { return m.Path == path })
 }
 
 func TestFilterAndScore_ContainsMatchOnKnownStubOps(t *testing.T) {
-	matches := filterAndScore(stubOps(t), "user", "", "")
+	matches := filterAndScore(stubIndex(t), "user", "", "")
 	require.NotEmpty(t, matches, "query 'user' should match the stub's user operations")
 	assert.True(t, hasPath(matches, "/access/api/v2/users"), "expected /access/api/v2/users (getUserList/createUser) in results")
 
@@ -43,7 +60,7 @@ func TestFilterAndScore_ContainsMatchOnKnownStubOps(t *testing.T) {
 }
 
 func TestFilterAndScore_TagFilter(t *testing.T) {
-	matches := filterAndScore(stubOps(t), "", "Users", "")
+	matches := filterAndScore(stubIndex(t), "", "Users", "")
 	require.NotEmpty(t, matches)
 	for _, m := range matches {
 		assert.True(t, slices.Contains(m.Tags, "Users"), "match %s %s should carry the Users tag", m.Method, m.Path)
@@ -51,7 +68,7 @@ func TestFilterAndScore_TagFilter(t *testing.T) {
 }
 
 func TestFilterAndScore_MethodFilter(t *testing.T) {
-	matches := filterAndScore(stubOps(t), "", "", "DELETE")
+	matches := filterAndScore(stubIndex(t), "", "", "DELETE")
 	require.NotEmpty(t, matches)
 	for _, m := range matches {
 		assert.Equal(t, "DELETE", m.Method)
@@ -60,7 +77,7 @@ func TestFilterAndScore_MethodFilter(t *testing.T) {
 }
 
 func TestFilterAndScore_MethodFilterCaseInsensitive(t *testing.T) {
-	matches := filterAndScore(stubOps(t), "", "", "delete")
+	matches := filterAndScore(stubIndex(t), "", "", "delete")
 	require.NotEmpty(t, matches)
 	for _, m := range matches {
 		assert.Equal(t, "DELETE", m.Method)
@@ -68,8 +85,8 @@ func TestFilterAndScore_MethodFilterCaseInsensitive(t *testing.T) {
 }
 
 func TestFilterAndScore_CombinedFiltersNarrowResults(t *testing.T) {
-	all := filterAndScore(stubOps(t), "", "", "")
-	narrowed := filterAndScore(stubOps(t), "", "Users", "GET")
+	all := filterAndScore(stubIndex(t), "", "", "")
+	narrowed := filterAndScore(stubIndex(t), "", "Users", "GET")
 	assert.Less(t, len(narrowed), len(all))
 	for _, m := range narrowed {
 		assert.Equal(t, "GET", m.Method)
@@ -77,63 +94,103 @@ func TestFilterAndScore_CombinedFiltersNarrowResults(t *testing.T) {
 }
 
 func TestFilterAndScore_NoMatchIsEmpty(t *testing.T) {
-	matches := filterAndScore(stubOps(t), "zzzznotreal", "", "")
+	matches := filterAndScore(stubIndex(t), "zzzznotreal", "", "")
 	assert.Empty(t, matches, "a nonsense query should not fuzzy-match any stub operation")
 }
 
 func TestFilterAndScore_EmptyQueryMatchesEverything(t *testing.T) {
 	ops := stubOps(t)
-	matches := filterAndScore(ops, "", "", "")
+	matches := filterAndScore(apispec.NewIndex(ops), "", "", "")
 	assert.Len(t, matches, len(ops))
 }
 
-func TestScoreOperation_MultiFieldContainsBeatsSingleField(t *testing.T) {
-	// "user" contains-matches all four fields of multi; "widget" contains-matches
-	// only the Summary of single -- weights must sum, not just take the best field.
-	multi := apispec.Operation{OperationId: "getUserList", Path: "/access/api/v2/users", Summary: "Get User List", Tags: []string{"Users"}}
-	single := apispec.Operation{OperationId: "createThing", Path: "/api/v2/things", Summary: "Create a Widget", Tags: []string{"Things"}}
+func TestScore_MultiFieldBeatsSingleField(t *testing.T) {
+	// "user" matches all four fields of multi, but only the summary of single
+	// -- field scores must sum, not just take the best field.
+	multi := apispec.Operation{Method: "GET", OperationId: "getUserList", Path: "/access/api/v2/users", Summary: "Get User List", Tags: []string{"Users"}}
+	single := apispec.Operation{Method: "GET", OperationId: "createThing", Path: "/api/v2/things", Summary: "Create a user", Tags: []string{"Things"}}
+
+	matches := filterAndScore(apispec.NewIndex([]apispec.Operation{single, multi}), "user", "", "")
+	require.Len(t, matches, 2)
+	assert.Equal(t, multi.Path, matches[0].Path, "matching operationId+path+summary+tag should outscore a single-field match")
+	assert.Equal(t, 100, matches[0].Score, "the best match is scaled to 100")
+	assert.Greater(t, matches[1].Score, fuzzyScoreMax)
+}
+
+// TestScore_BodyFieldsAreSearchable guards the point of the index: an
+// operation mentioning a word only in a description, parameter or
+// request-body property is still found, under a stemmed form of the word.
+func TestScore_BodyFieldsAreSearchable(t *testing.T) {
+	ops := []apispec.Operation{
+		{Method: "POST", Path: "/policies", Summary: "Create a policy", RequestBody: &apispec.RequestBody{Properties: []apispec.Property{
+			{Name: "cronExpression", Type: "string", Description: "When the policy runs."},
+		}}},
+		{Method: "GET", Path: "/artifacts", Parameters: []apispec.Parameter{{Name: "retentionDays", In: "query"}}},
+		{Method: "DELETE", Path: "/builds", Description: "Deletes builds scheduled for cleanup."},
+		{Method: "GET", Path: "/users", Summary: "List users"},
+	}
+	index := apispec.NewIndex(ops)
 
-	multiScore := scoreOperation(multi, "user")
-	singleScore := scoreOperation(single, "widget")
-	assert.Equal(t, 100, multiScore)
-	assert.Equal(t, weightSummary, singleScore)
-	assert.Greater(t, multiScore, singleScore, "matching operationId+path+summary+tag should outscore a single-field match")
+	for query, path := range map[string]string{"cron": "/policies", "retention": "/artifacts", "schedule": "/builds"} {
+		matches := filterAndScore(index, query, "", "")
+		require.Len(t, matches, 1, query)
+		assert.Equal(t, path, matches[0].Path, query)
+		require.NotEmpty(t, matches[0].Explanation, query)
+	}
 }
 
-func TestScoreOperation_ContainsAlwaysBeatsFuzzy(t *testing.T) {
+func TestScore_ExplanationNamesFieldsAndTerms(t *testing.T) {
+	op := apispec.Operation{Method: "GET", OperationId: "getPermissions", Path: "/access/api/v2/permissions", Summary: "Get permissions"}
+	matches := filterAndScore(apispec.NewIndex([]apispec.Operation{op}), "perm", "", "")
+	require.Len(t, matches, 1)
+	fields := make(map[string]bool)
+	for _, term := range matches[0].Explanation {
+		assert.Equal(t, "perm", term.Term)
+		assert.Equal(t, "permission", term.Matched, "a partial word matches the indexed terms it starts")
+		fields[term.Field] = true
+	}
+	assert.Equal(t, map[string]bool{apispec.FieldOperationId: true, apispec.FieldPath: true, apispec.FieldSummary: true}, fields)
+	assert.Contains(t, formatExplanation(matches[0].Explanation), "perm→permission@operation_id=")
+
+	matches = filterAndScore(apispec.NewIndex([]apispec.Operation{op}), "permisions", "", "")
+	require.Len(t, matches, 1)
+	assert.Equal(t, []apispec.TermScore{{Term: "permisions", Field: "fuzzy", Score: float64(matches[0].Score)}}, matches[0].Explanation)
+}
+
+func TestScore_ContainsAlwaysBeatsFuzzy(t *testing.T) {
 	weakContains := apispec.Operation{OperationId: "x", Path: "/a", Summary: "s", Tags: []string{"usery"}}
 	// "usrr" doesn't contain "user" as a substring (order differs), but is a
 	// one-substitution Levenshtein neighbor of it -- a pure fuzzy match.
 	closeFuzzy := apispec.Operation{OperationId: "usrr", Path: "/b", Summary: "s", Tags: []string{"z"}}
 
-	containsScore := scoreOperation(weakContains, "user")
-	fuzzyScoreVal := scoreOperation(closeFuzzy, "user")
+	containsScore := scoreOf(weakContains, "user")
+	fuzzyScoreVal := scoreOf(closeFuzzy, "user")
 	require.Greater(t, containsScore, 0)
 	require.Greater(t, fuzzyScoreVal, 0)
 	assert.Greater(t, containsScore, fuzzyScoreVal, "any contains-match must outrank a pure fuzzy-match, regardless of field")
 }
 
-func TestScoreOperation_DissimilarQueryScoresZero(t *testing.T) {
+func TestScore_DissimilarQueryScoresZero(t *testing.T) {
 	op := apispec.Operation{OperationId: "getUserList", Path: "/access/api/v2/users", Summary: "Get User List", Tags: []string{"Users"}}
-	assert.Equal(t, 0, scoreOperation(op, "zzzznotreal"))
+	assert.Equal(t, 0, scoreOf(op, "zzzznotreal"))
 }
 
-// TestScoreOperation_UnrelatedSimilarLengthWordDoesNotFuzzyMatch guards a real
+// TestScore_UnrelatedSimilarLengthWordDoesNotFuzzyMatch guards a real
 // false positive found against the full bundle: "evidence" has no contains-match
 // anywhere, but its whole-string Levenshtein similarity to "Environments" (0.42)
 // used to clear the old, too-low fuzzy threshold, flooding "jf api docs search
 // evidence" with unrelated Environments-tagged operations instead of correctly
 // reporting no match.
-func TestScoreOperation_UnrelatedSimilarLengthWordDoesNotFuzzyMatch(t *testing.T) {
+func TestScore_UnrelatedSimilarLengthWordDoesNotFuzzyMatch(t *testing.T) {
 	op := apispec.Operation{OperationId: "getGlobalEnvironments", Path: "/access/api/v1/environments", Summary: "Get Global Environments", Tags: []string{"Environments"}}
-	assert.Equal(t, 0, scoreOperation(op, "evidence"))
+	assert.Equal(t, 0, scoreOf(op, "evidence"))
 }
 
-// TestScoreOperation_RealTypoStillFuzzyMatches is the flip side of the above:
+// TestScore_RealTypoStillFuzzyMatches is the flip side of the above:
 // the raised fuzzy threshold must still tolerate genuine near-typos.
-func TestScoreOperation_RealTypoStillFuzzyMatches(t *testing.T) {
+func TestScore_RealTypoStillFuzzyMatches(t *testing.T) {
 	op := apispec.Operation{OperationId: "getWorkers", Path: "/worker/api/v1/workers", Summary: "Get Workers", Tags: []string{"Workers"}}
-	assert.Greater(t, scoreOperation(op, "workrs"), 0, "a one-letter-dropped typo of 'workers' should still fuzzy-match")
+	assert.Greater(t, scoreOf(op, "workrs"), 0, "a one-letter-dropped typo of 'workers' should still fuzzy-match")
 }
 
 func TestFuzzySimilarityThreshold_DefaultAndOverride(t *testing.T) {
@@ -159,15 +216,27 @@ func TestFuzzyScore_RespectsEnvOverride(t *testing.T) {
 	op := apispec.Operation{OperationId: "getGlobalEnvironments", Path: "/access/api/v1/environments", Summary: "Get Global Environments", Tags: []string{"Environments"}}
 
 	t.Setenv(envFuzzySimilarityMin, "")
-	assert.Equal(t, 0, scoreOperation(op, "evidence"), "default threshold should reject this coincidental match")
+	assert.Equal(t, 0, scoreOf(op, "evidence"), "default threshold should reject this coincidental match")
 
 	t.Setenv(envFuzzySimilarityMin, "0.3")
-	assert.Greater(t, scoreOperation(op, "evidence"), 0, "a lowered override should admit it")
+	assert.Greater(t, scoreOf(op, "evidence"), 0, "a lowered override should admit it")
 }
 
 func TestJfApiOneLiner(t *testing.T) {
 	assert.Equal(t, "jf api /access/api/v2/users", jfApiOneLiner(apispec.Operation{Method: "GET", Path: "/access/api/v2/users"}))
 	assert.Equal(t, "jf api /access/api/v2/users -X POST", jfApiOneLiner(apispec.Operation{Method: "POST", Path: "/access/api/v2/users"}))
+
+	synthesized := apispec.Operation{Method: "POST", Path: "/access/api/v2/users", RequestBody: &apispec.RequestBody{
+		SynthesizedExample: []byte(`{"username":""}`),
+	}}
+	assert.Equal(t, `jf api /access/api/v2/users -X POST -H "Content-Type: application/json" -d '{"username":""}'`, jfApiOneLiner(synthesized))
+	assert.Equal(t, apispec.ExampleSynthesized, jfApiData(synthesized))
+
+	declared := apispec.Operation{Method: "PUT", Path: "/x", RequestBody: &apispec.RequestBody{
+		Example: []byte("{\n  \"name\": \"O'Brien\"\n}"),
+	}}
+	assert.Equal(t, `jf api /x -X PUT -H "Content-Type: application/json" -d '{"name":"O'\''Brien"}'`, jfApiOneLiner(declared), "a declared example is compacted and shell-quoted")
+	assert.Equal(t, apispec.ExampleDeclared, jfApiData(declared))
 }
 
 func TestHasTag(t *testing.T) {
@@ -185,6 +254,7 @@ func newSearchApp(stdOut *bytes.Buffer, capturedErr *error) *cli.App {
 		cli.StringFlag{Name: flagTag},
 		cli.StringFlag{Name: flagMethod},
 		cli.IntFlag{Name: flagLimit, Value: defaultLimit},
+		cli.BoolFlag{Name: flagExplain},
 		cli.StringFlag{Name: "format"},
 	}
 	app.Action = func(c *cli.Context) error {
@@ -230,6 +300,37 @@ func TestRunSearchCmd_TableOutput(t *testing.T) {
 	assert.Contains(t, stdOut.String(), "/access/api/v2/users")
 }
 
+func TestRunSearchCmd_Explain(t *testing.T) {
+	runJSON := func(args ...string) map[string]any {
+		var out bytes.Buffer
+		prevLogger := clientlog.GetLogger()
+		t.Cleanup(func() { clientlog.SetLogger(prevLogger) })
+		clientlog.SetLogger(clientlog.NewLoggerWithFlags(clientlog.INFO, &out, 0))
+		var stdOut bytes.Buffer
+		var runErr error
+		require.NoError(t, newSearchApp(&stdOut, &runErr).Run(append([]string{"cmd"}, args...)))
+		require.NoError(t, runErr)
+		var result map[string]any
+		require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+		return result
+	}
+	firstMatch := func(result map[string]any) map[string]any {
+		matches := result["matches"].([]any)
+		require.NotEmpty(t, matches)
+		return matches[0].(map[string]any)
+	}
+
+	assert.Nil(t, firstMatch(runJSON("user"))["explanation"], "explanations are only included with --explain")
+	assert.NotEmpty(t, firstMatch(runJSON("--explain", "user"))["explanation"])
+
+	var stdOut bytes.Buffer
+	var runErr error
+	require.NoError(t, newSearchApp(&stdOut, &runErr).Run([]string{"cmd", "--format", "table", "--explain", "user"}))
+	require.NoError(t, runErr)
+	assert.Contains(t, stdOut.String(), "WHY")
+	assert.Contains(t, stdOut.String(), "@path=")
+}
+
 func TestRunSearchCmd_EmptyResultTableStillReportsSpecBundle(t *testing.T) {
 	var stdOut bytes.Buffer
 	var runErr error
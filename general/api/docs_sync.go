@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	commonCliUtils "github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
+	coreconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	apispec "github.com/jfrog/jfrog-cli/docs/api-spec"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+const flagSpecPath = "spec-path"
+
+// SyncCommand implements `jf api docs sync`: it downloads an OpenAPI document
+// served by the configured platform, at the path given with --spec-path, and
+// caches it, so that `jf api docs` also covers the endpoints of that
+// platform's version. The platform has no well-known path for its spec, so
+// there's no default.
+func SyncCommand(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	serverDetails, err := cliutils.CreateServerDetailsWithConfigOffer(c, true, commonCliUtils.Platform)
+	if err != nil {
+		return err
+	}
+	return runSyncCmd(c, serverDetails)
+}
+
+func runSyncCmd(c commandContext, serverDetails *coreconfig.ServerDetails) error {
+	if serverDetails.GetUrl() == "" {
+		return errorutils.CheckErrorf("no JFrog Platform URL specified, either via the --url flag or as part of the server configuration")
+	}
+	specPath := strings.TrimSpace(c.String(flagSpecPath))
+	if specPath == "" {
+		return errorutils.CheckErrorf("the --%s option is mandatory: the path of the OpenAPI document (a single YAML or JSON file) served by the platform, relative to the platform URL", flagSpecPath)
+	}
+	fullURL, err := joinPlatformAPIURL(serverDetails.GetUrl(), specPath)
+	if err != nil {
+		return err
+	}
+	details, err := buildRequestDetails(serverDetails, c)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	client, err := newPlatformHttpClient(ctx, serverDetails, time.Duration(c.Int(flagTimeout))*time.Second, false)
+	if err != nil {
+		return err
+	}
+	ex := &exchanger{ctx: ctx, client: client}
+	resp, body, err := ex.send(http.MethodGet, fullURL, nil, details)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errorutils.CheckErrorf("downloading the OpenAPI spec from %s returned %s. Check the --%s option.", fullURL, resp.Status, flagSpecPath)
+	}
+
+	source, err := apispec.SaveSynced(body, fullURL)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	version := source.Version
+	if version == "" {
+		version = "unversioned"
+	}
+	log.Info(fmt.Sprintf("jf api docs sync: cached %d operations (%s) from %s in %s", source.Operations, version, fullURL, source.Location))
+	return nil
+}
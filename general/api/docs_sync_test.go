@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	apispec "github.com/jfrog/jfrog-cli/docs/api-spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSyncCmd(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("JFROG_CLI_HOME_DIR", home)
+	t.Setenv(apispec.SpecDirEnv, "")
+
+	var requested, auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested, auth = r.URL.Path, r.Header.Get("Authorization")
+		if r.URL.Path == "/custom/spec.yaml" {
+			_, _ = w.Write([]byte("info:\n  version: '2.0'\npaths:\n  /plugins:\n    get:\n      responses:\n        '200':\n          description: OK\n"))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+	serverDetails := &coreConfig.ServerDetails{Url: srv.URL, AccessToken: "my-token"}
+
+	err := runSyncCmd(newMockContext(&commandArgs{}), serverDetails)
+	assert.ErrorContains(t, err, "--spec-path option is mandatory")
+	assert.Empty(t, requested, "nothing is downloaded without --spec-path")
+
+	ctx := newMockContext(&commandArgs{})
+	ctx.setString(flagSpecPath, "/missing/spec.yaml")
+	assert.ErrorContains(t, runSyncCmd(ctx, serverDetails), "404")
+	assert.Equal(t, "/missing/spec.yaml", requested)
+	assert.Equal(t, "Bearer my-token", auth)
+
+	ctx = newMockContext(&commandArgs{})
+	ctx.setString(flagSpecPath, "/custom/spec.yaml")
+	require.NoError(t, runSyncCmd(ctx, serverDetails))
+	data, err := os.ReadFile(filepath.Join(home, "api-specs", "synced", "platform.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "/plugins")
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	commonCliUtils "github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
+	coreformat "github.com/jfrog/jfrog-cli-core/v2/common/format"
+	"github.com/jfrog/jfrog-cli/utils/cliutils"
+	clientUtils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/urfave/cli"
+)
+
+// ValidateCommand implements `jf api docs validate <method> <path>`. It runs
+// the same checks as `jf api --validate` against the embedded OpenAPI spec
+// bundle without sending anything -- same local, offline model as
+// `jf api docs describe`.
+func ValidateCommand(c *cli.Context) error {
+	return runValidateCmd(c, os.Stdout)
+}
+
+// runValidateCmd is split out from ValidateCommand so tests can supply their
+// own stdOut -- same split as DescribeCommand/runDescribeCmd.
+func runValidateCmd(c *cli.Context, stdOut io.Writer) error {
+	if c.NArg() != 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	body, err := resolveRequestBody(c)
+	if err != nil {
+		return err
+	}
+	result, err := validateRequest(c.Args().Get(0), c.Args().Get(1), body)
+	if err != nil {
+		return err
+	}
+
+	outputFormat, err := commonCliUtils.GetOutputFormat(c, coreformat.Json)
+	if err != nil {
+		return err
+	}
+	switch outputFormat {
+	case coreformat.Json:
+		err = renderValidateJSON(result)
+	case coreformat.Table:
+		err = renderValidateTable(result, stdOut)
+	default:
+		return errorutils.CheckErrorf("unsupported format '%s' for api docs validate. Accepted values: table, json", outputFormat)
+	}
+	if err != nil {
+		return err
+	}
+	if !result.Valid {
+		return errorutils.CheckErrorf("%d validation issue(s) found for %s %s", len(result.Issues), result.Method, result.Path)
+	}
+	return nil
+}
+
+func renderValidateJSON(result *validationResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return errorutils.CheckErrorf("failed to marshal api docs validate result: %s", err.Error())
+	}
+	log.Output(clientUtils.IndentJson(data))
+	return nil
+}
+
+func renderValidateTable(result *validationResult, w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "METHOD\t%s\n", result.Method)
+	_, _ = fmt.Fprintf(tw, "PATH\t%s\n", result.Path)
+	_, _ = fmt.Fprintf(tw, "OPERATION\t%s\n", result.Operation)
+	_, _ = fmt.Fprintf(tw, "VALID\t%t\n", result.Valid)
+	for _, issue := range result.Issues {
+		_, _ = fmt.Fprintf(tw, "ISSUE\t%s\n", issue)
+	}
+	return tw.Flush()
+}
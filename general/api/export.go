@@ -0,0 +1,256 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+)
+
+const (
+	flagPrintCurl = "print-curl"
+	flagExport    = "export"
+)
+
+const (
+	exportCurl   = "curl"
+	exportHttpie = "httpie"
+	exportGo     = "go"
+)
+
+// The environment variables an exported snippet reads credentials from, so
+// that it can be shared without the token that jf api would have sent.
+const (
+	exportTokenEnv         = "JF_ACCESS_TOKEN"
+	exportUserEnv          = "JF_USER"
+	exportPasswordEnv      = "JF_PASSWORD"
+	exportAuthorizationEnv = "JF_AUTHORIZATION"
+)
+
+// exportHeader is a request header whose value is the literal Value, followed
+// by the value of the environment variable Env when it's set.
+type exportHeader struct {
+	Name  string
+	Value string
+	Env   string
+}
+
+// exportedRequest is what jf api would have sent, with every credential
+// replaced by an environment variable reference.
+type exportedRequest struct {
+	Method   string
+	URL      string
+	Headers  []exportHeader
+	Body     []byte
+	Insecure bool
+	// BasicAuth is set when the configured credentials are a user and
+	// password, sent as $JF_USER:$JF_PASSWORD.
+	BasicAuth bool
+}
+
+// exportFormat returns the snippet format requested with --print-curl or
+// --export, or "" when the request should be sent.
+func exportFormat(c commandContext) (string, error) {
+	exportAs := strings.ToLower(strings.TrimSpace(c.String(flagExport)))
+	if c.IsSet(flagExport) {
+		switch exportAs {
+		case exportCurl, exportHttpie, exportGo:
+		default:
+			return "", errorutils.CheckErrorf("unsupported --export format %q. Accepted values: %s, %s, %s", c.String(flagExport), exportCurl, exportHttpie, exportGo)
+		}
+	}
+	if c.Bool(flagPrintCurl) {
+		if exportAs != "" && exportAs != exportCurl {
+			return "", errorutils.CheckErrorf("--print-curl can't be used with --export=%s", exportAs)
+		}
+		return exportCurl, nil
+	}
+	return exportAs, nil
+}
+
+// newExportedRequest collects the request's method, URL, user headers and
+// body. The Authorization header, whether passed with -H or derived from the
+// configured credentials, never carries a secret: it's redacted with
+// redactHeaders and the redacted part is replaced by an environment variable.
+// So is the value of every other sensitive header (see isSensitiveHeader),
+// such as Cookie or X-JFrog-Art-Api.
+func newExportedRequest(method, fullURL string, body []byte, details *httputils.HttpClientDetails, insecure bool) *exportedRequest {
+	req := &exportedRequest{Method: method, URL: fullURL, Body: body, Insecure: insecure}
+	redacted := redactHeaders(details.Headers)
+	names := make([]string, 0, len(redacted))
+	for name := range redacted {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		header := exportHeader{Name: name, Value: redacted[name]}
+		switch {
+		case strings.EqualFold(name, "Authorization"):
+			header = authorizationHeader(name, redacted[name])
+		case isSensitiveHeader(name):
+			header = exportHeader{Name: name, Env: headerEnv(name)}
+		}
+		req.Headers = append(req.Headers, header)
+	}
+
+	if !hasHeaderFold(details.Headers, "Authorization") {
+		switch {
+		case details.AccessToken != "":
+			req.Headers = append(req.Headers, exportHeader{Name: "Authorization", Value: "Bearer ", Env: exportTokenEnv})
+		case details.User != "" && details.Password != "":
+			req.BasicAuth = true
+		}
+	}
+	return req
+}
+
+// authorizationHeader turns a value redacted by redactedAuthValue ("Bearer ***"
+// or "***") into an environment variable reference.
+func authorizationHeader(name, redacted string) exportHeader {
+	if scheme, ok := strings.CutSuffix(redacted, "***"); ok && scheme != "" {
+		return exportHeader{Name: name, Value: scheme, Env: exportTokenEnv}
+	}
+	return exportHeader{Name: name, Env: exportAuthorizationEnv}
+}
+
+// headerEnv returns the environment variable an exported snippet reads the
+// value of a sensitive header from: JF_X_JFROG_ART_API for X-JFrog-Art-Api.
+func headerEnv(name string) string {
+	return "JF_" + strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name)
+}
+
+func writeExport(stdOut io.Writer, exportAs string, req *exportedRequest) error {
+	var snippet string
+	switch exportAs {
+	case exportHttpie:
+		snippet = req.httpie()
+	case exportGo:
+		var err error
+		if snippet, err = req.goProgram(); err != nil {
+			return err
+		}
+	default:
+		snippet = req.curl()
+	}
+	return writeBody(stdOut, []byte(snippet))
+}
+
+func (r *exportedRequest) curl() string {
+	args := []string{"curl"}
+	if r.Method == "HEAD" {
+		args = append(args, "--head")
+	} else {
+		args = append(args, "-X "+r.Method)
+	}
+	if r.Insecure {
+		args = append(args, "-k")
+	}
+	args = append(args, shellQuote(r.URL))
+	for _, h := range r.Headers {
+		args = append(args, "-H "+shellValue(h.Name+": "+h.Value, h.Env))
+	}
+	if r.BasicAuth {
+		args = append(args, "-u "+basicAuthShellValue())
+	}
+	if len(r.Body) > 0 {
+		args = append(args, "--data-raw "+shellQuote(string(r.Body)))
+	}
+	return strings.Join(args, " \\\n  ")
+}
+
+func (r *exportedRequest) httpie() string {
+	args := []string{"http"}
+	if r.Insecure {
+		args = append(args, "--verify=no")
+	}
+	if r.BasicAuth {
+		args = append(args, "-a "+basicAuthShellValue())
+	}
+	args = append(args, r.Method, shellQuote(r.URL))
+	for _, h := range r.Headers {
+		args = append(args, shellValue(h.Name+":"+h.Value, h.Env))
+	}
+	if len(r.Body) > 0 {
+		args = append(args, "--raw "+shellQuote(string(r.Body)))
+	}
+	return strings.Join(args, " \\\n  ")
+}
+
+// goProgram renders a self-contained, gofmt'ed Go program sending the request
+// with net/http.
+func (r *exportedRequest) goProgram() (string, error) {
+	usesEnv := r.BasicAuth
+	for _, h := range r.Headers {
+		usesEnv = usesEnv || h.Env != ""
+	}
+
+	var src bytes.Buffer
+	src.WriteString("package main\n\nimport (\n\t\"fmt\"\n\t\"io\"\n\t\"net/http\"\n")
+	if usesEnv {
+		src.WriteString("\t\"os\"\n")
+	}
+	if len(r.Body) > 0 {
+		src.WriteString("\t\"strings\"\n")
+	}
+	src.WriteString(")\n\nfunc main() {\n")
+	bodyArg := "nil"
+	if len(r.Body) > 0 {
+		_, _ = fmt.Fprintf(&src, "body := strings.NewReader(%s)\n", strconv.Quote(string(r.Body)))
+		bodyArg = "body"
+	}
+	_, _ = fmt.Fprintf(&src, "req, err := http.NewRequest(%s, %s, %s)\nif err != nil {\npanic(err)\n}\n", strconv.Quote(r.Method), strconv.Quote(r.URL), bodyArg)
+	for _, h := range r.Headers {
+		value := strconv.Quote(h.Value)
+		if h.Env != "" {
+			value = fmt.Sprintf("%s+os.Getenv(%s)", value, strconv.Quote(h.Env))
+			value = strings.TrimPrefix(value, `""+`)
+		}
+		_, _ = fmt.Fprintf(&src, "req.Header.Set(%s, %s)\n", strconv.Quote(h.Name), value)
+	}
+	if r.BasicAuth {
+		_, _ = fmt.Fprintf(&src, "req.SetBasicAuth(os.Getenv(%s), os.Getenv(%s))\n", strconv.Quote(exportUserEnv), strconv.Quote(exportPasswordEnv))
+	}
+	if r.Insecure {
+		src.WriteString("// The server is configured with insecure TLS: set InsecureSkipVerify on the client's transport if needed.\n")
+	}
+	src.WriteString("resp, err := http.DefaultClient.Do(req)\nif err != nil {\npanic(err)\n}\ndefer resp.Body.Close()\n")
+	src.WriteString("respBody, err := io.ReadAll(resp.Body)\nif err != nil {\npanic(err)\n}\n")
+	src.WriteString("fmt.Println(resp.Status)\nfmt.Println(string(respBody))\n}\n")
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return "", errorutils.CheckErrorf("failed to render the Go snippet: %s", err.Error())
+	}
+	return string(formatted), nil
+}
+
+// shellValue quotes literal for a POSIX shell, followed by a reference to the
+// environment variable env when it's set, e.g. "Authorization: Bearer $JF_ACCESS_TOKEN".
+func shellValue(literal, env string) string {
+	if env == "" {
+		return shellQuote(literal)
+	}
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`")
+	return `"` + escaper.Replace(literal) + "$" + env + `"`
+}
+
+func basicAuthShellValue() string {
+	return `"$` + exportUserEnv + `:$` + exportPasswordEnv + `"`
+}
+
+// shellQuote single-quotes s for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
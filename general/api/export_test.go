@@ -0,0 +1,168 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShellValue(t *testing.T) {
+	assert.Equal(t, `'it'\''s'`, shellValue("it's", ""))
+	assert.Equal(t, `"Authorization: Bearer $JF_ACCESS_TOKEN"`, shellValue("Authorization: Bearer ", exportTokenEnv))
+	assert.Equal(t, `"X-\$\"q\": $JF_AUTHORIZATION"`, shellValue(`X-$"q": `, exportAuthorizationEnv))
+}
+
+func TestNewExportedRequest_Credentials(t *testing.T) {
+	tests := []struct {
+		name        string
+		details     httputils.HttpClientDetails
+		wantHeaders []exportHeader
+		wantBasic   bool
+	}{
+		{
+			name:        "access token",
+			details:     httputils.HttpClientDetails{AccessToken: "secret"},
+			wantHeaders: []exportHeader{{Name: "Authorization", Value: "Bearer ", Env: exportTokenEnv}},
+		},
+		{
+			name:      "user and password",
+			details:   httputils.HttpClientDetails{User: "admin", Password: "secret"},
+			wantBasic: true,
+		},
+		{
+			name:    "user bearer header wins over the configured token",
+			details: httputils.HttpClientDetails{AccessToken: "secret", Headers: map[string]string{"Authorization": "Bearer other-secret", "Accept": "application/json"}},
+			wantHeaders: []exportHeader{
+				{Name: "Accept", Value: "application/json"},
+				{Name: "Authorization", Value: "Bearer ", Env: exportTokenEnv},
+			},
+		},
+		{
+			name:        "user non-bearer header",
+			details:     httputils.HttpClientDetails{Headers: map[string]string{"authorization": "Basic c2VjcmV0"}},
+			wantHeaders: []exportHeader{{Name: "authorization", Env: exportAuthorizationEnv}},
+		},
+		{
+			name: "sensitive user headers",
+			details: httputils.HttpClientDetails{Headers: map[string]string{
+				"X-JFrog-Art-Api": "s3cr3t",
+				"Cookie":          "session=secret",
+				"X-Api-Key":       "secret",
+				"X-Request-Id":    "42",
+			}},
+			wantHeaders: []exportHeader{
+				{Name: "Cookie", Env: "JF_COOKIE"},
+				{Name: "X-Api-Key", Env: "JF_X_API_KEY"},
+				{Name: "X-JFrog-Art-Api", Env: "JF_X_JFROG_ART_API"},
+				{Name: "X-Request-Id", Value: "42"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newExportedRequest("GET", "https://acme.jfrog.io/x", nil, &tt.details, false)
+			assert.Equal(t, tt.wantHeaders, req.Headers)
+			assert.Equal(t, tt.wantBasic, req.BasicAuth)
+		})
+	}
+}
+
+func TestExportedRequestSnippets(t *testing.T) {
+	req := &exportedRequest{
+		Method: "POST",
+		URL:    "https://acme.jfrog.io/access/api/v2/users",
+		Headers: []exportHeader{
+			{Name: "Content-Type", Value: "application/json"},
+			{Name: "Authorization", Value: "Bearer ", Env: exportTokenEnv},
+		},
+		Body: []byte(`{"username":"o'neil"}`),
+	}
+
+	assert.Equal(t, `curl \
+  -X POST \
+  'https://acme.jfrog.io/access/api/v2/users' \
+  -H 'Content-Type: application/json' \
+  -H "Authorization: Bearer $JF_ACCESS_TOKEN" \
+  --data-raw '{"username":"o'\''neil"}'`, req.curl())
+
+	assert.Equal(t, `http \
+  POST \
+  'https://acme.jfrog.io/access/api/v2/users' \
+  'Content-Type:application/json' \
+  "Authorization:Bearer $JF_ACCESS_TOKEN" \
+  --raw '{"username":"o'\''neil"}'`, req.httpie())
+
+	program, err := req.goProgram()
+	require.NoError(t, err)
+	assert.Contains(t, program, `body := strings.NewReader("{\"username\":\"o'neil\"}")`)
+	assert.Contains(t, program, `http.NewRequest("POST", "https://acme.jfrog.io/access/api/v2/users", body)`)
+	assert.Contains(t, program, `req.Header.Set("Authorization", "Bearer "+os.Getenv("JF_ACCESS_TOKEN"))`)
+	assert.Contains(t, program, "\t\"os\"\n\t\"strings\"\n")
+}
+
+func TestExportedRequestSnippets_BasicAuthInsecure(t *testing.T) {
+	req := &exportedRequest{Method: "HEAD", URL: "https://acme.jfrog.io/x", BasicAuth: true, Insecure: true}
+	assert.Equal(t, `curl \
+  --head \
+  -k \
+  'https://acme.jfrog.io/x' \
+  -u "$JF_USER:$JF_PASSWORD"`, req.curl())
+	assert.Contains(t, req.httpie(), `--verify=no \
+  -a "$JF_USER:$JF_PASSWORD" \
+  HEAD`)
+
+	program, err := req.goProgram()
+	require.NoError(t, err)
+	assert.Contains(t, program, `req.SetBasicAuth(os.Getenv("JF_USER"), os.Getenv("JF_PASSWORD"))`)
+	assert.NotContains(t, program, `"strings"`)
+}
+
+// TestApiExport_DoesNotSend guards that an exported request never reaches the
+// server and never carries the configured token.
+func TestApiExport_DoesNotSend(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+	}))
+	t.Cleanup(srv.Close)
+	serverDetails := &coreConfig.ServerDetails{Url: srv.URL, AccessToken: "my-token"}
+
+	for _, exportAs := range []string{exportCurl, exportHttpie, exportGo} {
+		t.Run(exportAs, func(t *testing.T) {
+			ctx := newMockContext(&commandArgs{path: "/access/api/v2/users", method: "POST", data: `{"a":1}`, export: exportAs})
+			var stdOut bytes.Buffer
+			require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+			assert.Contains(t, stdOut.String(), srv.URL+"/access/api/v2/users")
+			assert.Contains(t, stdOut.String(), exportTokenEnv)
+			assert.NotContains(t, stdOut.String(), "my-token")
+		})
+	}
+
+	ctx := newMockContext(&commandArgs{path: "/x", headers: map[string]string{"X-JFrog-Art-Api": "s3cr3t"}})
+	ctx.setBool(flagPrintCurl, true)
+	var stdOut bytes.Buffer
+	require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.Contains(t, stdOut.String(), "curl \\\n  -X GET")
+	assert.Contains(t, stdOut.String(), `-H "X-JFrog-Art-Api: $JF_X_JFROG_ART_API"`)
+	assert.NotContains(t, stdOut.String(), "s3cr3t")
+	assert.Zero(t, calls.Load())
+}
+
+func TestApiExport_InvalidFormat(t *testing.T) {
+	serverDetails := newTestServer(t)
+
+	ctx := newMockContext(&commandArgs{path: "/x", export: "wget"})
+	var stdOut bytes.Buffer
+	assert.ErrorContains(t, runApiCmd(ctx, serverDetails, &stdOut, nil), "unsupported --export format")
+
+	ctx = newMockContext(&commandArgs{path: "/x", export: exportHttpie})
+	ctx.setBool(flagPrintCurl, true)
+	assert.ErrorContains(t, runApiCmd(ctx, serverDetails, &stdOut, nil), "--print-curl can't be used with --export=httpie")
+}
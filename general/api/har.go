@@ -0,0 +1,495 @@
+package api
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+)
+
+const (
+	flagRecord = "record"
+	flagReplay = "replay"
+)
+
+// The subset of the HAR 1.2 format (http://www.softwareishard.com/blog/har-12-spec/)
+// that jf api records and replays.
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            int64       `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harTimings struct {
+	Send    int64 `json:"send"`
+	Wait    int64 `json:"wait"`
+	Receive int64 `json:"receive"`
+}
+
+// newCassette returns the recorder for --record or the replayer for --replay,
+// which can't be combined.
+func newCassette(c commandContext) (*harRecorder, *harReplayer, error) {
+	record, replay := c.String(flagRecord), c.String(flagReplay)
+	switch {
+	case record != "" && replay != "":
+		return nil, nil, errorutils.CheckErrorf("only one of --record and --replay can be used")
+	case record != "":
+		return newHarRecorder(record), nil, nil
+	case replay != "":
+		replayer, err := newHarReplayer(replay)
+		return nil, replayer, err
+	}
+	return nil, nil, nil
+}
+
+// harRecorder collects the exchanges of a jf api invocation, to be appended
+// to a HAR file by flush.
+type harRecorder struct {
+	path    string
+	mu      sync.Mutex
+	entries []harEntry
+}
+
+func newHarRecorder(path string) *harRecorder {
+	return &harRecorder{path: path}
+}
+
+// add records an exchange. Credentials never reach the file: request headers
+// are redacted like --verbose's, Authorization and the headers named like
+// credentials, such as Cookie or X-JFrog-Art-Api (see redactHeaders), and so
+// are the
+// response's Set-Cookie headers, the values of sensitive query parameters and
+// the sensitive fields of JSON and form bodies (see isHarSensitiveName).
+func (r *harRecorder) add(started time.Time, elapsed time.Duration, method, fullURL string, body []byte, details *httputils.HttpClientDetails, resp *http.Response, respBody []byte) {
+	requestHeaders := make(map[string]string)
+	for k, v := range redactHeaders(details.Headers) {
+		requestHeaders[k] = v
+	}
+	if !hasHeaderFold(details.Headers, "Authorization") {
+		switch {
+		case details.AccessToken != "":
+			requestHeaders["Authorization"] = "Bearer ***"
+		case details.User != "" && details.Password != "":
+			requestHeaders["Authorization"] = "Basic ***"
+		}
+	}
+	fullURL = redactHarURL(fullURL)
+	requestContentType := headerFold(details.Headers, "Content-Type")
+	body = redactHarBody(requestContentType, body)
+	respBody = redactHarBody(resp.Header.Get("Content-Type"), respBody)
+
+	entry := harEntry{
+		StartedDateTime: started.UTC().Format(time.RFC3339Nano),
+		Time:            elapsed.Milliseconds(),
+		Request: harRequest{
+			Method:      method,
+			URL:         fullURL,
+			HTTPVersion: "HTTP/1.1",
+			Headers:     harHeaders(requestHeaders),
+			QueryString: harQueryString(fullURL),
+			HeadersSize: -1,
+			BodySize:    len(body),
+		},
+		Response: harResponse{
+			Status:      resp.StatusCode,
+			StatusText:  http.StatusText(resp.StatusCode),
+			HTTPVersion: resp.Proto,
+			Headers:     harResponseHeaders(resp.Header),
+			Content:     harBodyContent(resp.Header.Get("Content-Type"), respBody),
+			HeadersSize: -1,
+			BodySize:    len(respBody),
+		},
+		Timings: harTimings{Wait: elapsed.Milliseconds()},
+	}
+	if len(body) > 0 {
+		content := harBodyContent(requestContentType, body)
+		entry.Request.PostData = &harPostData{MimeType: content.MimeType, Text: content.Text, Encoding: content.Encoding}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, entry)
+}
+
+// flush appends the recorded entries to the HAR file, creating it if needed.
+func (r *harRecorder) flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.entries) == 0 {
+		return nil
+	}
+	har, err := readHarFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		har, err = &harFile{Log: harLog{Version: "1.2", Creator: harCreator{Name: "jf api", Version: "1"}}}, nil
+	}
+	if err != nil {
+		return err
+	}
+	har.Log.Entries = append(har.Log.Entries, r.entries...)
+	data, err := json.MarshalIndent(har, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	// Redaction goes by field names, so keep the file private all the same.
+	if err = os.WriteFile(r.path, append(data, '\n'), 0o600); err != nil {
+		return errorutils.CheckError(err)
+	}
+	r.entries = nil
+	return nil
+}
+
+// harReplayer serves responses from a HAR file instead of the network.
+type harReplayer struct {
+	path    string
+	mu      sync.Mutex
+	entries []harEntry
+	used    []bool
+}
+
+func newHarReplayer(path string) (*harReplayer, error) {
+	har, err := readHarFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errorutils.CheckErrorf("the --replay file %s does not exist", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &harReplayer{path: path, entries: har.Log.Entries, used: make([]bool, len(har.Log.Entries))}, nil
+}
+
+// respond returns the recorded response for a request. Entries are matched
+// by method, path and query string, ignoring the scheme and host, so that a
+// cassette recorded against one server replays under any configured URL.
+// Identical requests are answered by their recorded entries in order, and the
+// last one is reused once they've all been served.
+func (r *harReplayer) respond(method, fullURL string) (*http.Response, []byte, error) {
+	key, err := harMatchKey(method, fullURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	last := -1
+	for i, entry := range r.entries {
+		entryKey, err := harMatchKey(entry.Request.Method, entry.Request.URL)
+		if err != nil || entryKey != key {
+			continue
+		}
+		last = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if last == -1 {
+		return nil, nil, errorutils.CheckErrorf("no recorded response for %s in %s", key, r.path)
+	}
+	r.used[last] = true
+
+	recorded := r.entries[last].Response
+	body, err := recorded.Content.decode()
+	if err != nil {
+		return nil, nil, errorutils.CheckErrorf("the recorded response for %s in %s can't be decoded: %s", key, r.path, err.Error())
+	}
+	header := http.Header{}
+	for _, h := range recorded.Headers {
+		header.Add(h.Name, h.Value)
+	}
+	statusText := recorded.StatusText
+	if statusText == "" {
+		statusText = http.StatusText(recorded.Status)
+	}
+	resp := &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, statusText),
+		StatusCode:    recorded.Status,
+		Proto:         recorded.HTTPVersion,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+	return resp, body, nil
+}
+
+func readHarFile(path string) (*harFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	var har harFile
+	if err = json.Unmarshal(data, &har); err != nil {
+		return nil, errorutils.CheckErrorf("%s is not a valid HAR file: %s", path, err.Error())
+	}
+	return &har, nil
+}
+
+func harMatchKey(method, fullURL string) (string, error) {
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	key := strings.ToUpper(method) + " " + u.EscapedPath()
+	// Recorded URLs have their sensitive query parameters redacted, so the
+	// request's are too, for them to match.
+	if rawQuery := redactHarQuery(u.RawQuery); rawQuery != "" {
+		key += "?" + rawQuery
+	}
+	return key, nil
+}
+
+// harSensitiveNameSuffixes are the endings of the JSON field, form field and
+// query parameter names whose values are redacted from HAR files, once
+// lowercased and stripped of '-' and '_'. Names such as "token",
+// "access_token" or "clientSecret" end with one, while the identifiers and
+// metadata of credentials, such as "token_id", "token_type" or
+// "password_expiry_days", don't and are kept for --replay and --query. Among
+// the names ending with "key", only those of credentials are listed: others,
+// such as "key" or "repoKey", name repositories and projects.
+var harSensitiveNameSuffixes = []string{
+	"password", "passwd", "passphrase", "token", "secret", "credential", "credentials",
+	"apikey", "privatekey", "accesskey", "secretkey", "signingkey", "encryptionkey", "passkey",
+}
+
+func isHarSensitiveName(name string) bool {
+	normalized := strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(name))
+	for _, suffix := range harSensitiveNameSuffixes {
+		if strings.HasSuffix(normalized, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactHarURL redacts the values of the sensitive query parameters of
+// fullURL.
+func redactHarURL(fullURL string) string {
+	u, err := url.Parse(fullURL)
+	if err != nil || u.RawQuery == "" {
+		return fullURL
+	}
+	u.RawQuery = redactHarQuery(u.RawQuery)
+	return u.String()
+}
+
+// redactHarQuery redacts the values of the sensitive parameters of a raw query
+// or form body, leaving the rest of it as is.
+func redactHarQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	pairs := strings.Split(rawQuery, "&")
+	for i, pair := range pairs {
+		name, _, hasValue := strings.Cut(pair, "=")
+		if !hasValue {
+			continue
+		}
+		if unescaped, err := url.QueryUnescape(name); err == nil && isHarSensitiveName(unescaped) {
+			pairs[i] = name + "=***"
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// redactHarBody redacts the values of the sensitive fields of a JSON or form
+// body, at any depth. Other bodies, and bodies without sensitive fields, are
+// returned as is.
+func redactHarBody(contentType string, body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	if strings.Contains(strings.ToLower(contentType), "application/x-www-form-urlencoded") {
+		return []byte(redactHarQuery(string(body)))
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if decoder.Decode(&value) != nil || decoder.More() {
+		return body
+	}
+	redacted, changed := redactHarJSON(value)
+	if !changed {
+		return body
+	}
+	data, err := json.Marshal(redacted)
+	if err != nil {
+		return body
+	}
+	return data
+}
+
+// redactHarJSON replaces the string and number values of sensitive fields with
+// "***", and reports whether any was.
+func redactHarJSON(value any) (any, bool) {
+	changed := false
+	switch typed := value.(type) {
+	case map[string]any:
+		for name, field := range typed {
+			switch field.(type) {
+			case string, json.Number:
+				if isHarSensitiveName(name) {
+					typed[name] = "***"
+					changed = true
+				}
+			default:
+				var fieldChanged bool
+				typed[name], fieldChanged = redactHarJSON(field)
+				changed = changed || fieldChanged
+			}
+		}
+	case []any:
+		for i, item := range typed {
+			var itemChanged bool
+			typed[i], itemChanged = redactHarJSON(item)
+			changed = changed || itemChanged
+		}
+	}
+	return value, changed
+}
+
+// harBodyContent stores body as text, or base64-encoded when it isn't UTF-8.
+func harBodyContent(mimeType string, body []byte) harContent {
+	content := harContent{Size: len(body), MimeType: mimeType}
+	if utf8.Valid(body) {
+		content.Text = string(body)
+	} else {
+		content.Text = base64.StdEncoding.EncodeToString(body)
+		content.Encoding = "base64"
+	}
+	return content
+}
+
+func (c harContent) decode() ([]byte, error) {
+	if c.Encoding == "base64" {
+		return base64.StdEncoding.DecodeString(c.Text)
+	}
+	return []byte(c.Text), nil
+}
+
+func harHeaders(headers map[string]string) []harNameValue {
+	out := make([]harNameValue, 0, len(headers))
+	for k, v := range headers {
+		out = append(out, harNameValue{Name: k, Value: v})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func harResponseHeaders(header http.Header) []harNameValue {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	out := make([]harNameValue, 0, len(header))
+	for _, name := range names {
+		for _, v := range header[name] {
+			if strings.EqualFold(name, "Set-Cookie") {
+				v = "***"
+			}
+			out = append(out, harNameValue{Name: name, Value: v})
+		}
+	}
+	return out
+}
+
+func harQueryString(fullURL string) []harNameValue {
+	out := []harNameValue{}
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return out
+	}
+	query := u.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, v := range query[name] {
+			out = append(out, harNameValue{Name: name, Value: v})
+		}
+	}
+	return out
+}
+
+// headerFold returns the value of the header name in h, case-insensitively.
+func headerFold(h map[string]string, name string) string {
+	for k, v := range h {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHarRecorder_RedactsAndAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.har")
+	details := &httputils.HttpClientDetails{AccessToken: "secret-token", Headers: map[string]string{"Content-Type": "application/json"}}
+	resp := &http.Response{StatusCode: http.StatusCreated, Proto: "HTTP/1.1", Header: http.Header{
+		"Content-Type": {"application/json"},
+		"Set-Cookie":   {"session=secret-cookie"},
+	}}
+
+	for i := 0; i < 2; i++ {
+		recorder := newHarRecorder(path)
+		recorder.add(time.Now(), 5*time.Millisecond, "POST", "https://acme.jfrog.io/api/x?a=1", []byte(`{"n":1}`), details, resp, []byte{0xff, 0x00})
+		require.NoError(t, recorder.flush())
+	}
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret-token")
+	assert.NotContains(t, string(data), "secret-cookie")
+
+	har, err := readHarFile(path)
+	require.NoError(t, err)
+	require.Len(t, har.Log.Entries, 2, "a second run appends to the cassette")
+	entry := har.Log.Entries[0]
+	assert.Equal(t, "1.2", har.Log.Version)
+	assert.Contains(t, entry.Request.Headers, harNameValue{Name: "Authorization", Value: "Bearer ***"})
+	assert.Equal(t, []harNameValue{{Name: "a", Value: "1"}}, entry.Request.QueryString)
+	require.NotNil(t, entry.Request.PostData)
+	assert.Equal(t, `{"n":1}`, entry.Request.PostData.Text)
+	assert.Equal(t, "application/json", entry.Request.PostData.MimeType)
+	assert.Equal(t, http.StatusCreated, entry.Response.Status)
+	assert.Equal(t, "base64", entry.Response.Content.Encoding, "a binary body is stored base64-encoded")
+}
+
+func TestHarRecorder_RedactsSecretsFromBodiesAndQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.har")
+	details := &httputils.HttpClientDetails{AccessToken: "my-token", Headers: map[string]string{"Content-Type": "application/json"}}
+	resp := &http.Response{StatusCode: http.StatusOK, Proto: "HTTP/1.1", Header: http.Header{"Content-Type": {"application/json"}}}
+
+	recorder := newHarRecorder(path)
+	recorder.add(time.Now(), time.Millisecond, "POST", "https://acme.jfrog.io/access/api/v1/tokens?password=query-secret&scope=admin",
+		[]byte(`{"username":"admin","password":"body-secret","repos":[{"key":"libs","apiKey":"nested-secret"}]}`),
+		details, resp, []byte(`{"token_id":"1","token_type":"Bearer","access_token":"response-secret","refresh_token":"refresh-secret","id_token":"id-secret","expires_in":3600,"password_expiry_days":90,"scope":"applied-permissions/user"}`))
+	formDetails := &httputils.HttpClientDetails{Headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded"}}
+	recorder.add(time.Now(), time.Millisecond, "POST", "https://acme.jfrog.io/access/api/v1/tokens",
+		[]byte("grant_type=password&username=admin&password=form-secret"), formDetails, resp, []byte(`[]`))
+	require.NoError(t, recorder.flush())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, secret := range []string{"query-secret", "body-secret", "nested-secret", "response-secret", "refresh-secret", "id-secret", "form-secret"} {
+		assert.NotContains(t, string(data), secret)
+	}
+
+	har, err := readHarFile(path)
+	require.NoError(t, err)
+	require.Len(t, har.Log.Entries, 2)
+	entry := har.Log.Entries[0]
+	assert.Equal(t, "https://acme.jfrog.io/access/api/v1/tokens?password=***&scope=admin", entry.Request.URL)
+	assert.Contains(t, entry.Request.QueryString, harNameValue{Name: "password", Value: "***"})
+	assert.JSONEq(t, `{"username":"admin","password":"***","repos":[{"key":"libs","apiKey":"***"}]}`, entry.Request.PostData.Text)
+	assert.JSONEq(t, `{"token_id":"1","token_type":"Bearer","access_token":"***","refresh_token":"***","id_token":"***","expires_in":3600,"password_expiry_days":90,"scope":"applied-permissions/user"}`, entry.Response.Content.Text,
+		"the identifiers and metadata of tokens are kept")
+	assert.Equal(t, "grant_type=password&username=admin&password=***", har.Log.Entries[1].Request.PostData.Text)
+
+	// The redacted request still replays.
+	replayer, err := newHarReplayer(path)
+	require.NoError(t, err)
+	replayed, body, err := replayer.respond("POST", "http://localhost/access/api/v1/tokens?password=other&scope=admin")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, replayed.StatusCode)
+	assert.NotContains(t, string(body), "response-secret")
+}
+
+func TestHarReplayer_Respond(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.har")
+	har := harFile{Log: harLog{Version: "1.2", Entries: []harEntry{
+		{Request: harRequest{Method: "GET", URL: "https://recorded.example/api/x?page=1"}, Response: harResponse{Status: 200, Content: harContent{Text: "first"}}},
+		{Request: harRequest{Method: "GET", URL: "https://recorded.example/api/x?page=1"}, Response: harResponse{Status: 503, Content: harContent{Text: "second"}}},
+		{Request: harRequest{Method: "DELETE", URL: "https://recorded.example/api/x?page=1"}, Response: harResponse{Status: 204, Headers: []harNameValue{{Name: "X-Id", Value: "7"}}}},
+	}}}
+	data, err := json.Marshal(har)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	replayer, err := newHarReplayer(path)
+	require.NoError(t, err)
+
+	for _, want := range []struct {
+		status int
+		body   string
+	}{{200, "first"}, {503, "second"}, {503, "second"}} {
+		resp, body, err := replayer.respond("GET", "http://localhost:8082/api/x?page=1")
+		require.NoError(t, err)
+		assert.Equal(t, want.status, resp.StatusCode)
+		assert.Equal(t, want.body, string(body))
+	}
+
+	resp, _, err := replayer.respond("delete", "http://other/api/x?page=1")
+	require.NoError(t, err)
+	assert.Equal(t, "204 No Content", resp.Status)
+	assert.Equal(t, "7", resp.Header.Get("X-Id"))
+
+	_, _, err = replayer.respond("GET", "http://localhost/api/x?page=2")
+	assert.ErrorContains(t, err, "no recorded response for GET /api/x?page=2")
+}
+
+// TestApiRecordThenReplay guards the intended flow: a paginated walk recorded
+// against a live server replays with the same output once the server is gone.
+func TestApiRecordThenReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.har")
+	serverDetails := newPagedServer(t, 5)
+
+	ctx := newMockContext(&commandArgs{path: "/items?offset=0&limit=2", paginate: true, record: path})
+	var recorded bytes.Buffer
+	require.NoError(t, runApiCmd(ctx, serverDetails, &recorded, nil))
+
+	har, err := readHarFile(path)
+	require.NoError(t, err)
+	assert.Len(t, har.Log.Entries, 3)
+
+	offline := &coreConfig.ServerDetails{Url: "http://127.0.0.1:1", AccessToken: "my-token"}
+	ctx = newMockContext(&commandArgs{path: "/items?offset=0&limit=2", paginate: true, replay: path})
+	var replayed bytes.Buffer
+	require.NoError(t, runApiCmd(ctx, offline, &replayed, nil))
+	assert.JSONEq(t, recorded.String(), replayed.String())
+}
+
+func TestApiRecord_RedactsSensitiveHeaders(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "items.har")
+	serverDetails := newPagedServer(t, 1)
+
+	ctx := newMockContext(&commandArgs{path: "/items?offset=0&limit=2", record: path, headers: map[string]string{
+		"X-JFrog-Art-Api":      "s3cr3t",
+		"Cookie":               "session=cookie-secret",
+		"X-Service-Api-Key":    "key-secret",
+		"X-JFrog-Access-Token": "token-secret",
+		"X-Request-Id":         "kept",
+	}})
+	var stdOut bytes.Buffer
+	require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	for _, secret := range []string{"s3cr3t", "cookie-secret", "key-secret", "token-secret"} {
+		assert.NotContains(t, string(data), secret)
+	}
+	har, err := readHarFile(path)
+	require.NoError(t, err)
+	require.Len(t, har.Log.Entries, 1)
+	assert.Contains(t, har.Log.Entries[0].Request.Headers, harNameValue{Name: "X-JFrog-Art-Api", Value: "***"})
+	assert.Contains(t, har.Log.Entries[0].Request.Headers, harNameValue{Name: "X-Request-Id", Value: "kept"})
+}
+
+func TestApiRecordAndReplayAreExclusive(t *testing.T) {
+	serverDetails := newTestServer(t)
+	ctx := newMockContext(&commandArgs{path: "/x", record: "a.har", replay: "b.har"})
+	var stdOut bytes.Buffer
+	assert.ErrorContains(t, runApiCmd(ctx, serverDetails, &stdOut, nil), "only one of --record and --replay")
+
+	ctx = newMockContext(&commandArgs{path: "/x", replay: filepath.Join(t.TempDir(), "missing.har")})
+	assert.ErrorContains(t, runApiCmd(ctx, serverDetails, &stdOut, nil), "does not exist")
+}
@@ -0,0 +1,536 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// jsonPath is a compiled --query expression. It supports the commonly used
+// subset of JSONPath:
+//
+//	$                   the root
+//	.name / ['name']    a child field ("name" may also be a quoted list: ['a','b'])
+//	.* / [*]            every child of an object or array
+//	[0] / [-1] / [0,2]  array indexes, negative ones counting from the end
+//	[1:3] / [::2]       array slices
+//	..name / ..*        recursive descent
+//	[?(@.f == 'v')]     filters comparing a relative path with a literal
+//	                    (==, !=, <, <=, >, >=), or testing it exists ([?(@.f)])
+//
+// A jq-style expression (".tokens[].token_id", ".[0]", ".") is accepted too and
+// translated to the equivalent JSONPath.
+type jsonPath struct {
+	steps []pathStep
+	// definite is true when the expression selects at most one value (only
+	// plain names and single indexes), in which case the value itself is the
+	// result rather than a list of matches.
+	definite bool
+}
+
+type pathStep interface {
+	apply(nodes []any) []any
+}
+
+func compileJSONPath(expr string) (*jsonPath, error) {
+	p := &pathParser{src: toJSONPath(strings.TrimSpace(expr)), definite: true}
+	steps, err := p.parse()
+	if err != nil {
+		return nil, errorutils.CheckErrorf("invalid --query expression %q: %s", expr, err.Error())
+	}
+	return &jsonPath{steps: steps, definite: p.definite}, nil
+}
+
+// toJSONPath translates the jq-style subset to JSONPath; a JSONPath
+// expression is returned unchanged.
+func toJSONPath(expr string) string {
+	switch {
+	case strings.HasPrefix(expr, "$"):
+		return expr
+	case expr == "" || expr == ".":
+		return "$"
+	case strings.HasPrefix(expr, "."):
+		expr = "$" + expr
+	default:
+		expr = "$." + expr
+	}
+	expr = strings.ReplaceAll(expr, "[]", "[*]")
+	return strings.ReplaceAll(expr, ".[", "[")
+}
+
+// evaluate runs the expression against a decoded JSON document.
+func (jp *jsonPath) evaluate(doc any) []any {
+	nodes := []any{doc}
+	for _, step := range jp.steps {
+		nodes = step.apply(nodes)
+	}
+	return nodes
+}
+
+type childStep struct {
+	names    []string
+	wildcard bool
+}
+
+func (s childStep) apply(nodes []any) []any {
+	var out []any
+	for _, node := range nodes {
+		if s.wildcard {
+			out = append(out, children(node)...)
+			continue
+		}
+		if obj, ok := node.(map[string]any); ok {
+			for _, name := range s.names {
+				if v, found := obj[name]; found {
+					out = append(out, v)
+				}
+			}
+		}
+	}
+	return out
+}
+
+type indexStep struct {
+	indexes []int
+}
+
+func (s indexStep) apply(nodes []any) []any {
+	var out []any
+	for _, node := range nodes {
+		arr, ok := node.([]any)
+		if !ok {
+			continue
+		}
+		for _, i := range s.indexes {
+			if i < 0 {
+				i += len(arr)
+			}
+			if i >= 0 && i < len(arr) {
+				out = append(out, arr[i])
+			}
+		}
+	}
+	return out
+}
+
+type sliceStep struct {
+	start, end *int
+	step       int
+}
+
+func (s sliceStep) apply(nodes []any) []any {
+	var out []any
+	for _, node := range nodes {
+		arr, ok := node.([]any)
+		if !ok {
+			continue
+		}
+		start, end := 0, len(arr)
+		if s.start != nil {
+			start = clampIndex(*s.start, len(arr))
+		}
+		if s.end != nil {
+			end = clampIndex(*s.end, len(arr))
+		}
+		for i := start; i < end; i += s.step {
+			out = append(out, arr[i])
+		}
+	}
+	return out
+}
+
+func clampIndex(i, length int) int {
+	if i < 0 {
+		i += length
+	}
+	return min(max(i, 0), length)
+}
+
+// recursiveStep applies inner to every node and all of its descendants.
+type recursiveStep struct {
+	inner pathStep
+}
+
+func (s recursiveStep) apply(nodes []any) []any {
+	var all []any
+	var walk func(node any)
+	walk = func(node any) {
+		all = append(all, node)
+		for _, child := range children(node) {
+			walk(child)
+		}
+	}
+	for _, node := range nodes {
+		walk(node)
+	}
+	return s.inner.apply(all)
+}
+
+type filterStep struct {
+	operand  []pathStep
+	operator string
+	literal  any
+}
+
+func (s filterStep) apply(nodes []any) []any {
+	var out []any
+	for _, node := range nodes {
+		for _, child := range children(node) {
+			values := []any{child}
+			for _, step := range s.operand {
+				values = step.apply(values)
+			}
+			if len(values) == 0 {
+				continue
+			}
+			if s.operator == "" || compareJSON(values[0], s.operator, s.literal) {
+				out = append(out, child)
+			}
+		}
+	}
+	return out
+}
+
+// children returns the values of an object, sorted by key for a stable
+// output, or the elements of an array.
+func children(node any) []any {
+	switch v := node.(type) {
+	case []any:
+		return v
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		out := make([]any, len(keys))
+		for i, k := range keys {
+			out[i] = v[k]
+		}
+		return out
+	}
+	return nil
+}
+
+// compareJSON compares a document value with a filter literal. Numbers are
+// compared numerically and strings lexically; other types only support
+// equality.
+func compareJSON(value any, operator string, literal any) bool {
+	if n, ok := value.(json.Number); ok {
+		value, _ = n.Float64()
+	}
+	switch l := literal.(type) {
+	case float64:
+		v, ok := value.(float64)
+		return ok && compareOrdered(v, operator, l)
+	case string:
+		v, ok := value.(string)
+		return ok && compareOrdered(v, operator, l)
+	}
+	switch operator {
+	case "==":
+		return value == literal
+	case "!=":
+		return value != literal
+	}
+	return false
+}
+
+func compareOrdered[T float64 | string](a T, operator string, b T) bool {
+	switch operator {
+	case "==":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return false
+}
+
+// pathParser is a recursive-descent parser over a JSONPath expression.
+type pathParser struct {
+	src      string
+	pos      int
+	definite bool
+}
+
+func (p *pathParser) parse() ([]pathStep, error) {
+	if !p.consume("$") {
+		return nil, p.errorf("expected the expression to start with $")
+	}
+	return p.parseSteps(false)
+}
+
+// parseSteps parses segments until the end of the input or, inside a filter
+// (relative is true), until a character that can't start a segment.
+func (p *pathParser) parseSteps(relative bool) ([]pathStep, error) {
+	var steps []pathStep
+	for p.pos < len(p.src) {
+		var step pathStep
+		var err error
+		switch {
+		case p.consume(".."):
+			p.definite = false
+			if step, err = p.parseDotOrBracket(); err == nil {
+				step = recursiveStep{inner: step}
+			}
+		case p.consume("."):
+			step, err = p.parseDotOrBracket()
+		case p.peek() == '[':
+			step, err = p.parseBracket()
+		case relative:
+			return steps, nil
+		default:
+			return nil, p.errorf("unexpected %q", p.src[p.pos:p.pos+1])
+		}
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+func (p *pathParser) parseDotOrBracket() (pathStep, error) {
+	if p.peek() == '[' {
+		return p.parseBracket()
+	}
+	if p.consume("*") {
+		p.definite = false
+		return childStep{wildcard: true}, nil
+	}
+	start := p.pos
+	for p.pos < len(p.src) && isNameChar(p.src[p.pos]) {
+		p.pos++
+	}
+	if start == p.pos {
+		return nil, p.errorf("expected a field name")
+	}
+	return childStep{names: []string{p.src[start:p.pos]}}, nil
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || c == '-' || c == '$' || c == '@' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func (p *pathParser) parseBracket() (pathStep, error) {
+	p.consume("[")
+	p.skipSpaces()
+	var step pathStep
+	var err error
+	switch {
+	case p.consume("*"):
+		p.definite = false
+		step = childStep{wildcard: true}
+	case p.consume("?("):
+		p.definite = false
+		step, err = p.parseFilter()
+	case p.peek() == '\'' || p.peek() == '"':
+		step, err = p.parseNames()
+	default:
+		step, err = p.parseIndexesOrSlice()
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if !p.consume("]") {
+		return nil, p.errorf("expected ]")
+	}
+	return step, nil
+}
+
+func (p *pathParser) parseNames() (pathStep, error) {
+	var names []string
+	for {
+		name, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		p.skipSpaces()
+		if !p.consume(",") {
+			break
+		}
+		p.skipSpaces()
+	}
+	if len(names) > 1 {
+		p.definite = false
+	}
+	return childStep{names: names}, nil
+}
+
+func (p *pathParser) parseIndexesOrSlice() (pathStep, error) {
+	var parts []*int
+	var separators []byte
+	for {
+		p.skipSpaces()
+		n, err := p.parseOptionalInt()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, n)
+		p.skipSpaces()
+		if p.peek() != ':' && p.peek() != ',' {
+			break
+		}
+		separators = append(separators, p.src[p.pos])
+		p.pos++
+	}
+
+	if len(separators) > 0 && separators[0] == ':' {
+		p.definite = false
+		if len(parts) > 3 || bytes.IndexByte(separators, ',') != -1 {
+			return nil, p.errorf("invalid slice")
+		}
+		step := sliceStep{start: parts[0], end: parts[1], step: 1}
+		if len(parts) == 3 && parts[2] != nil {
+			step.step = *parts[2]
+		}
+		if step.step <= 0 {
+			return nil, p.errorf("slice step must be positive")
+		}
+		return step, nil
+	}
+
+	indexes := make([]int, len(parts))
+	for i, n := range parts {
+		if n == nil {
+			return nil, p.errorf("expected an index")
+		}
+		indexes[i] = *n
+	}
+	if len(indexes) > 1 {
+		p.definite = false
+	}
+	return indexStep{indexes: indexes}, nil
+}
+
+func (p *pathParser) parseFilter() (pathStep, error) {
+	p.skipSpaces()
+	if !p.consume("@") {
+		return nil, p.errorf("a filter must start with @")
+	}
+	// The relative path must not affect whether the outer expression is
+	// definite: it's evaluated per candidate, not as part of the result.
+	definite := p.definite
+	operand, err := p.parseSteps(true)
+	p.definite = definite
+	if err != nil {
+		return nil, err
+	}
+	step := filterStep{operand: operand}
+	p.skipSpaces()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(op) {
+			step.operator = op
+			p.skipSpaces()
+			if step.literal, err = p.parseLiteral(); err != nil {
+				return nil, err
+			}
+			p.skipSpaces()
+			break
+		}
+	}
+	if !p.consume(")") {
+		return nil, p.errorf("expected ) to close the filter")
+	}
+	return step, nil
+}
+
+func (p *pathParser) parseLiteral() (any, error) {
+	switch {
+	case p.peek() == '\'' || p.peek() == '"':
+		return p.parseQuoted()
+	case p.consume("true"):
+		return true, nil
+	case p.consume("false"):
+		return false, nil
+	case p.consume("null"):
+		return nil, nil
+	}
+	start := p.pos
+	for p.pos < len(p.src) && strings.IndexByte("+-.eE0123456789", p.src[p.pos]) != -1 {
+		p.pos++
+	}
+	n, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+	if err != nil {
+		return nil, p.errorf("expected a string, number, true, false or null")
+	}
+	return n, nil
+}
+
+func (p *pathParser) parseQuoted() (string, error) {
+	quote := p.src[p.pos]
+	p.pos++
+	var b strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		p.pos++
+		switch {
+		case c == '\\' && p.pos < len(p.src):
+			b.WriteByte(p.src[p.pos])
+			p.pos++
+		case c == quote:
+			return b.String(), nil
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *pathParser) parseOptionalInt() (*int, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(p.src[start:p.pos])
+	if err != nil {
+		return nil, p.errorf("invalid index %q", p.src[start:p.pos])
+	}
+	return &n, nil
+}
+
+func (p *pathParser) peek() byte {
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+func (p *pathParser) consume(s string) bool {
+	if strings.HasPrefix(p.src[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *pathParser) skipSpaces() {
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *pathParser) errorf(format string, a ...any) error {
+	return fmt.Errorf("at position %d: %s", p.pos, fmt.Sprintf(format, a...))
+}
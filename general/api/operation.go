@@ -0,0 +1,117 @@
+package api
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	apispec "github.com/jfrog/jfrog-cli/docs/api-spec"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	flagOp    = "op"
+	flagParam = "param"
+)
+
+// pathPlaceholder matches a {name} template segment of an operation's path.
+var pathPlaceholder = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// requestTarget returns the method and path of the request: the method flag
+// (GET by default) and the path argument, or, with --op, the method and path
+// of the catalog operation with that operationId, with its {placeholders}
+// and query parameters filled in from --param.
+func requestTarget(c commandContext) (method, path string, err error) {
+	opID := strings.TrimSpace(c.String(flagOp))
+	params := c.StringSlice(flagParam)
+	if opID == "" {
+		if len(params) > 0 {
+			return "", "", errorutils.CheckErrorf("--%s requires --%s, to know which operation the parameters are for", flagParam, flagOp)
+		}
+		return httpMethodOrDefault(c), c.Args().First(), nil
+	}
+	if len(c.Args()) != 0 {
+		return "", "", errorutils.CheckErrorf("--%s replaces the endpoint path argument, so both can't be used", flagOp)
+	}
+	op, ok := apispec.OperationByID(opID)
+	if !ok {
+		return "", "", errorutils.CheckErrorf("no operation with operationId '%s' found in the %q OpenAPI spec bundle. Run 'jf api docs search <query>' to find it.", opID, apispec.Info().SpecBundle)
+	}
+	if c.IsSet(flagMethod) && !strings.EqualFold(strings.TrimSpace(c.String(flagMethod)), op.Method) {
+		return "", "", errorutils.CheckErrorf("operation '%s' is %s %s, but --%s is %s", op.OperationId, op.Method, op.Path, flagMethod, strings.ToUpper(c.String(flagMethod)))
+	}
+	values, err := parseParams(params)
+	if err != nil {
+		return "", "", err
+	}
+	path, err = operationPath(op, values)
+	if err != nil {
+		return "", "", err
+	}
+	return op.Method, path, nil
+}
+
+// parseParams parses --param name=value pairs. A repeated name adds a value,
+// for repeated query parameters.
+func parseParams(params []string) (map[string][]string, error) {
+	values := make(map[string][]string, len(params))
+	for _, param := range params {
+		name, value, ok := strings.Cut(param, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, errorutils.CheckErrorf("invalid --%s '%s': expected name=value", flagParam, param)
+		}
+		values[name] = append(values[name], value)
+	}
+	return values, nil
+}
+
+// operationPath fills op's path placeholders and query parameters in from
+// values. Every placeholder and required query parameter must have a value,
+// and every value must be for one of them or an optional query parameter.
+func operationPath(op apispec.Operation, values map[string][]string) (string, error) {
+	used := make(map[string]bool, len(values))
+	var missing []string
+	path := pathPlaceholder.ReplaceAllStringFunc(op.Path, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		used[name] = true
+		v, ok := values[name]
+		if !ok {
+			missing = append(missing, "path:"+name)
+			return placeholder
+		}
+		return url.PathEscape(v[len(v)-1])
+	})
+
+	query := url.Values{}
+	for _, p := range op.Parameters {
+		if p.In != "query" {
+			continue
+		}
+		used[p.Name] = true
+		if v, ok := values[p.Name]; ok {
+			query[p.Name] = v
+		} else if p.Required {
+			missing = append(missing, "query:"+p.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return "", errorutils.CheckErrorf("missing required parameter(s) of operation '%s' (%s %s): %s. Pass each with --%s name=value.", op.OperationId, op.Method, op.Path, strings.Join(missing, ", "), flagParam)
+	}
+
+	var unknown []string
+	for name := range values {
+		if !used[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return "", errorutils.CheckErrorf("operation '%s' (%s %s) has no path or query parameter named %s. Run 'jf api docs describe %s %s' to list its parameters.", op.OperationId, op.Method, op.Path, strings.Join(unknown, ", "), op.Method, op.Path)
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	return path, nil
+}
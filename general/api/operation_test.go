@@ -0,0 +1,126 @@
+//go:build !full
+
+// The requestTarget tests resolve operationIds from the stub fixtures and
+// don't apply to a full build -- same rationale as docs_search_test.go.
+
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	apispec "github.com/jfrog/jfrog-cli/docs/api-spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var repoFileOp = apispec.Operation{
+	Method:      "GET",
+	Path:        "/artifactory/api/storage/{repoKey}/{filePath}.json",
+	OperationId: "getFileInfo",
+	Parameters: []apispec.Parameter{
+		{Name: "repoKey", In: "path", Required: true},
+		{Name: "filePath", In: "path", Required: true},
+		{Name: "list", In: "query"},
+		{Name: "properties", In: "query", Required: true},
+		{Name: "X-Trace", In: "header"},
+	},
+}
+
+func TestOperationPath(t *testing.T) {
+	path, err := operationPath(repoFileOp, map[string][]string{
+		"repoKey":    {"libs-release"},
+		"filePath":   {"org/app 1"},
+		"properties": {"a", "b"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/artifactory/api/storage/libs-release/org%2Fapp%201.json?properties=a&properties=b", path)
+}
+
+func TestOperationPath_MissingRequired(t *testing.T) {
+	_, err := operationPath(repoFileOp, map[string][]string{"repoKey": {"libs-release"}, "list": {"1"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "path:filePath, query:properties")
+}
+
+func TestOperationPath_UnknownParameter(t *testing.T) {
+	_, err := operationPath(repoFileOp, map[string][]string{
+		"repoKey": {"r"}, "filePath": {"f"}, "properties": {"p"}, "repo": {"typo"}, "X-Trace": {"1"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "named X-Trace, repo", "header parameters can't be passed with --param")
+	assert.Contains(t, err.Error(), "jf api docs describe GET")
+}
+
+func TestParseParams(t *testing.T) {
+	values, err := parseParams([]string{"name=foo", "filter=a=b", "name=bar", "empty="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"name": {"foo", "bar"}, "filter": {"a=b"}, "empty": {""}}, values)
+
+	for _, invalid := range []string{"novalue", "=value"} {
+		_, err = parseParams([]string{invalid})
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestRequestTarget(t *testing.T) {
+	method, path, err := requestTarget(newMockContext(&commandArgs{path: "/a", method: "post"}))
+	require.NoError(t, err)
+	assert.Equal(t, "POST", method)
+	assert.Equal(t, "/a", path)
+
+	method, path, err = requestTarget(newMockContext(&commandArgs{op: "getGroupDetails", params: []string{"name=readers"}}))
+	require.NoError(t, err)
+	assert.Equal(t, "GET", method)
+	assert.Equal(t, "/access/api/v2/groups/readers", path)
+
+	_, path, err = requestTarget(newMockContext(&commandArgs{op: "getuserlist", params: []string{"limit=5"}}))
+	require.NoError(t, err)
+	assert.Equal(t, "/access/api/v2/users?limit=5", path, "operationIds fall back to a case-insensitive match")
+}
+
+func TestRequestTarget_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		args commandArgs
+		want string
+	}{
+		"param without op":   {commandArgs{path: "/a", params: []string{"a=b"}}, "requires --op"},
+		"op and path":        {commandArgs{path: "/a", op: "getGroupDetails"}, "replaces the endpoint path"},
+		"unknown op":         {commandArgs{op: "noSuchOperation"}, "jf api docs search"},
+		"conflicting method": {commandArgs{op: "getGroupDetails", method: "DELETE", params: []string{"name=x"}}, "is GET /access/api/v2/groups/{name}, but --method is DELETE"},
+		"missing path param": {commandArgs{op: "getGroupDetails"}, "path:name"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := requestTarget(newMockContext(&tc.args))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.want)
+		})
+	}
+}
+
+func TestRunApiCmd_Op(t *testing.T) {
+	swapReportUsageFn(t, func(_ string, _ *coreConfig.ServerDetails, ch chan<- bool) {
+		ch <- true
+	})
+	var gotMethod, gotURI string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotURI = r.Method, r.URL.RequestURI()
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+	serverDetails := &coreConfig.ServerDetails{Url: srv.URL, AccessToken: "my-token"}
+
+	var stdOut bytes.Buffer
+	ctx := newMockContext(&commandArgs{op: "getUserList", params: []string{"status=enabled", "limit=10"}})
+	require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.Equal(t, http.MethodGet, gotMethod)
+	assert.Equal(t, "/access/api/v2/users?limit=10&status=enabled", gotURI)
+
+	gotURI = ""
+	ctx = newMockContext(&commandArgs{op: "getGroupDetails"})
+	require.Error(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.Empty(t, gotURI, "a missing required parameter fails before sending anything")
+}
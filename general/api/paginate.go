@@ -0,0 +1,344 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	flagPaginate = "paginate"
+	flagNdjson   = "ndjson"
+	flagMaxPages = "max-pages"
+
+	// defaultMaxPages bounds a --paginate walk when --max-pages isn't set, so
+	// that a server ignoring the pagination parameters can't keep it going
+	// forever.
+	defaultMaxPages = 1000
+)
+
+// Query parameters recognized as a pagination cursor when the server doesn't
+// advertise a Link: rel="next" header. offset/limit is the Artifactory,
+// Access and Lifecycle convention; page_num (with num_of_rows or page_size as
+// the page size) is Xray's.
+const (
+	queryOffset    = "offset"
+	queryLimit     = "limit"
+	queryPageNum   = "page_num"
+	queryNumOfRows = "num_of_rows"
+	queryPageSize  = "page_size"
+)
+
+func validatePaginateFlags(c commandContext) error {
+	if c.Bool(flagNdjson) && !c.Bool(flagPaginate) {
+		return errorutils.CheckErrorf("--ndjson can only be used together with --paginate")
+	}
+	if c.IsSet(flagMaxPages) {
+		if !c.Bool(flagPaginate) {
+			return errorutils.CheckErrorf("--max-pages can only be used together with --paginate")
+		}
+		if c.Int(flagMaxPages) <= 0 {
+			return errorutils.CheckErrorf("--max-pages must be a positive number")
+		}
+	}
+	return nil
+}
+
+func getMaxPages(c commandContext) int {
+	if c.IsSet(flagMaxPages) {
+		return c.Int(flagMaxPages)
+	}
+	return defaultMaxPages
+}
+
+// paginateAndPrint walks every page of a list endpoint starting at firstURL,
+// and either merges the pages into a single JSON document (the default) or
+// writes each page as one compact JSON line (--ndjson). The first HTTP error
+// ends the walk and is reported exactly like a non-paginated call; with
+// --ndjson, the pages already written stay on stdout.
+func paginateAndPrint(ex *exchanger, c commandContext, method, firstURL string, body []byte, details *httputils.HttpClientDetails, filter *outputFilter, stdOut io.Writer) error {
+	ndjson := c.Bool(flagNdjson)
+	maxPages := getMaxPages(c)
+	merger := &pageMerger{}
+	visited := map[string]bool{}
+	var previousItems []json.RawMessage
+	nextURL := firstURL
+	for page := 1; nextURL != ""; page++ {
+		if visited[nextURL] {
+			log.Warn("jf api --paginate: the server pointed back to an already fetched page,", nextURL+", stopping.")
+			break
+		}
+		if page > maxPages {
+			return errorutils.CheckErrorf("jf api --paginate: stopped after %d pages, the server may be ignoring the pagination parameters of %s. Use --max-pages to fetch more pages", maxPages, nextURL)
+		}
+		visited[nextURL] = true
+
+		resp, respBody, err := ex.exchange(method, nextURL, body, details)
+		if err != nil {
+			return err
+		}
+		if isErrorStatus(resp.StatusCode) {
+			return printResponse(resp, respBody, method, nextURL, filter, stdOut)
+		}
+
+		items, _, counted := pageItems(respBody)
+		if page > 1 && counted && len(items) > 0 && sameItems(items, previousItems) {
+			// A server that ignores offset/limit or page_num keeps returning
+			// the same page under a new URL, which visited can't catch.
+			log.Warn("jf api --paginate: page", strconv.Itoa(page), "holds the same items as the previous page, the server may be ignoring the pagination parameters of", nextURL+". Stopping.")
+			break
+		}
+		previousItems = items
+		if ndjson {
+			if err = writeNdjsonPage(stdOut, page, respBody, filter); err != nil {
+				return err
+			}
+		} else if err = merger.add(page, respBody); err != nil {
+			return err
+		}
+
+		nextURL, err = nextPageURL(nextURL, resp.Header, len(items), counted)
+		if err != nil {
+			return err
+		}
+	}
+	if ndjson {
+		return nil
+	}
+	merged, err := merger.result()
+	if err != nil {
+		return err
+	}
+	if merged, err = filter.render(merged, false); err != nil {
+		return err
+	}
+	return writeBody(stdOut, merged)
+}
+
+// nextPageURL returns the URL of the page following currentURL, or "" when
+// the walk is over. A Link: rel="next" header always wins. Otherwise the
+// offset/limit or page_num query parameters of currentURL are advanced, but
+// only while the current page was a non-empty list (itemCount, counted) and,
+// when the page size is known, a full one.
+func nextPageURL(currentURL string, header http.Header, itemCount int, counted bool) (string, error) {
+	current, err := url.Parse(currentURL)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	if link := nextLink(header.Values("Link")); link != "" {
+		next, err := current.Parse(link)
+		if err != nil {
+			return "", errorutils.CheckErrorf("invalid Link rel=\"next\" URL %q: %s", link, err.Error())
+		}
+		return next.String(), nil
+	}
+	if !counted || itemCount == 0 {
+		return "", nil
+	}
+
+	query := current.Query()
+	switch {
+	case query.Has(queryOffset) || query.Has(queryLimit):
+		limit := queryInt(query, queryLimit)
+		if limit > 0 && itemCount < limit {
+			return "", nil
+		}
+		step := limit
+		if step <= 0 {
+			step = itemCount
+		}
+		query.Set(queryOffset, strconv.Itoa(queryInt(query, queryOffset)+step))
+	case query.Has(queryPageNum):
+		pageSize := queryInt(query, queryNumOfRows)
+		if pageSize <= 0 {
+			pageSize = queryInt(query, queryPageSize)
+		}
+		if pageSize > 0 && itemCount < pageSize {
+			return "", nil
+		}
+		pageNum := queryInt(query, queryPageNum)
+		if pageNum <= 0 {
+			pageNum = 1
+		}
+		query.Set(queryPageNum, strconv.Itoa(pageNum+1))
+	default:
+		return "", nil
+	}
+	current.RawQuery = query.Encode()
+	return current.String(), nil
+}
+
+// sameItems reports whether two pages hold the same items, in the same order.
+func sameItems(a, b []json.RawMessage) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !bytes.Equal(bytes.TrimSpace(a[i]), bytes.TrimSpace(b[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// queryInt returns the integer value of a query parameter, or 0 when it is
+// absent or not a number.
+func queryInt(query url.Values, key string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(query.Get(key)))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// nextLink extracts the rel="next" target from RFC 5988 Link header values,
+// e.g. `<https://host/api?page=2>; rel="next", <https://host/api?page=9>; rel="last"`.
+func nextLink(values []string) string {
+	for _, value := range values {
+		for _, link := range strings.Split(value, ",") {
+			segments := strings.Split(link, ";")
+			target := strings.TrimSpace(segments[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range segments[1:] {
+				key, val, found := strings.Cut(strings.TrimSpace(param), "=")
+				if !found || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(val), `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// pageItems returns the list a page carries: the page itself when it's a JSON
+// array, or the value of its only array-valued field when it's a JSON object
+// (e.g. {"tokens":[...]} or {"repositories":[...],"total":42}), in which case
+// key names that field. ok is false when neither applies.
+func pageItems(body []byte) (items []json.RawMessage, key string, ok bool) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return nil, "", false
+	}
+	switch trimmed[0] {
+	case '[':
+		if json.Unmarshal(trimmed, &items) != nil {
+			return nil, "", false
+		}
+		return items, "", true
+	case '{':
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(trimmed, &fields) != nil {
+			return nil, "", false
+		}
+		for k, v := range fields {
+			if v = bytes.TrimSpace(v); len(v) == 0 || v[0] != '[' {
+				continue
+			}
+			if key != "" {
+				// More than one array field: no way to tell which one is the list.
+				return nil, "", false
+			}
+			key = k
+		}
+		if key == "" || json.Unmarshal(fields[key], &items) != nil {
+			return nil, "", false
+		}
+		return items, key, true
+	default:
+		return nil, "", false
+	}
+}
+
+// pageMerger accumulates the items of every page into a single document
+// shaped like the first page: a JSON array, or the first page's object with
+// its list field replaced by the items of all pages.
+type pageMerger struct {
+	pages int
+	// first is the first page's body, kept verbatim so that a single page
+	// that isn't a mergeable list can still be printed unchanged.
+	first     []byte
+	mergeable bool
+	key       string
+	items     []json.RawMessage
+}
+
+func (m *pageMerger) add(page int, body []byte) error {
+	m.pages++
+	items, key, ok := pageItems(body)
+	if m.pages == 1 {
+		m.first, m.mergeable, m.key, m.items = body, ok, key, items
+		return nil
+	}
+	if !m.mergeable || !ok {
+		return errorutils.CheckErrorf("jf api --paginate: page %d can't be merged with the previous pages, as they are not all JSON arrays or objects holding a single array field. Use --ndjson to print each page as is", page)
+	}
+	if key != m.key {
+		return errorutils.CheckErrorf("jf api --paginate: page %d lists its items under %q, while page 1 used %q. Use --ndjson to print each page as is", page, key, m.key)
+	}
+	m.items = append(m.items, items...)
+	return nil
+}
+
+func (m *pageMerger) result() ([]byte, error) {
+	if m.pages == 1 && !m.mergeable {
+		return m.first, nil
+	}
+	items := m.items
+	if items == nil {
+		items = []json.RawMessage{}
+	}
+	if m.key == "" {
+		return marshalMerged(items)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(m.first, &fields); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	merged, err := marshalMerged(items)
+	if err != nil {
+		return nil, err
+	}
+	fields[m.key] = merged
+	return marshalMerged(fields)
+}
+
+func marshalMerged(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to merge the paginated response: %s", err.Error())
+	}
+	return data, nil
+}
+
+// writeNdjsonPage writes body as a single compact JSON line, or as what
+// --query/--raw make of it.
+func writeNdjsonPage(stdOut io.Writer, page int, body []byte, filter *outputFilter) error {
+	var line bytes.Buffer
+	if filter != nil {
+		filtered, err := filter.render(body, true)
+		if err != nil {
+			return err
+		}
+		line.Write(bytes.TrimSuffix(filtered, []byte("\n")))
+	} else if err := json.Compact(&line, body); err != nil {
+		return errorutils.CheckErrorf("jf api --paginate --ndjson: page %d is not valid JSON: %s", page, err.Error())
+	}
+	line.WriteByte('\n')
+	_, err := stdOut.Write(line.Bytes())
+	return errorutils.CheckError(err)
+}
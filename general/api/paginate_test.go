@@ -0,0 +1,359 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   string
+	}{
+		{
+			name:   "next among several relations",
+			values: []string{`<https://x.io/api?page=2>; rel="next", <https://x.io/api?page=9>; rel="last"`},
+			want:   "https://x.io/api?page=2",
+		},
+		{
+			name:   "unquoted rel and relative target",
+			values: []string{`</api/items?cursor=abc>; rel=next`},
+			want:   "/api/items?cursor=abc",
+		},
+		{
+			name:   "rel with several values",
+			values: []string{`<https://x.io/p2>; rel="prefetch next"`},
+			want:   "https://x.io/p2",
+		},
+		{
+			name:   "split across several header values",
+			values: []string{`<https://x.io/p1>; rel="prev"`, `<https://x.io/p3>; rel="next"`},
+			want:   "https://x.io/p3",
+		},
+		{
+			name:   "no next relation",
+			values: []string{`<https://x.io/p1>; rel="prev"`},
+			want:   "",
+		},
+		{
+			name: "no header",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, nextLink(tt.values))
+		})
+	}
+}
+
+func TestNextPageURL(t *testing.T) {
+	linkHeader := http.Header{}
+	linkHeader.Set("Link", `</api/items?cursor=abc>; rel="next"`)
+
+	tests := []struct {
+		name      string
+		current   string
+		header    http.Header
+		itemCount int
+		counted   bool
+		want      string
+	}{
+		{
+			name:    "link header resolved against the current URL",
+			current: "https://x.io/api/items?offset=0&limit=2",
+			header:  linkHeader,
+			want:    "https://x.io/api/items?cursor=abc",
+		},
+		{
+			name:      "offset advanced by limit on a full page",
+			current:   "https://x.io/api/items?offset=4&limit=2",
+			itemCount: 2,
+			counted:   true,
+			want:      "https://x.io/api/items?limit=2&offset=6",
+		},
+		{
+			name:      "limit without offset starts from zero",
+			current:   "https://x.io/api/items?limit=3",
+			itemCount: 3,
+			counted:   true,
+			want:      "https://x.io/api/items?limit=3&offset=3",
+		},
+		{
+			name:      "offset without limit advances by the page's item count",
+			current:   "https://x.io/api/items?offset=10",
+			itemCount: 5,
+			counted:   true,
+			want:      "https://x.io/api/items?offset=15",
+		},
+		{
+			name:      "short page ends an offset walk",
+			current:   "https://x.io/api/items?offset=0&limit=10",
+			itemCount: 3,
+			counted:   true,
+			want:      "",
+		},
+		{
+			name:      "page_num incremented on a full page",
+			current:   "https://x.io/xray/api/v1/violations?page_num=1&num_of_rows=2",
+			itemCount: 2,
+			counted:   true,
+			want:      "https://x.io/xray/api/v1/violations?num_of_rows=2&page_num=2",
+		},
+		{
+			name:      "short page ends a page_num walk",
+			current:   "https://x.io/api/items?page_num=3&page_size=10",
+			itemCount: 9,
+			counted:   true,
+			want:      "",
+		},
+		{
+			name:      "empty page ends the walk",
+			current:   "https://x.io/api/items?offset=0",
+			itemCount: 0,
+			counted:   true,
+			want:      "",
+		},
+		{
+			name:      "page that isn't a list ends the walk",
+			current:   "https://x.io/api/items?offset=0&limit=1",
+			itemCount: 0,
+			counted:   false,
+			want:      "",
+		},
+		{
+			name:      "no cursor parameters and no link is a single page",
+			current:   "https://x.io/api/items",
+			itemCount: 5,
+			counted:   true,
+			want:      "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := tt.header
+			if header == nil {
+				header = http.Header{}
+			}
+			got, err := nextPageURL(tt.current, header, tt.itemCount, tt.counted)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPageItems(t *testing.T) {
+	items, key, ok := pageItems([]byte(`[{"a":1},{"a":2}]`))
+	assert.True(t, ok)
+	assert.Empty(t, key)
+	assert.Len(t, items, 2)
+
+	items, key, ok = pageItems([]byte(`{"tokens":[{"id":"x"}],"total":7}`))
+	assert.True(t, ok)
+	assert.Equal(t, "tokens", key)
+	assert.Len(t, items, 1)
+
+	_, _, ok = pageItems([]byte(`{"a":[1],"b":[2]}`))
+	assert.False(t, ok, "two array fields are ambiguous")
+
+	_, _, ok = pageItems([]byte(`{"name":"x"}`))
+	assert.False(t, ok)
+
+	_, _, ok = pageItems([]byte(`plain text`))
+	assert.False(t, ok)
+}
+
+func TestPageMerger(t *testing.T) {
+	t.Run("arrays are concatenated", func(t *testing.T) {
+		m := &pageMerger{}
+		require.NoError(t, m.add(1, []byte(`[1,2]`)))
+		require.NoError(t, m.add(2, []byte(`[3]`)))
+		got, err := m.result()
+		require.NoError(t, err)
+		assert.JSONEq(t, `[1,2,3]`, string(got))
+	})
+
+	t.Run("objects keep the first page's other fields", func(t *testing.T) {
+		m := &pageMerger{}
+		require.NoError(t, m.add(1, []byte(`{"tokens":[{"id":"a"}],"total":2}`)))
+		require.NoError(t, m.add(2, []byte(`{"tokens":[{"id":"b"}],"total":2}`)))
+		got, err := m.result()
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"tokens":[{"id":"a"},{"id":"b"}],"total":2}`, string(got))
+	})
+
+	t.Run("single unmergeable page is printed as is", func(t *testing.T) {
+		m := &pageMerger{}
+		require.NoError(t, m.add(1, []byte(`{"name":"x"}`)))
+		got, err := m.result()
+		require.NoError(t, err)
+		assert.Equal(t, `{"name":"x"}`, string(got))
+	})
+
+	t.Run("list key mismatch is an error", func(t *testing.T) {
+		m := &pageMerger{}
+		require.NoError(t, m.add(1, []byte(`{"users":[1]}`)))
+		assert.ErrorContains(t, m.add(2, []byte(`{"groups":[2]}`)), "--ndjson")
+	})
+}
+
+// newPagedServer serves /items as offset/limit pages over total items, each
+// page being {"items":[...]} so the merge path of a wrapped list is covered.
+func newPagedServer(t *testing.T, total int) *coreConfig.ServerDetails {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var items []string
+		for i := offset; i < min(offset+limit, total); i++ {
+			items = append(items, strconv.Itoa(i))
+		}
+		w.WriteHeader(http.StatusOK)
+		if _, err := fmt.Fprintf(w, `{"items":[%s]}`, strings.Join(items, ",")); err != nil {
+			t.Log(err)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return &coreConfig.ServerDetails{Url: srv.URL, AccessToken: "my-token"}
+}
+
+func TestApiPaginate_OffsetLimitMerged(t *testing.T) {
+	serverDetails := newPagedServer(t, 5)
+	ctx := newMockContext(&commandArgs{path: "/items?offset=0&limit=2", paginate: true})
+
+	var stdOut bytes.Buffer
+	require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.JSONEq(t, `{"items":[0,1,2,3,4]}`, stdOut.String())
+}
+
+func TestApiPaginate_Ndjson(t *testing.T) {
+	serverDetails := newPagedServer(t, 4)
+	ctx := newMockContext(&commandArgs{path: "/items?offset=0&limit=2", paginate: true, ndjson: true})
+
+	var stdOut bytes.Buffer
+	require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	// Two full pages, then the empty third page that ends the walk.
+	assert.Equal(t, "{\"items\":[0,1]}\n{\"items\":[2,3]}\n{\"items\":[]}\n", stdOut.String())
+}
+
+func TestApiPaginate_FollowsLinkHeader(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/first":
+			w.Header().Set("Link", `</second>; rel="next"`)
+			_, _ = w.Write([]byte(`["a","b"]`))
+		case "/second":
+			_, _ = w.Write([]byte(`["c"]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	serverDetails := &coreConfig.ServerDetails{Url: srv.URL, AccessToken: "my-token"}
+	ctx := newMockContext(&commandArgs{path: "/first", paginate: true})
+
+	var stdOut bytes.Buffer
+	require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.JSONEq(t, `["a","b","c"]`, stdOut.String())
+}
+
+func TestApiPaginate_ErrorPageStopsWalk(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") == "0" {
+			_, _ = w.Write([]byte(`[1]`))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors":["denied"]}`))
+	}))
+	defer srv.Close()
+
+	serverDetails := &coreConfig.ServerDetails{Url: srv.URL, AccessToken: "my-token"}
+	ctx := newMockContext(&commandArgs{path: "/items?offset=0&limit=1", paginate: true})
+
+	var stdOut bytes.Buffer
+	assert.Error(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.Equal(t, `{"errors":["denied"]}`, strings.TrimSpace(stdOut.String()))
+}
+
+func TestApiPaginate_TimeoutCoversWholeWalk(t *testing.T) {
+	// Every page is full, new and answers in 400ms, so the walk never ends on
+	// its own; a 1-second --timeout must stop it after a few pages.
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(400 * time.Millisecond):
+		}
+		_, _ = fmt.Fprintf(w, `[%d]`, requests.Add(1))
+	}))
+	defer srv.Close()
+
+	serverDetails := &coreConfig.ServerDetails{Url: srv.URL, AccessToken: "my-token"}
+	ctx := newMockContext(&commandArgs{path: "/items?offset=0&limit=1", paginate: true, timeout: 1})
+
+	var stdOut bytes.Buffer
+	start := time.Now()
+	assert.Error(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.Less(t, time.Since(start), 3*time.Second)
+}
+
+func TestApiPaginate_StopsWhenServerIgnoresCursor(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`[1,2]`))
+	}))
+	defer srv.Close()
+
+	serverDetails := &coreConfig.ServerDetails{Url: srv.URL, AccessToken: "my-token"}
+	ctx := newMockContext(&commandArgs{path: "/items?offset=0", paginate: true})
+
+	var stdOut bytes.Buffer
+	require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.JSONEq(t, `[1,2]`, stdOut.String())
+	assert.EqualValues(t, 2, requests.Load())
+}
+
+func TestApiPaginate_MaxPages(t *testing.T) {
+	// Every page is full and different, but the walk never ends on its own.
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `[%d]`, requests.Add(1))
+	}))
+	defer srv.Close()
+
+	serverDetails := &coreConfig.ServerDetails{Url: srv.URL, AccessToken: "my-token"}
+	ctx := newMockContext(&commandArgs{path: "/items?offset=0&limit=1", paginate: true, maxPages: 3})
+
+	var stdOut bytes.Buffer
+	assert.ErrorContains(t, runApiCmd(ctx, serverDetails, &stdOut, nil), "--max-pages")
+	assert.EqualValues(t, 3, requests.Load())
+
+	ctx = newMockContext(&commandArgs{path: "/items", maxPages: 3})
+	assert.ErrorContains(t, runApiCmd(ctx, serverDetails, &stdOut, nil), "--paginate")
+	ctx = newMockContext(&commandArgs{path: "/items", paginate: true, maxPages: -1})
+	assert.ErrorContains(t, runApiCmd(ctx, serverDetails, &stdOut, nil), "positive")
+}
+
+func TestApiNdjsonRequiresPaginate(t *testing.T) {
+	serverDetails := newTestServer(t)
+	ctx := newMockContext(&commandArgs{path: "/success", ndjson: true})
+
+	var stdOut bytes.Buffer
+	assert.ErrorContains(t, runApiCmd(ctx, serverDetails, &stdOut, nil), "--paginate")
+}
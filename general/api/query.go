@@ -0,0 +1,104 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	flagQuery = "query"
+	flagRaw   = "raw"
+)
+
+// outputFilter turns a successful response body into what jf api prints,
+// applying --query and --raw. Error responses are never filtered: they're
+// reported as is, or as the structured object of cliutils.HandleHTTPErrorAsJSON,
+// since their shape has nothing to do with what the query was written for.
+// A nil *outputFilter prints bodies unchanged.
+type outputFilter struct {
+	query *jsonPath
+	raw   bool
+}
+
+func newOutputFilter(c commandContext) (*outputFilter, error) {
+	if !c.IsSet(flagQuery) && !c.Bool(flagRaw) {
+		return nil, nil
+	}
+	filter := &outputFilter{raw: c.Bool(flagRaw)}
+	if c.IsSet(flagQuery) {
+		query, err := compileJSONPath(c.String(flagQuery))
+		if err != nil {
+			return nil, err
+		}
+		filter.query = query
+	}
+	return filter, nil
+}
+
+// render returns what to print for body. A query selecting at most one value
+// (plain names and indexes only) yields that value, or null when it's absent;
+// any other query yields the array of all matches. With --raw, each value is
+// printed on its own line instead, strings without quotes. compact renders
+// single-line JSON, for --paginate --ndjson.
+func (f *outputFilter) render(body []byte, compact bool) ([]byte, error) {
+	if f == nil {
+		return body, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, errorutils.CheckErrorf("--query and --raw require a JSON response body, but parsing it failed: %s", err.Error())
+	}
+
+	results, single := []any{doc}, true
+	if f.query != nil {
+		results, single = f.query.evaluate(doc), f.query.definite
+	}
+	if single && len(results) == 0 {
+		results = []any{nil}
+	}
+
+	if f.raw {
+		var out bytes.Buffer
+		for _, v := range results {
+			if s, ok := v.(string); ok {
+				out.WriteString(s)
+			} else if err := encodeJSON(&out, v, true); err != nil {
+				return nil, err
+			}
+			out.WriteByte('\n')
+		}
+		return out.Bytes(), nil
+	}
+
+	var value any = results
+	if single {
+		value = results[0]
+	} else if results == nil {
+		value = []any{}
+	}
+	var out bytes.Buffer
+	if err := encodeJSON(&out, value, compact); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// encodeJSON writes v to out without escaping HTML characters, so that a "<"
+// or "&" in a value is printed as is rather than as a \u003c-style escape.
+func encodeJSON(out *bytes.Buffer, v any, compact bool) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+	if !compact {
+		encoder.SetIndent("", "  ")
+	}
+	if err := encoder.Encode(v); err != nil {
+		return errorutils.CheckError(err)
+	}
+	// Encode always terminates the value with a newline; callers add their own.
+	out.Truncate(out.Len() - 1)
+	return nil
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const queryTestDoc = `{
+  "tokens": [
+    {"token_id": "a1", "subject": "admin", "expiry": 100, "refreshable": true},
+    {"token_id": "b2", "subject": "ci", "expiry": 300, "refreshable": false},
+    {"token_id": "c3", "subject": "ci", "expiry": 200}
+  ],
+  "meta": {"total": 3, "owner": {"name": "<root>"}}
+}`
+
+func TestJSONPathEvaluate(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		want     string
+		definite bool
+	}{
+		{name: "root", expr: "$", want: queryTestDoc, definite: true},
+		{name: "jq identity", expr: ".", want: queryTestDoc, definite: true},
+		{name: "nested field", expr: "$.meta.total", want: `3`, definite: true},
+		{name: "bracket field", expr: "$['meta']['owner'].name", want: `"<root>"`, definite: true},
+		{name: "index", expr: "$.tokens[1].token_id", want: `"b2"`, definite: true},
+		{name: "negative index", expr: "$.tokens[-1].token_id", want: `"c3"`, definite: true},
+		{name: "missing field", expr: "$.nope", want: `null`, definite: true},
+		{name: "wildcard", expr: "$.tokens[*].token_id", want: `["a1","b2","c3"]`},
+		{name: "jq iteration", expr: ".tokens[].token_id", want: `["a1","b2","c3"]`},
+		{name: "jq without leading dot", expr: "tokens[0].subject", want: `"admin"`, definite: true},
+		{name: "union of indexes", expr: "$.tokens[0,2].token_id", want: `["a1","c3"]`},
+		{name: "union of names", expr: "$.tokens[0]['token_id','subject']", want: `["a1","admin"]`},
+		{name: "slice", expr: "$.tokens[1:].token_id", want: `["b2","c3"]`},
+		{name: "slice with step", expr: "$.tokens[::2].token_id", want: `["a1","c3"]`},
+		{name: "recursive descent", expr: "$..name", want: `["<root>"]`},
+		{name: "string filter", expr: "$.tokens[?(@.subject == 'ci')].token_id", want: `["b2","c3"]`},
+		{name: "numeric filter", expr: "$.tokens[?(@.expiry >= 200)].token_id", want: `["b2","c3"]`},
+		{name: "boolean filter", expr: "$.tokens[?(@.refreshable == true)].token_id", want: `["a1"]`},
+		{name: "existence filter", expr: "$.tokens[?(@.refreshable)].token_id", want: `["a1","b2"]`},
+		{name: "no match", expr: "$.tokens[?(@.subject == 'nobody')]", want: `[]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jp, err := compileJSONPath(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.definite, jp.definite)
+
+			got, err := (&outputFilter{query: jp}).render([]byte(queryTestDoc), false)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestCompileJSONPath_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"$.tokens[",
+		"$.tokens[?(@.a == )]",
+		"$.tokens['unterminated]",
+		"$.tokens[1:2:0]",
+		"$ tokens",
+	} {
+		_, err := compileJSONPath(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestOutputFilterRaw(t *testing.T) {
+	jp, err := compileJSONPath("$.tokens[*].token_id")
+	require.NoError(t, err)
+	got, err := (&outputFilter{query: jp, raw: true}).render([]byte(queryTestDoc), false)
+	require.NoError(t, err)
+	assert.Equal(t, "a1\nb2\nc3\n", string(got))
+
+	jp, err = compileJSONPath("$.meta.owner")
+	require.NoError(t, err)
+	got, err = (&outputFilter{query: jp, raw: true}).render([]byte(queryTestDoc), false)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"name\":\"<root>\"}\n", string(got), "non-string values stay compact JSON, unescaped")
+
+	got, err = (&outputFilter{raw: true}).render([]byte(`"just a string"`), false)
+	require.NoError(t, err)
+	assert.Equal(t, "just a string\n", string(got))
+}
+
+func TestOutputFilterNonJSONBody(t *testing.T) {
+	jp, err := compileJSONPath("$.a")
+	require.NoError(t, err)
+	_, err = (&outputFilter{query: jp}).render([]byte("plain text"), false)
+	assert.ErrorContains(t, err, "JSON response body")
+}
+
+func TestApiQuery(t *testing.T) {
+	serverDetails := newTestServerWithStatus(t, http.StatusOK, []byte(queryTestDoc), "application/json")
+	ctx := newMockContext(&commandArgs{path: "/tokens", query: "$.tokens[*].token_id", raw: true})
+
+	var stdOut bytes.Buffer
+	require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.Equal(t, "a1\nb2\nc3\n", stdOut.String())
+}
+
+func TestApiQuery_InvalidExpressionFailsBeforeSending(t *testing.T) {
+	serverDetails := newTestServer(t)
+	ctx := newMockContext(&commandArgs{path: "/tokens", query: "$.tokens["})
+
+	var stdOut bytes.Buffer
+	assert.ErrorContains(t, runApiCmd(ctx, serverDetails, &stdOut, nil), "invalid --query expression")
+	assert.Empty(t, stdOut.String())
+}
+
+// TestApiQuery_ErrorResponseNotFiltered guards the composition with the JSON
+// error mode: an HTTP error is reported by HandleHTTPErrorAsJSON exactly as
+// without --query, instead of the query being run against the error body.
+func TestApiQuery_ErrorResponseNotFiltered(t *testing.T) {
+	t.Setenv("JFROG_CLI_ERROR_OUTPUT_FORMAT", "json")
+	body := []byte(`{"errors":[{"code":"UNAUTHORIZED"}]}`)
+	serverDetails := newTestServerWithStatus(t, http.StatusUnauthorized, body, "application/json")
+	ctx := newMockContext(&commandArgs{path: "/tokens", query: "$.tokens[*].token_id", raw: true})
+
+	var stdOut bytes.Buffer
+	assert.Error(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(stdOut.Bytes(), &out))
+	assert.EqualValues(t, http.StatusUnauthorized, out["status_code"])
+}
+
+func TestApiQuery_AppliedToMergedPages(t *testing.T) {
+	serverDetails := newPagedServer(t, 3)
+	ctx := newMockContext(&commandArgs{path: "/items?offset=0&limit=2", paginate: true, query: "$.items[-1]"})
+
+	var stdOut bytes.Buffer
+	require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.Equal(t, "2\n", stdOut.String())
+}
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	flagRetries         = "retries"
+	flagRetryWait       = "retry-wait"
+	flagRetryAllMethods = "retry-all-methods"
+)
+
+const (
+	defaultRetryWait = time.Second
+	// maxRetryWait caps both the exponential backoff and a server-requested
+	// Retry-After, so a misconfigured proxy can't park jf api for hours.
+	maxRetryWait = 5 * time.Minute
+)
+
+// idempotentMethods are retried by default. POST and PATCH may have already
+// taken effect when a response is lost, so retrying them is opt-in
+// (--retry-all-methods).
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// retryPolicy decides whether and when a failed jf api attempt is retried.
+type retryPolicy struct {
+	// explicit is true when --retries was passed, in which case jf api's own
+	// loop replaces the HTTP client's built-in retries (see
+	// newPlatformHttpClient), even when the method isn't retried at all.
+	explicit bool
+	retries  int
+	wait     time.Duration
+}
+
+func newRetryPolicy(c commandContext, method string) (retryPolicy, error) {
+	policy := retryPolicy{explicit: c.IsSet(flagRetries), retries: c.Int(flagRetries), wait: defaultRetryWait}
+	if policy.retries < 0 {
+		return retryPolicy{}, errorutils.CheckErrorf("--retries must be a non-negative number, got %d", policy.retries)
+	}
+	if c.IsSet(flagRetryWait) {
+		wait, err := parseRetryWait(c.String(flagRetryWait))
+		if err != nil {
+			return retryPolicy{}, err
+		}
+		policy.wait = wait
+	}
+	if policy.retries > 0 && !idempotentMethods[method] && !c.Bool(flagRetryAllMethods) {
+		log.Warn(fmt.Sprintf("jf api: %s requests are not retried unless --retry-all-methods is set; --retries is ignored.", method))
+		policy.retries = 0
+	}
+	return policy, nil
+}
+
+// enabled reports whether jf api runs its own retry loop instead of the HTTP
+// client's.
+func (p retryPolicy) enabled() bool {
+	return p.explicit
+}
+
+// next decides, after the given (1-based) attempt ended with resp or err,
+// whether to retry and how long to wait first. Network errors and HTTP 429,
+// 502, 503 and 504 responses are retried; a retry that couldn't start before
+// ctx's deadline is not attempted, leaving the last outcome as the result.
+func (p retryPolicy) next(ctx context.Context, attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt > p.retries || ctx.Err() != nil {
+		return 0, false
+	}
+	var wait time.Duration
+	switch {
+	case err != nil:
+		wait = p.backoff(attempt)
+	case isRetryableStatus(resp.StatusCode):
+		var ok bool
+		if wait, ok = retryAfter(resp.Header, time.Now()); !ok {
+			wait = p.backoff(attempt)
+		}
+	default:
+		return 0, false
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+		log.Debug("jf api: the next retry would start after --timeout expires, giving up.")
+		return 0, false
+	}
+	return wait, true
+}
+
+// backoff doubles the configured wait with every attempt, up to maxRetryWait.
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	wait := p.wait
+	for i := 1; i < attempt && wait < maxRetryWait; i++ {
+		wait *= 2
+	}
+	return min(wait, maxRetryWait)
+}
+
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses a Retry-After header, given either as a number of seconds
+// or as an HTTP date, into a wait capped at maxRetryWait.
+func retryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return min(time.Duration(seconds)*time.Second, maxRetryWait), true
+	}
+	at, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return min(max(at.Sub(now), 0), maxRetryWait), true
+}
+
+// parseRetryWait accepts the same format as the --retry-wait-time flag of the
+// upload/download commands: a number ending with s or ms.
+func parseRetryWait(value string) (time.Duration, error) {
+	v := strings.TrimSpace(value)
+	unit := time.Second
+	switch {
+	case strings.HasSuffix(v, "ms"):
+		v, unit = strings.TrimSuffix(v, "ms"), time.Millisecond
+	case strings.HasSuffix(v, "s"):
+		v = strings.TrimSuffix(v, "s")
+	default:
+		v = ""
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, errorutils.CheckErrorf("--retry-wait should be a non-negative number ending with s for seconds or ms for milliseconds (for example: 10s or 100ms), got %q", value)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// attemptOutcome describes a failed attempt for the retry log line.
+func attemptOutcome(resp *http.Response, err error) string {
+	if err != nil {
+		return "failed: " + err.Error()
+	}
+	return "returned " + resp.Status
+}
+
+// sleepContext waits for d, or returns ctx's error if it's done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
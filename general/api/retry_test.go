@@ -0,0 +1,181 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRetryWait(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "10s", want: 10 * time.Second},
+		{in: "250ms", want: 250 * time.Millisecond},
+		{in: "0s", want: 0},
+		{in: "10", wantErr: true},
+		{in: "-1s", wantErr: true},
+		{in: "fast", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseRetryWait(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	header := func(v string) http.Header {
+		h := http.Header{}
+		h.Set("Retry-After", v)
+		return h
+	}
+
+	wait, ok := retryAfter(header("3"), now)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, wait)
+
+	wait, ok = retryAfter(header(now.Add(7*time.Second).Format(http.TimeFormat)), now)
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, wait)
+
+	wait, ok = retryAfter(header("86400"), now)
+	assert.True(t, ok)
+	assert.Equal(t, maxRetryWait, wait, "a very long Retry-After is capped")
+
+	_, ok = retryAfter(http.Header{}, now)
+	assert.False(t, ok)
+
+	_, ok = retryAfter(header("soon"), now)
+	assert.False(t, ok)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := retryPolicy{wait: time.Second}
+	assert.Equal(t, time.Second, p.backoff(1))
+	assert.Equal(t, 2*time.Second, p.backoff(2))
+	assert.Equal(t, 4*time.Second, p.backoff(3))
+	assert.Equal(t, maxRetryWait, p.backoff(30))
+}
+
+func TestRetryPolicyNext(t *testing.T) {
+	p := retryPolicy{explicit: true, retries: 2, wait: time.Millisecond}
+	ctx := context.Background()
+
+	_, retry := p.next(ctx, 1, &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}, nil)
+	assert.True(t, retry, "503 is retried")
+
+	_, retry = p.next(ctx, 1, &http.Response{StatusCode: http.StatusInternalServerError, Header: http.Header{}}, nil)
+	assert.False(t, retry, "500 is not retried")
+
+	_, retry = p.next(ctx, 1, nil, assert.AnError)
+	assert.True(t, retry, "network errors are retried")
+
+	_, retry = p.next(ctx, 3, &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}, nil)
+	assert.False(t, retry, "retries are exhausted after the configured count")
+
+	deadlineCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	h := http.Header{}
+	h.Set("Retry-After", "60")
+	_, retry = p.next(deadlineCtx, 1, &http.Response{StatusCode: http.StatusTooManyRequests, Header: h}, nil)
+	assert.False(t, retry, "a retry that can't start before the deadline is not attempted")
+}
+
+// newFlakyServer fails the first failures requests with status (and the given
+// Retry-After, when set), then answers 200 "OK". The returned counter holds
+// the number of requests received.
+func newFlakyServer(t *testing.T, failures int32, status int, retryAfter string) (*coreConfig.ServerDetails, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"errors":["busy"]}`))
+			return
+		}
+		_, _ = w.Write([]byte("OK"))
+	}))
+	t.Cleanup(srv.Close)
+	return &coreConfig.ServerDetails{Url: srv.URL, AccessToken: "my-token"}, &calls
+}
+
+func TestApiRetries_RecoversFrom503(t *testing.T) {
+	serverDetails, calls := newFlakyServer(t, 2, http.StatusServiceUnavailable, "")
+	ctx := newMockContext(&commandArgs{path: "/flaky", retries: 3, retryWait: "1ms"})
+
+	var stdOut bytes.Buffer
+	require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.Equal(t, "OK", strings.TrimSpace(stdOut.String()))
+	assert.EqualValues(t, 3, calls.Load())
+}
+
+func TestApiRetries_HonoursRetryAfter(t *testing.T) {
+	serverDetails, calls := newFlakyServer(t, 1, http.StatusTooManyRequests, "1")
+	ctx := newMockContext(&commandArgs{path: "/throttled", retries: 1, retryWait: "1ms"})
+
+	var stdOut bytes.Buffer
+	start := time.Now()
+	require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.GreaterOrEqual(t, time.Since(start), time.Second, "Retry-After takes precedence over --retry-wait")
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestApiRetries_ExhaustedKeepsFinalStatus(t *testing.T) {
+	t.Setenv("JFROG_CLI_ERROR_OUTPUT_FORMAT", "json")
+	serverDetails, calls := newFlakyServer(t, 10, http.StatusServiceUnavailable, "")
+	ctx := newMockContext(&commandArgs{path: "/down", retries: 2, retryWait: "1ms"})
+
+	var stdOut bytes.Buffer
+	assert.Error(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.EqualValues(t, 3, calls.Load())
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(stdOut.Bytes(), &out))
+	assert.EqualValues(t, http.StatusServiceUnavailable, out["status_code"])
+}
+
+func TestApiRetries_PostIsOptIn(t *testing.T) {
+	serverDetails, calls := newFlakyServer(t, 1, http.StatusServiceUnavailable, "")
+	ctx := newMockContext(&commandArgs{path: "/create", method: "POST", retries: 3, retryWait: "1ms"})
+
+	var stdOut bytes.Buffer
+	assert.Error(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.EqualValues(t, 1, calls.Load(), "POST must not be retried without --retry-all-methods")
+
+	serverDetails, calls = newFlakyServer(t, 1, http.StatusServiceUnavailable, "")
+	ctx = newMockContext(&commandArgs{path: "/create", method: "POST", retries: 3, retryWait: "1ms", retryAllMethods: true})
+	stdOut.Reset()
+	require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestApiRetries_InvalidRetryWait(t *testing.T) {
+	serverDetails := newTestServer(t)
+	ctx := newMockContext(&commandArgs{path: "/success", retries: 1, retryWait: "5"})
+
+	var stdOut bytes.Buffer
+	assert.ErrorContains(t, runApiCmd(ctx, serverDetails, &stdOut, nil), "--retry-wait")
+}
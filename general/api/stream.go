@@ -0,0 +1,302 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/common/progressbar"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	ioutils "github.com/jfrog/jfrog-client-go/utils/io"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	flagForm   = "form"
+	flagOutput = "output"
+)
+
+// streamed reports whether the request is sent in streaming mode, where
+// neither the request nor the response body is held in memory: --form builds
+// a multipart body on the fly and --output writes the response to a file (or
+// "-" for standard output) as it's received. --input is streamed from disk
+// too in this mode.
+func streamed(c commandContext) bool {
+	return c.IsSet(flagForm) || c.IsSet(flagOutput)
+}
+
+// validateStreamFlags rejects the flags that need the whole request or
+// response body in memory.
+func validateStreamFlags(c commandContext) error {
+	if !streamed(c) {
+		return nil
+	}
+	if c.IsSet(flagForm) && (c.IsSet(flagInput) || c.IsSet(flagData)) {
+		return errorutils.CheckErrorf("--form can't be used with --input or --data")
+	}
+	for _, flag := range []string{flagPaginate, flagQuery, flagRaw, flagRetries, flagRecord, flagReplay, flagExport, flagPrintCurl, flagValidate} {
+		if c.IsSet(flag) {
+			return errorutils.CheckErrorf("--%s can't be used with --form or --output, which stream the request and response bodies", flag)
+		}
+	}
+	return nil
+}
+
+// streamBody is a request body read while it's being sent.
+type streamBody struct {
+	reader io.Reader
+	// size is -1 when unknown, in which case the body is sent chunked.
+	size        int64
+	contentType string
+	close       func() error
+	// label and path describe a file upload for the progress bar.
+	label string
+	path  string
+}
+
+// newStreamBody opens the request body of a streamed request: a multipart
+// form, an --input file or standard input, or --data.
+func newStreamBody(c commandContext) (*streamBody, error) {
+	switch {
+	case c.IsSet(flagForm):
+		return newMultipartBody(c.StringSlice(flagForm))
+	case c.IsSet(flagInput) && c.IsSet(flagData):
+		return nil, errorutils.CheckErrorf("only one of --input and --data can be used")
+	case c.IsSet(flagInput):
+		path := c.String(flagInput)
+		if path == "-" {
+			return &streamBody{reader: os.Stdin, size: -1}, nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		info, err := f.Stat()
+		if err != nil {
+			_ = f.Close()
+			return nil, errorutils.CheckError(err)
+		}
+		// The transport closes the file once it's sent, unless the progress
+		// bar wraps it; either way, closing it again isn't an error.
+		closeFile := func() error {
+			if err := f.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+				return err
+			}
+			return nil
+		}
+		return &streamBody{reader: f, size: info.Size(), close: closeFile, label: "Uploading", path: path}, nil
+	case c.IsSet(flagData):
+		data := c.String(flagData)
+		return &streamBody{reader: strings.NewReader(data), size: int64(len(data))}, nil
+	}
+	return &streamBody{size: 0}, nil
+}
+
+// formField is a single --form entry: key=value, or key=@path for a file.
+type formField struct {
+	key   string
+	value string
+	file  string
+}
+
+func parseFormFields(raw []string) ([]formField, error) {
+	fields := make([]formField, 0, len(raw))
+	for _, entry := range raw {
+		key, value, ok := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, errorutils.CheckErrorf("--form %q must use key=value or key=@path format", entry)
+		}
+		field := formField{key: key, value: value}
+		if path, isFile := strings.CutPrefix(value, "@"); isFile {
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, errorutils.CheckErrorf("--form %s: %s", key, err.Error())
+			}
+			if info.IsDir() {
+				return nil, errorutils.CheckErrorf("--form %s: %s is a directory", key, path)
+			}
+			field.value, field.file = "", path
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// newMultipartBody streams a multipart/form-data body through a pipe, copying
+// each file part from disk as the request is sent.
+func newMultipartBody(raw []string) (*streamBody, error) {
+	fields, err := parseFormFields(raw)
+	if err != nil {
+		return nil, err
+	}
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeMultipart(mw, fields))
+	}()
+	return &streamBody{reader: pr, size: -1, contentType: mw.FormDataContentType(), close: pr.Close}, nil
+}
+
+func writeMultipart(mw *multipart.Writer, fields []formField) error {
+	for _, field := range fields {
+		if field.file == "" {
+			if err := mw.WriteField(field.key, field.value); err != nil {
+				return err
+			}
+			continue
+		}
+		part, err := mw.CreateFormFile(field.key, filepath.Base(field.file))
+		if err != nil {
+			return err
+		}
+		if err = copyFile(part, field.file); err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
+func copyFile(w io.Writer, path string) (err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// streamAndPrint sends a streamed request. A successful response body is
+// copied to --output as it arrives; an error response is small enough to be
+// read and reported like any other jf api response, and never overwrites the
+// output file.
+func streamAndPrint(ex *exchanger, method, fullURL string, body *streamBody, details *httputils.HttpClientDetails, output string, stdOut io.Writer) (err error) {
+	if body.close != nil {
+		defer func() {
+			err = errors.Join(err, body.close())
+		}()
+	}
+	progressMgr, err := progressbar.InitFilesProgressBarIfPossible(false)
+	if err != nil {
+		return err
+	}
+	if progressMgr != nil {
+		progressMgr.InitProgressReaders()
+		defer func() {
+			err = errors.Join(err, progressMgr.Quit())
+		}()
+	}
+
+	reader := body.reader
+	if progressMgr != nil && body.path != "" {
+		progress := progressMgr.NewProgressReader(body.size, body.label, body.path)
+		defer progressMgr.RemoveProgress(progress.GetId())
+		reader = progress.ActionWithProgress(reader)
+	}
+	req, err := http.NewRequestWithContext(ex.ctx, method, fullURL, reader)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	req.ContentLength = body.size
+	setStreamHeaders(req, details, body.contentType)
+
+	if ex.verbose {
+		writeVerboseRequest(method, fullURL, details)
+	}
+	resp, err := ex.client.GetClient().Do(req)
+	if err != nil {
+		log.Error("jf api: request failed:", err)
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		err = errors.Join(err, resp.Body.Close())
+	}()
+	log.Info("Http Status:", resp.StatusCode)
+
+	if isErrorStatus(resp.StatusCode) {
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if ex.verbose {
+			writeVerboseResponse(resp, respBody)
+		}
+		return printResponse(resp, respBody, method, fullURL, nil, stdOut)
+	}
+	if ex.verbose {
+		writeVerboseResponse(resp, nil)
+	}
+	return writeStreamedResponse(resp, method, fullURL, output, progressMgr, stdOut)
+}
+
+func setStreamHeaders(req *http.Request, details *httputils.HttpClientDetails, contentType string) {
+	for k, v := range details.Headers {
+		req.Header.Set(k, v)
+	}
+	if contentType != "" && !hasHeaderFold(details.Headers, "Content-Type") {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if !hasHeaderFold(details.Headers, "Authorization") {
+		switch {
+		case details.AccessToken != "":
+			req.Header.Set("Authorization", "Bearer "+details.AccessToken)
+		case details.User != "" && details.Password != "":
+			req.SetBasicAuth(details.User, details.Password)
+		}
+	}
+}
+
+// writeStreamedResponse copies the response body to output, or to stdOut for
+// "-". A file is written next to its final path and renamed once complete, so
+// an interrupted download never leaves a truncated file behind. Without
+// --output (a --form upload), the response is printed like any other.
+func writeStreamedResponse(resp *http.Response, method, fullURL, output string, progressMgr ioutils.ProgressMgr, stdOut io.Writer) error {
+	switch output {
+	case "":
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		return printResponse(resp, respBody, method, fullURL, nil, stdOut)
+	case "-":
+		_, err := io.Copy(stdOut, resp.Body)
+		return errorutils.CheckError(err)
+	}
+
+	reader := io.Reader(resp.Body)
+	if progressMgr != nil {
+		progress := progressMgr.NewProgressReader(resp.ContentLength, "Downloading", output)
+		defer progressMgr.RemoveProgress(progress.GetId())
+		reader = progress.ActionWithProgress(reader)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(output), "."+filepath.Base(output)+".*.part")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	written, err := io.Copy(tmp, reader)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		// CreateTemp's 0600 is meant for the partial file only.
+		err = os.Chmod(tmp.Name(), 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), output)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return errorutils.CheckError(err)
+	}
+	log.Info(fmt.Sprintf("jf api: wrote %d bytes to %s", written, output))
+	return nil
+}
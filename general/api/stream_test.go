@@ -0,0 +1,134 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStreamServer(t *testing.T, handler http.HandlerFunc) *coreConfig.ServerDetails {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return &coreConfig.ServerDetails{Url: srv.URL, AccessToken: "my-token"}
+}
+
+func TestApiFormUpload(t *testing.T) {
+	upload := filepath.Join(t.TempDir(), "bundle.tgz")
+	require.NoError(t, os.WriteFile(upload, []byte("binary\x00content"), 0o600))
+
+	var fields map[string][]string
+	var fileName, fileContent, auth string
+	serverDetails := newStreamServer(t, func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if !assert.NoError(t, r.ParseMultipartForm(1<<20)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fields = r.MultipartForm.Value
+		file, header, err := r.FormFile("file")
+		if assert.NoError(t, err) {
+			fileName = header.Filename
+			content, _ := io.ReadAll(file)
+			fileContent = string(content)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	})
+
+	ctx := newMockContext(&commandArgs{path: "/upload", method: "POST", form: []string{"description=nightly build", "file=@" + upload}})
+	var stdOut bytes.Buffer
+	require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.Equal(t, map[string][]string{"description": {"nightly build"}}, fields)
+	assert.Equal(t, "bundle.tgz", fileName)
+	assert.Equal(t, "binary\x00content", fileContent)
+	assert.Equal(t, "Bearer my-token", auth)
+	assert.JSONEq(t, `{"ok":true}`, stdOut.String(), "without --output the response is printed as usual")
+}
+
+func TestApiOutputDownload(t *testing.T) {
+	serverDetails := newStreamServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(bytes.Repeat([]byte("x"), 64*1024))
+	})
+	dir := t.TempDir()
+	output := filepath.Join(dir, "disk.img")
+
+	ctx := newMockContext(&commandArgs{path: "/artifact", output: output})
+	var stdOut bytes.Buffer
+	require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.Empty(t, stdOut.String())
+	content, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Len(t, content, 64*1024)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no partial file is left behind")
+
+	ctx = newMockContext(&commandArgs{path: "/artifact", output: "-"})
+	require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.Equal(t, 64*1024, stdOut.Len())
+}
+
+func TestApiOutputErrorStatus(t *testing.T) {
+	serverDetails := newStreamServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"not found"}]}`))
+	})
+	output := filepath.Join(t.TempDir(), "disk.img")
+
+	ctx := newMockContext(&commandArgs{path: "/missing", output: output})
+	var stdOut bytes.Buffer
+	assert.Error(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.Contains(t, stdOut.String(), "not found", "the error body is printed, not saved")
+	assert.NoFileExists(t, output)
+}
+
+func TestApiStreamedInput(t *testing.T) {
+	upload := filepath.Join(t.TempDir(), "disk.img")
+	require.NoError(t, os.WriteFile(upload, []byte("raw image"), 0o600))
+
+	var received string
+	var contentLength int64
+	serverDetails := newStreamServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received, contentLength = string(body), r.ContentLength
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	ctx := newMockContext(&commandArgs{path: "/artifact", method: "PUT", output: "-"})
+	ctx.setString(flagInput, upload)
+	var stdOut bytes.Buffer
+	require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.Equal(t, "raw image", received)
+	assert.EqualValues(t, len("raw image"), contentLength, "a file is sent with its size, not chunked")
+}
+
+func TestApiStreamFlagConflicts(t *testing.T) {
+	serverDetails := newTestServer(t)
+	tests := []struct {
+		name    string
+		args    commandArgs
+		wantErr string
+	}{
+		{"form with data", commandArgs{path: "/x", form: []string{"a=b"}, data: "{}"}, "--form can't be used with --input or --data"},
+		{"output with query", commandArgs{path: "/x", output: "out", query: "$.a"}, "--query can't be used with --form or --output"},
+		{"form with paginate", commandArgs{path: "/x", form: []string{"a=b"}, paginate: true}, "--paginate can't be used"},
+		{"output with record", commandArgs{path: "/x", output: "out", record: "a.har"}, "--record can't be used"},
+		{"malformed form field", commandArgs{path: "/x", form: []string{"novalue"}}, "key=value or key=@path"},
+		{"missing form file", commandArgs{path: "/x", form: []string{"file=@/does/not/exist"}}, "--form file:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdOut bytes.Buffer
+			assert.ErrorContains(t, runApiCmd(newMockContext(&tt.args), serverDetails, &stdOut, nil), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	apispec "github.com/jfrog/jfrog-cli/docs/api-spec"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const flagValidate = "validate"
+
+// validationIssue is a single mismatch between a request and the catalog
+// operation it targets.
+type validationIssue struct {
+	// In is "path", "query" or "body".
+	In      string `json:"in"`
+	Name    string `json:"name,omitempty"`
+	Message string `json:"message"`
+}
+
+func (i validationIssue) String() string {
+	switch {
+	case i.Name == "":
+		return i.In + ": " + i.Message
+	case i.In == "body":
+		return "body property " + i.Name + ": " + i.Message
+	default:
+		return i.In + " parameter " + i.Name + ": " + i.Message
+	}
+}
+
+// validationResult is the outcome of checking a request against the embedded
+// OpenAPI catalog, and the JSON/table payload of `jf api docs validate`.
+type validationResult struct {
+	SpecBundle  string            `json:"spec_bundle"`
+	SpecVersion string            `json:"spec_version"`
+	Method      string            `json:"method"`
+	Path        string            `json:"path"`
+	Operation   string            `json:"operation"`
+	OperationId string            `json:"operation_id,omitempty"`
+	Valid       bool              `json:"valid"`
+	Issues      []validationIssue `json:"issues"`
+}
+
+// report renders the issues as the error returned by jf api --validate.
+func (r *validationResult) report() string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "the request does not match %s %s in the embedded %q OpenAPI spec bundle:", r.Method, r.Operation, r.SpecBundle)
+	for _, issue := range r.Issues {
+		sb.WriteString("\n  - " + issue.String())
+	}
+	return sb.String()
+}
+
+// validateRequest checks a request against the catalog operation matching
+// method and pathArg (which may carry a query string): that path parameters
+// were filled in, that required query parameters are present, and that a
+// JSON body has the operation's required top-level properties with the
+// declared primitive types. It's deliberately lenient where the catalog is
+// vague: unknown properties, nested schemas and null values aren't checked,
+// so a request the server would accept is never rejected locally. An error is
+// returned only when no catalog operation matches at all.
+func validateRequest(method, pathArg string, body []byte) (*validationResult, error) {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	path, rawQuery, _ := strings.Cut(normalizeApiPath(pathArg), "?")
+
+	info := apispec.Info()
+	op, pathParams, ok := apispec.MatchOperation(method, path)
+	if !ok {
+		return nil, errorutils.CheckErrorf(
+			"no operation found for %s %s in the embedded %q OpenAPI spec bundle, so the request can't be validated. "+
+				"Run 'jf api docs search <query>' to find the exact method/path, or drop --validate to send the request as is.",
+			method, path, info.SpecBundle)
+	}
+
+	result := &validationResult{
+		SpecBundle:  info.SpecBundle,
+		SpecVersion: info.SpecVersion,
+		Method:      method,
+		Path:        path,
+		Operation:   op.Path,
+		OperationId: op.OperationId,
+		Issues:      []validationIssue{},
+	}
+	result.Issues = append(result.Issues, validateParameters(op, pathParams, rawQuery)...)
+	result.Issues = append(result.Issues, validateBody(op.RequestBody, body)...)
+	result.Valid = len(result.Issues) == 0
+	return result, nil
+}
+
+func validateParameters(op apispec.Operation, pathParams map[string]string, rawQuery string) []validationIssue {
+	var issues []validationIssue
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		issues = append(issues, validationIssue{In: "query", Message: "the query string can't be parsed: " + err.Error()})
+	}
+	for _, param := range op.Parameters {
+		switch param.In {
+		case "path":
+			// A placeholder copied verbatim from 'jf api docs search' matches
+			// its own template, but was surely meant to be replaced.
+			if value, ok := pathParams[param.Name]; ok && value == "{"+param.Name+"}" {
+				issues = append(issues, validationIssue{In: "path", Name: param.Name, Message: "the {" + param.Name + "} placeholder was not replaced with a value"})
+			}
+		case "query":
+			if param.Required && !query.Has(param.Name) {
+				issues = append(issues, validationIssue{In: "query", Name: param.Name, Message: "required parameter is missing"})
+			}
+		}
+	}
+	return issues
+}
+
+func validateBody(requestBody *apispec.RequestBody, body []byte) []validationIssue {
+	if len(bytes.TrimSpace(body)) == 0 {
+		if requestBody != nil && requestBody.Required {
+			return []validationIssue{{In: "body", Message: "a JSON request body is required (use --data or --input)"}}
+		}
+		return nil
+	}
+	if requestBody == nil {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return []validationIssue{{In: "body", Message: "not valid JSON: " + err.Error()}}
+	}
+	if itemType, isArray := strings.CutPrefix(requestBody.Schema, "array<"); isArray {
+		items, ok := doc.([]any)
+		if !ok {
+			return []validationIssue{{In: "body", Message: "expected a JSON array, got " + jsonTypeName(doc)}}
+		}
+		if len(requestBody.Properties) == 0 {
+			if message := checkPropertyType(requestBody.Schema, doc); message != "" {
+				return []validationIssue{{In: "body", Message: message}}
+			}
+			return nil
+		}
+		// The properties are those of each item.
+		var issues []validationIssue
+		for i, item := range items {
+			prefix := fmt.Sprintf("[%d]", i)
+			object, ok := item.(map[string]any)
+			if !ok {
+				issues = append(issues, validationIssue{In: "body", Name: prefix, Message: fmt.Sprintf("expected %s, got %s", strings.TrimSuffix(itemType, ">"), jsonTypeName(item))})
+				continue
+			}
+			issues = append(issues, validateProperties(requestBody.Properties, object, prefix+".")...)
+		}
+		return issues
+	}
+	if len(requestBody.Properties) == 0 {
+		return nil
+	}
+	object, ok := doc.(map[string]any)
+	if !ok {
+		return []validationIssue{{In: "body", Message: "expected a JSON object, got " + jsonTypeName(doc)}}
+	}
+	return validateProperties(requestBody.Properties, object, "")
+}
+
+// validateProperties checks the properties of object, whose issues are named
+// after prefix followed by the property's name.
+func validateProperties(properties []apispec.Property, object map[string]any, prefix string) []validationIssue {
+	var issues []validationIssue
+	for _, prop := range properties {
+		value, present := object[prop.Name]
+		switch {
+		case !present:
+			if prop.Required {
+				issues = append(issues, validationIssue{In: "body", Name: prefix + prop.Name, Message: "required property is missing"})
+			}
+		case value == nil:
+			// Nullability isn't tracked by the catalog (see apispec.flexType).
+		default:
+			if message := checkPropertyType(prop.Type, value); message != "" {
+				issues = append(issues, validationIssue{In: "body", Name: prefix + prop.Name, Message: message})
+			}
+		}
+	}
+	return issues
+}
+
+// checkPropertyType returns why value doesn't match the catalog type hint
+// typ (see apispec.Property), or "" when it does.
+func checkPropertyType(typ string, value any) string {
+	if itemType, ok := strings.CutPrefix(typ, "array<"); ok {
+		items, isArray := value.([]any)
+		if !isArray {
+			return fmt.Sprintf("expected %s, got %s", typ, jsonTypeName(value))
+		}
+		itemType = strings.TrimSuffix(itemType, ">")
+		for i, item := range items {
+			if item != nil && !matchesType(itemType, item) {
+				return fmt.Sprintf("expected %s, got %s at index %d", typ, jsonTypeName(item), i)
+			}
+		}
+		return ""
+	}
+	if !matchesType(typ, value) {
+		return fmt.Sprintf("expected %s, got %s", typ, jsonTypeName(value))
+	}
+	return ""
+}
+
+func matchesType(typ string, value any) bool {
+	switch typ {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	case "integer":
+		n, ok := value.(json.Number)
+		return ok && !strings.ContainsAny(n.String(), ".eE")
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "null", "":
+		return true
+	default:
+		// The name of a referenced component schema, which describes an object.
+		_, ok := value.(map[string]any)
+		return ok
+	}
+}
+
+func jsonTypeName(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			return "number"
+		}
+		return "integer"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,198 @@
+//go:build !full
+
+// These tests validate against the stub fixtures' operations and don't apply
+// to a full build -- same rationale as docs_describe_test.go.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	coreConfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	apispec "github.com/jfrog/jfrog-cli/docs/api-spec"
+	clientlog "github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func TestValidateRequest_ValidBody(t *testing.T) {
+	result, err := validateRequest("post", "/access/api/v2/users", []byte(`{"username":"u","email":"u@example.com","admin":false,"groups":["readers"],"middle_name":null}`))
+	require.NoError(t, err)
+	assert.True(t, result.Valid, "issues: %v", result.Issues)
+	assert.Equal(t, "POST", result.Method)
+	assert.Equal(t, "/access/api/v2/users", result.Operation)
+	assert.Equal(t, "createUser", result.OperationId)
+}
+
+func TestValidateRequest_BodyIssues(t *testing.T) {
+	result, err := validateRequest("POST", "/access/api/v2/users", []byte(`{"username":42,"admin":"yes","groups":["a",1],"extra":true}`))
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.ElementsMatch(t, []validationIssue{
+		{In: "body", Name: "admin", Message: "expected boolean, got string"},
+		{In: "body", Name: "email", Message: "required property is missing"},
+		{In: "body", Name: "groups", Message: "expected array<string>, got integer at index 1"},
+		{In: "body", Name: "username", Message: "expected string, got integer"},
+	}, result.Issues, "unknown properties such as 'extra' are not reported")
+}
+
+func TestValidateRequest_MissingRequiredBody(t *testing.T) {
+	result, err := validateRequest("POST", "/access/api/v2/users", nil)
+	require.NoError(t, err)
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, "body", result.Issues[0].In)
+	assert.Contains(t, result.Issues[0].Message, "required")
+}
+
+func TestValidateRequest_MalformedBody(t *testing.T) {
+	result, err := validateRequest("POST", "/access/api/v2/users", []byte(`{"username":`))
+	require.NoError(t, err)
+	require.Len(t, result.Issues, 1)
+	assert.Contains(t, result.Issues[0].Message, "not valid JSON")
+
+	result, err = validateRequest("POST", "/access/api/v2/users", []byte(`["u"]`))
+	require.NoError(t, err)
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, "expected a JSON object, got array", result.Issues[0].Message)
+}
+
+func TestValidateBody_Array(t *testing.T) {
+	requestBody := &apispec.RequestBody{Required: true, Schema: "array<Widget>", Properties: []apispec.Property{
+		{Name: "name", Type: "string", Required: true},
+		{Name: "size", Type: "integer"},
+	}}
+	assert.Empty(t, validateBody(requestBody, []byte(`[{"name":"a"},{"name":"b","size":2}]`)))
+	assert.Empty(t, validateBody(requestBody, []byte(`[]`)))
+	assert.Equal(t, []validationIssue{
+		{In: "body", Name: "[0].size", Message: "expected integer, got string"},
+		{In: "body", Name: "[1].name", Message: "required property is missing"},
+		{In: "body", Name: "[2]", Message: "expected Widget, got string"},
+	}, validateBody(requestBody, []byte(`[{"name":"a","size":"big"},{},"c"]`)))
+	assert.Equal(t, []validationIssue{{In: "body", Message: "expected a JSON array, got object"}},
+		validateBody(requestBody, []byte(`{"name":"a"}`)))
+
+	names := &apispec.RequestBody{Schema: "array<string>"}
+	assert.Empty(t, validateBody(names, []byte(`["a","b"]`)))
+	assert.Equal(t, []validationIssue{{In: "body", Message: "expected array<string>, got integer at index 1"}},
+		validateBody(names, []byte(`["a",1]`)))
+}
+
+func TestValidateRequest_PathTemplate(t *testing.T) {
+	result, err := validateRequest("DELETE", "worker/api/v1/workers/my-worker", nil)
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, "/worker/api/v1/workers/{workerKey}", result.Operation)
+	assert.Equal(t, "/worker/api/v1/workers/my-worker", result.Path)
+
+	result, err = validateRequest("DELETE", "/worker/api/v1/workers/{workerKey}", nil)
+	require.NoError(t, err)
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, validationIssue{In: "path", Name: "workerKey", Message: "the {workerKey} placeholder was not replaced with a value"}, result.Issues[0])
+}
+
+func TestValidateRequest_QueryStringIgnoredForMatching(t *testing.T) {
+	result, err := validateRequest("GET", "/access/api/v2/users?limit=10&status=enabled", nil)
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, "/access/api/v2/users", result.Path)
+}
+
+func TestValidateRequest_UnknownOperation(t *testing.T) {
+	_, err := validateRequest("GET", "/not/a/real/path", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "docs search")
+}
+
+func TestValidationResultReport(t *testing.T) {
+	result := &validationResult{SpecBundle: "stub", Method: "POST", Operation: "/access/api/v2/users", Issues: []validationIssue{
+		{In: "body", Name: "email", Message: "required property is missing"},
+		{In: "query", Name: "limit", Message: "required parameter is missing"},
+		{In: "body", Message: "not valid JSON"},
+	}}
+	assert.Equal(t, `the request does not match POST /access/api/v2/users in the embedded "stub" OpenAPI spec bundle:
+  - body property email: required property is missing
+  - query parameter limit: required parameter is missing
+  - body: not valid JSON`, result.report())
+}
+
+// TestApiValidate_FailsBeforeSending guards that --validate rejects a bad body
+// locally, without any request reaching the server.
+func TestApiValidate_FailsBeforeSending(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+	serverDetails := &coreConfig.ServerDetails{Url: srv.URL, AccessToken: "my-token"}
+
+	ctx := newMockContext(&commandArgs{path: "/access/api/v2/users", method: "POST", data: `{"username":"u"}`, validate: true})
+	var stdOut bytes.Buffer
+	err := runApiCmd(ctx, serverDetails, &stdOut, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "body property email: required property is missing")
+	assert.Zero(t, calls.Load())
+
+	ctx = newMockContext(&commandArgs{path: "/access/api/v2/users", method: "POST", data: `{"username":"u","email":"u@example.com"}`, validate: true})
+	require.NoError(t, runApiCmd(ctx, serverDetails, &stdOut, nil))
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func newValidateApp(stdOut *bytes.Buffer, capturedErr *error) *cli.App {
+	app := cli.NewApp()
+	app.Flags = []cli.Flag{
+		cli.StringFlag{Name: "format"},
+		cli.StringFlag{Name: "input"},
+		cli.StringFlag{Name: "data, d"},
+	}
+	app.Action = func(c *cli.Context) error {
+		*capturedErr = runValidateCmd(c, stdOut)
+		return nil
+	}
+	return app
+}
+
+func TestRunValidateCmd_JSON(t *testing.T) {
+	var jsonOut, logOut bytes.Buffer
+	logger := clientlog.NewLoggerWithFlags(clientlog.INFO, &logOut, 0)
+	logger.SetOutputWriter(&jsonOut)
+	prevLogger := clientlog.GetLogger()
+	t.Cleanup(func() { clientlog.SetLogger(prevLogger) })
+	clientlog.SetLogger(logger)
+
+	var stdOut bytes.Buffer
+	var runErr error
+	app := newValidateApp(&stdOut, &runErr)
+	require.NoError(t, app.Run([]string{"cmd", "-d", `{"email":"u@example.com"}`, "POST", "/access/api/v2/users"}))
+	require.Error(t, runErr, "an invalid request should exit non-zero")
+
+	var result validationResult
+	require.NoError(t, json.Unmarshal(jsonOut.Bytes(), &result))
+	assert.Equal(t, "stub", result.SpecBundle)
+	assert.False(t, result.Valid)
+	assert.Equal(t, []validationIssue{{In: "body", Name: "username", Message: "required property is missing"}}, result.Issues)
+}
+
+func TestRunValidateCmd_Table(t *testing.T) {
+	var stdOut bytes.Buffer
+	var runErr error
+	app := newValidateApp(&stdOut, &runErr)
+	require.NoError(t, app.Run([]string{"cmd", "--format", "table", "-d", `{"username":"u","email":"u@example.com"}`, "POST", "/access/api/v2/users"}))
+	require.NoError(t, runErr)
+	assert.Contains(t, stdOut.String(), "OPERATION")
+	assert.Contains(t, stdOut.String(), "true")
+}
+
+func TestRunValidateCmd_WrongNumberOfArguments(t *testing.T) {
+	var stdOut bytes.Buffer
+	var runErr error
+	app := newValidateApp(&stdOut, &runErr)
+	require.NoError(t, app.Run([]string{"cmd", "POST"}))
+	assert.Error(t, runErr)
+}
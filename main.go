@@ -30,9 +30,15 @@ import (
 	"github.com/jfrog/jfrog-cli/config"
 	"github.com/jfrog/jfrog-cli/docs/common"
 	apiDocs "github.com/jfrog/jfrog-cli/docs/general/api"
+	apiBatchDocs "github.com/jfrog/jfrog-cli/docs/general/apibatch"
 	apiDocsNodeDocs "github.com/jfrog/jfrog-cli/docs/general/apidocs"
 	apiDocsDescribeDocs "github.com/jfrog/jfrog-cli/docs/general/apidocsdescribe"
+	apiDocsDiffDocs "github.com/jfrog/jfrog-cli/docs/general/apidocsdiff"
+	apiDocsListDocs "github.com/jfrog/jfrog-cli/docs/general/apidocslist"
 	apiDocsSearchDocs "github.com/jfrog/jfrog-cli/docs/general/apidocssearch"
+	apiDocsSyncDocs "github.com/jfrog/jfrog-cli/docs/general/apidocssync"
+	apiDocsTagsDocs "github.com/jfrog/jfrog-cli/docs/general/apidocstags"
+	apiDocsValidateDocs "github.com/jfrog/jfrog-cli/docs/general/apidocsvalidate"
 	loginDocs "github.com/jfrog/jfrog-cli/docs/general/login"
 	oidcDocs "github.com/jfrog/jfrog-cli/docs/general/oidc"
 	summaryDocs "github.com/jfrog/jfrog-cli/docs/general/summary"
@@ -80,6 +86,7 @@ var traceID string
 func main() {
 	log.SetDefaultLogger()
 	err := execMain()
+	packagealias.RecordDispatchResult(coreutils.GetExitCode(err, 0, 0, false).Code)
 	if cleanupErr := fileutils.CleanOldDirs(); cleanupErr != nil {
 		clientlog.Warn("failed while attempting to cleanup old CLI temp directories:", cleanupErr)
 	}
@@ -396,6 +403,16 @@ func getCommands() ([]cli.Command, error) {
 			Category:     otherCategory,
 			Action:       api.Command,
 			Subcommands: []cli.Command{
+				{
+					Name:         "batch",
+					Flags:        cliutils.GetCommandFlags(cliutils.ApiBatch),
+					Usage:        corecommon.ResolveDescription(apiBatchDocs.GetDescription(), apiBatchDocs.GetAIDescription()),
+					HelpName:     corecommon.CreateUsage("api batch", corecommon.ResolveDescription(apiBatchDocs.GetDescription(), apiBatchDocs.GetAIDescription()), apiBatchDocs.Usage),
+					UsageText:    apiBatchDocs.GetArguments(),
+					ArgsUsage:    common.CreateEnvVars(),
+					BashComplete: corecommon.CreateBashCompletionFunc(),
+					Action:       api.BatchCommand,
+				},
 				{
 					Name:     "docs",
 					Usage:    corecommon.ResolveDescription(apiDocsNodeDocs.GetDescription(), apiDocsNodeDocs.GetAIDescription()),
@@ -420,6 +437,52 @@ func getCommands() ([]cli.Command, error) {
 							BashComplete: corecommon.CreateBashCompletionFunc(),
 							Action:       api.DescribeCommand,
 						},
+						{
+							Name:         "tags",
+							Flags:        cliutils.GetCommandFlags(cliutils.ApiDocsTags),
+							Usage:        corecommon.ResolveDescription(apiDocsTagsDocs.GetDescription(), apiDocsTagsDocs.GetAIDescription()),
+							HelpName:     corecommon.CreateUsage("api docs tags", corecommon.ResolveDescription(apiDocsTagsDocs.GetDescription(), apiDocsTagsDocs.GetAIDescription()), apiDocsTagsDocs.Usage),
+							UsageText:    apiDocsTagsDocs.GetArguments(),
+							BashComplete: corecommon.CreateBashCompletionFunc(),
+							Action:       api.TagsCommand,
+						},
+						{
+							Name:         "list",
+							Flags:        cliutils.GetCommandFlags(cliutils.ApiDocsList),
+							Usage:        corecommon.ResolveDescription(apiDocsListDocs.GetDescription(), apiDocsListDocs.GetAIDescription()),
+							HelpName:     corecommon.CreateUsage("api docs list", corecommon.ResolveDescription(apiDocsListDocs.GetDescription(), apiDocsListDocs.GetAIDescription()), apiDocsListDocs.Usage),
+							UsageText:    apiDocsListDocs.GetArguments(),
+							BashComplete: corecommon.CreateBashCompletionFunc(),
+							Action:       api.ListCommand,
+						},
+						{
+							Name:         "validate",
+							Flags:        cliutils.GetCommandFlags(cliutils.ApiDocsValidate),
+							Usage:        corecommon.ResolveDescription(apiDocsValidateDocs.GetDescription(), apiDocsValidateDocs.GetAIDescription()),
+							HelpName:     corecommon.CreateUsage("api docs validate", corecommon.ResolveDescription(apiDocsValidateDocs.GetDescription(), apiDocsValidateDocs.GetAIDescription()), apiDocsValidateDocs.Usage),
+							UsageText:    apiDocsValidateDocs.GetArguments(),
+							BashComplete: corecommon.CreateBashCompletionFunc(),
+							Action:       api.ValidateCommand,
+						},
+						{
+							Name:         "diff",
+							Flags:        cliutils.GetCommandFlags(cliutils.ApiDocsDiff),
+							Usage:        corecommon.ResolveDescription(apiDocsDiffDocs.GetDescription(), apiDocsDiffDocs.GetAIDescription()),
+							HelpName:     corecommon.CreateUsage("api docs diff", corecommon.ResolveDescription(apiDocsDiffDocs.GetDescription(), apiDocsDiffDocs.GetAIDescription()), apiDocsDiffDocs.Usage),
+							UsageText:    apiDocsDiffDocs.GetArguments(),
+							BashComplete: corecommon.CreateBashCompletionFunc(),
+							Action:       api.DiffCommand,
+						},
+						{
+							Name:         "sync",
+							Flags:        cliutils.GetCommandFlags(cliutils.ApiDocsSync),
+							Usage:        corecommon.ResolveDescription(apiDocsSyncDocs.GetDescription(), apiDocsSyncDocs.GetAIDescription()),
+							HelpName:     corecommon.CreateUsage("api docs sync", corecommon.ResolveDescription(apiDocsSyncDocs.GetDescription(), apiDocsSyncDocs.GetAIDescription()), apiDocsSyncDocs.Usage),
+							UsageText:    apiDocsSyncDocs.GetArguments(),
+							ArgsUsage:    common.CreateEnvVars(),
+							BashComplete: corecommon.CreateBashCompletionFunc(),
+							Action:       api.SyncCommand,
+						},
 					},
 				},
 			},
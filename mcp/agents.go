@@ -0,0 +1,217 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The container keys agents hold their MCP server definitions under.
+const (
+	// mcpServersKey is used by Cursor, Claude, Windsurf, Gemini CLI and
+	// JetBrains (Junie).
+	mcpServersKey = "mcpServers"
+	// vsCodeServersKey is used by VS Code (Copilot).
+	vsCodeServersKey = "servers"
+	// zedServersKey is used by Zed, in its settings file.
+	zedServersKey = "context_servers"
+	// codexServersKey is used by Codex, as a TOML table.
+	codexServersKey = "mcp_servers"
+)
+
+// agentSpec describes where and how to write the MCP server entry for a given
+// AI agent. The config schema is agent-specific: the key holding the servers,
+// the file format, and the shape of a remote server entry all vary (Cursor
+// omits the "type" field for remote servers, while Claude requires
+// "type": "http", and Windsurf names the URL "serverUrl").
+type agentSpec struct {
+	// displayName names the agent in messages.
+	displayName string
+	// projectFile is the config file path relative to the project directory,
+	// or empty when the agent has no project-level configuration.
+	projectFile string
+	// globalFile is the user-level config file path. It starts with "~" for
+	// the home directory, unless inConfigDir is set.
+	globalFile string
+	// inConfigDir makes globalFile relative to the OS user configuration
+	// directory (os.UserConfigDir) instead.
+	inConfigDir bool
+	// serversKey is the top-level key holding the MCP server definitions.
+	serversKey string
+	format     configFormat
+	// entry builds the agent's server entry for a remote MCP URL.
+	entry func(url string) map[string]interface{}
+	// localEntry builds the agent's server entry for a local server, launched
+	// as command with args and speaking over stdio.
+	localEntry func(command string, args []string) map[string]interface{}
+	// nextStep tells the user how to activate the connection in the agent.
+	nextStep string
+}
+
+func urlEntry(url string) map[string]interface{} {
+	return map[string]interface{}{"url": url}
+}
+
+func typedHTTPEntry(url string) map[string]interface{} {
+	return map[string]interface{}{"type": "http", "url": url}
+}
+
+func commandEntry(command string, args []string) map[string]interface{} {
+	return map[string]interface{}{"command": command, "args": args}
+}
+
+func typedStdioEntry(command string, args []string) map[string]interface{} {
+	return map[string]interface{}{"type": "stdio", "command": command, "args": args}
+}
+
+// supportedAgents maps the user-facing agent name to its configuration spec.
+var supportedAgents = map[string]agentSpec{
+	"cursor": {
+		displayName: "Cursor",
+		projectFile: filepath.Join(".cursor", "mcp.json"),
+		globalFile:  filepath.Join("~", ".cursor", "mcp.json"),
+		serversKey:  mcpServersKey,
+		format:      formatJSON,
+		entry:       urlEntry,
+		localEntry:  commandEntry,
+		nextStep:    "Reload Cursor and approve the JFrog MCP server when prompted.",
+	},
+	"claude": {
+		displayName: "Claude Code",
+		projectFile: ".mcp.json",
+		globalFile:  filepath.Join("~", ".claude.json"),
+		serversKey:  mcpServersKey,
+		format:      formatJSON,
+		entry:       typedHTTPEntry,
+		localEntry:  typedStdioEntry,
+		nextStep:    "Run /mcp inside Claude Code and complete the browser login.",
+	},
+	"vscode": {
+		displayName: "VS Code",
+		projectFile: filepath.Join(".vscode", "mcp.json"),
+		globalFile:  filepath.Join("Code", "User", "mcp.json"),
+		inConfigDir: true,
+		serversKey:  vsCodeServersKey,
+		format:      formatJSONC,
+		entry:       typedHTTPEntry,
+		localEntry:  typedStdioEntry,
+		nextStep:    "Start the JFrog server from the MCP view of VS Code (or the 'MCP: List Servers' command) and complete the browser login.",
+	},
+	"windsurf": {
+		displayName: "Windsurf",
+		globalFile:  filepath.Join("~", ".codeium", "windsurf", "mcp_config.json"),
+		serversKey:  mcpServersKey,
+		format:      formatJSON,
+		entry: func(url string) map[string]interface{} {
+			return map[string]interface{}{"serverUrl": url}
+		},
+		localEntry: commandEntry,
+		nextStep:   "Refresh the MCP servers in Windsurf's Cascade panel and complete the browser login.",
+	},
+	"gemini": {
+		displayName: "Gemini CLI",
+		projectFile: filepath.Join(".gemini", "settings.json"),
+		globalFile:  filepath.Join("~", ".gemini", "settings.json"),
+		serversKey:  mcpServersKey,
+		format:      formatJSON,
+		entry: func(url string) map[string]interface{} {
+			return map[string]interface{}{"httpUrl": url}
+		},
+		localEntry: commandEntry,
+		nextStep:   "Run /mcp auth jfrog inside Gemini CLI and complete the browser login.",
+	},
+	"codex": {
+		displayName: "Codex",
+		projectFile: filepath.Join(".codex", "config.toml"),
+		globalFile:  filepath.Join("~", ".codex", "config.toml"),
+		serversKey:  codexServersKey,
+		format:      formatTOML,
+		entry:       urlEntry,
+		localEntry:  commandEntry,
+		nextStep:    "Run 'codex mcp login jfrog' and complete the browser login.",
+	},
+	"zed": {
+		displayName: "Zed",
+		projectFile: filepath.Join(".zed", "settings.json"),
+		globalFile:  filepath.Join("~", ".config", "zed", "settings.json"),
+		serversKey:  zedServersKey,
+		format:      formatJSONC,
+		entry:       urlEntry,
+		localEntry:  commandEntry,
+		nextStep:    "Open Zed's Agent Panel settings and complete the browser login for the JFrog server.",
+	},
+	"jetbrains": {
+		displayName: "JetBrains AI",
+		projectFile: filepath.Join(".junie", "mcp", "mcp.json"),
+		globalFile:  filepath.Join("~", ".junie", "mcp", "mcp.json"),
+		serversKey:  mcpServersKey,
+		format:      formatJSON,
+		entry:       urlEntry,
+		localEntry:  commandEntry,
+		nextStep:    "Open Settings | Tools | AI Assistant | Model Context Protocol (MCP) in the IDE and complete the browser login.",
+	},
+}
+
+// agentNames returns the supported agent names, sorted.
+func agentNames() []string {
+	names := make([]string, 0, len(supportedAgents))
+	for name := range supportedAgents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SupportedAgentNames returns the supported agent names, sorted, for help and
+// error messages.
+func SupportedAgentNames() string {
+	return strings.Join(agentNames(), ", ")
+}
+
+func resolveAgent(name string) (agentSpec, string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	if normalized == "" {
+		return agentSpec{}, "", errorutils.CheckErrorf("the --agent flag is required. Supported agents: %s", SupportedAgentNames())
+	}
+	spec, ok := supportedAgents[normalized]
+	if !ok {
+		return agentSpec{}, "", errorutils.CheckErrorf("unsupported agent %q. Supported agents: %s", name, SupportedAgentNames())
+	}
+	return spec, normalized, nil
+}
+
+// configFilePath resolves the absolute config file path for the agent at the
+// requested scope.
+func (a agentSpec) configFilePath(projectDir string, global bool) (string, error) {
+	if global {
+		if a.inConfigDir {
+			dir, err := os.UserConfigDir()
+			if err != nil {
+				return "", errorutils.CheckError(err)
+			}
+			return filepath.Join(dir, a.globalFile), nil
+		}
+		return expandHome(a.globalFile)
+	}
+	if a.projectFile == "" {
+		return "", errorutils.CheckErrorf("%s has no project-level MCP configuration. Pass --global to configure it for the current user", a.displayName)
+	}
+	if projectDir == "" {
+		projectDir = "."
+	}
+	return filepath.Abs(filepath.Join(projectDir, a.projectFile))
+}
+
+func expandHome(path string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~"+string(os.PathSeparator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", errorutils.CheckError(err)
+		}
+		path = filepath.Join(home, strings.TrimPrefix(path, "~"))
+	}
+	return filepath.Abs(path)
+}
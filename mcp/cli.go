@@ -1,22 +1,28 @@
 package mcp
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"strings"
+	"text/tabwriter"
 
 	commonCliUtils "github.com/jfrog/jfrog-cli-core/v2/common/cliutils"
 	corecommon "github.com/jfrog/jfrog-cli-core/v2/docs/common"
 	coreconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli/docs/common"
+	doctorDocs "github.com/jfrog/jfrog-cli/docs/mcp/doctor"
 	installDocs "github.com/jfrog/jfrog-cli/docs/mcp/install"
+	listDocs "github.com/jfrog/jfrog-cli/docs/mcp/list"
+	serveDocs "github.com/jfrog/jfrog-cli/docs/mcp/serve"
 	showDocs "github.com/jfrog/jfrog-cli/docs/mcp/show"
 	uninstallDocs "github.com/jfrog/jfrog-cli/docs/mcp/uninstall"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
 	"github.com/jfrog/jfrog-cli/utils/usage"
 	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
 	"github.com/urfave/cli"
 )
 
@@ -32,6 +38,26 @@ func GetCommands() []cli.Command {
 			BashComplete: corecommon.CreateBashCompletionFunc(),
 			Action:       showCmd,
 		},
+		{
+			Name:         "list",
+			Flags:        cliutils.GetCommandFlags(cliutils.McpList),
+			Usage:        corecommon.ResolveDescription(listDocs.GetDescription(), listDocs.GetAIDescription()),
+			HelpName:     corecommon.CreateUsage("mcp list", corecommon.ResolveDescription(listDocs.GetDescription(), listDocs.GetAIDescription()), listDocs.Usage),
+			UsageText:    listDocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       listCmd,
+		},
+		{
+			Name:         "doctor",
+			Flags:        cliutils.GetCommandFlags(cliutils.McpDoctor),
+			Usage:        corecommon.ResolveDescription(doctorDocs.GetDescription(), doctorDocs.GetAIDescription()),
+			HelpName:     corecommon.CreateUsage("mcp doctor", corecommon.ResolveDescription(doctorDocs.GetDescription(), doctorDocs.GetAIDescription()), doctorDocs.Usage),
+			UsageText:    doctorDocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       doctorCmd,
+		},
 		{
 			Name:         "install",
 			Flags:        cliutils.GetCommandFlags(cliutils.McpInstall),
@@ -39,9 +65,19 @@ func GetCommands() []cli.Command {
 			HelpName:     corecommon.CreateUsage("mcp install", corecommon.ResolveDescription(installDocs.GetDescription(), installDocs.GetAIDescription()), installDocs.Usage),
 			UsageText:    installDocs.GetArguments(),
 			ArgsUsage:    common.CreateEnvVars(),
-			BashComplete: corecommon.CreateBashCompletionFunc("cursor", "claude"),
+			BashComplete: corecommon.CreateBashCompletionFunc(agentNames()...),
 			Action:       installCmd,
 		},
+		{
+			Name:         "serve",
+			Flags:        cliutils.GetCommandFlags(cliutils.McpServe),
+			Usage:        corecommon.ResolveDescription(serveDocs.GetDescription(), serveDocs.GetAIDescription()),
+			HelpName:     corecommon.CreateUsage("mcp serve", corecommon.ResolveDescription(serveDocs.GetDescription(), serveDocs.GetAIDescription()), serveDocs.Usage),
+			UsageText:    serveDocs.GetArguments(),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action:       serveCmd,
+		},
 		{
 			Name:         "uninstall",
 			Flags:        cliutils.GetCommandFlags(cliutils.McpUninstall),
@@ -49,7 +85,7 @@ func GetCommands() []cli.Command {
 			HelpName:     corecommon.CreateUsage("mcp uninstall", corecommon.ResolveDescription(uninstallDocs.GetDescription(), uninstallDocs.GetAIDescription()), uninstallDocs.Usage),
 			UsageText:    uninstallDocs.GetArguments(),
 			ArgsUsage:    common.CreateEnvVars(),
-			BashComplete: corecommon.CreateBashCompletionFunc("cursor", "claude"),
+			BashComplete: corecommon.CreateBashCompletionFunc(agentNames()...),
 			Action:       uninstallCmd,
 		},
 	})
@@ -92,6 +128,11 @@ func runShow(c *cli.Context, serverDetails *coreconfig.ServerDetails, out io.Wri
 		McpUrl:      mcpURL,
 		Transport:   "http",
 	}
+	if agent := c.String("agent"); agent != "" {
+		if info.Agent, err = DescribeAgent(agent, c.String("project-dir"), c.Bool("global"), mcpURL); err != nil {
+			return err
+		}
+	}
 	if strings.EqualFold(c.String("format"), "json") {
 		data, err := json.MarshalIndent(info, "", "  ")
 		if err != nil {
@@ -104,10 +145,19 @@ func runShow(c *cli.Context, serverDetails *coreconfig.ServerDetails, out io.Wri
 		msg += fmt.Sprintf("Server ID:     %s\n", info.ServerId)
 	}
 	msg += "Auth:          OAuth (completed in the agent / client platform)\n"
+	if a := info.Agent; a != nil {
+		entry, err := json.Marshal(a.Entry)
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		msg += fmt.Sprintf("Agent:         %s (%s scope)\n", a.Name, a.Scope)
+		msg += fmt.Sprintf("Config file:   %s (%s)\n", a.ConfigFile, a.Format)
+		msg += fmt.Sprintf("Entry:         %s.%s = %s\n", a.ServersKey, DefaultServerName, entry)
+	}
 	return fprintf(out, "%s", msg)
 }
 
-func installCmd(c *cli.Context) error {
+func listCmd(c *cli.Context) error {
 	if c.NArg() != 0 {
 		return cliutils.WrongNumberOfArgumentsHandler(c)
 	}
@@ -115,24 +165,210 @@ func installCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	const cmdName = "jf mcp install"
+	const cmdName = "jf mcp list"
 	wait := usage.StartReport(cmdName, collectFlags(c), serverDetails)
 	defer usage.WaitForReport(cmdName, wait, usage.DefaultReportTimeout)
 
+	servers, err := coreconfig.GetAllServersConfigs()
+	if err != nil {
+		return err
+	}
+	return runList(c, serverDetails, servers, os.Stdout)
+}
+
+func runList(c *cli.Context, serverDetails *coreconfig.ServerDetails, servers []*coreconfig.ServerDetails, out io.Writer) error {
+	// Entries are listed even without a current server to compare them to.
 	mcpURL, err := ResolveMcpURL(c.String("mcp-url"), serverDetails)
+	if err != nil {
+		log.Debug("No current MCP endpoint to compare the entries to: " + err.Error())
+	}
+	info := ListInfo{
+		ServerId: serverDetails.ServerId,
+		McpUrl:   mcpURL,
+		Entries: List(ListParams{
+			ProjectDir: c.String("project-dir"),
+			McpURL:     mcpURL,
+			Servers:    servers,
+		}),
+	}
+	if strings.EqualFold(c.String("format"), "json") {
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		return fprintf(out, "%s\n", string(data))
+	}
+
+	if len(info.Entries) == 0 {
+		return fprintf(out, "No JFrog MCP server entries found. Add one with 'jf mcp install --agent <agent>'.\n")
+	}
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if err := fprintf(tw, "AGENT\tSCOPE\tNAME\tMCP URL / COMMAND\tSTATUS\tCONFIG FILE\n"); err != nil {
+		return err
+	}
+	var stale, duplicate int
+	for _, e := range info.Entries {
+		if e.Stale {
+			stale++
+		}
+		if e.Duplicate {
+			duplicate++
+		}
+		target := e.McpUrl
+		if e.Local {
+			target = e.Command
+		}
+		if err := fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", e.Agent, e.Scope, e.Name, target, entryStatus(e), e.ConfigFile); err != nil {
+			return err
+		}
+	}
+	if err := errorutils.CheckError(tw.Flush()); err != nil {
+		return err
+	}
+	return fprintf(out, "\n%d entries, %d stale, %d duplicate.\n", len(info.Entries), stale, duplicate)
+}
+
+// entryStatus summarizes how a listed entry relates to the configured
+// servers.
+func entryStatus(e ConfiguredEntry) string {
+	var status []string
+	switch {
+	case e.Local:
+		status = append(status, "local")
+	case e.Current:
+		status = append(status, "current")
+	case e.ServerId != "":
+		status = append(status, "server "+e.ServerId)
+	case e.Stale:
+		status = append(status, "stale")
+	}
+	if e.Duplicate {
+		status = append(status, "duplicate")
+	}
+	return strings.Join(status, ", ")
+}
+
+func doctorCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	serverDetails, err := cliutils.CreateServerDetailsWithConfigOffer(c, true, commonCliUtils.Platform)
+	if err != nil {
+		return err
+	}
+	const cmdName = "jf mcp doctor"
+	wait := usage.StartReport(cmdName, collectFlags(c), serverDetails)
+	defer usage.WaitForReport(cmdName, wait, usage.DefaultReportTimeout)
+
+	return runDoctor(c, serverDetails, os.Stdout)
+}
+
+// runDoctor prints the checklist of a doctor run, and fails when a check
+// failed, so scripts can rely on the exit code.
+func runDoctor(c *cli.Context, serverDetails *coreconfig.ServerDetails, out io.Writer) error {
+	report := Doctor(DoctorParams{
+		McpURLOverride: c.String("mcp-url"),
+		ServerDetails:  serverDetails,
+		ProjectDir:     c.String("project-dir"),
+		Agent:          c.String("agent"),
+		ServerName:     serverNameOrDefault(c),
+	})
+	if strings.EqualFold(c.String("format"), "json") {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if err := fprintf(out, "%s\n", string(data)); err != nil {
+			return err
+		}
+	} else {
+		msg := ""
+		for _, check := range report.Checks {
+			msg += fmt.Sprintf("[%s] %s: %s\n", strings.ToUpper(check.Status), check.Title, check.Message)
+			if check.Fix != "" {
+				msg += fmt.Sprintf("       Fix: %s\n", check.Fix)
+			}
+		}
+		msg += fmt.Sprintf("\n%d passed, %d warnings, %d failed.\n", report.Passed, report.Warnings, report.Failed)
+		if err := fprintf(out, "%s", msg); err != nil {
+			return err
+		}
+	}
+	if report.Failed > 0 {
+		return errorutils.CheckErrorf("%d of the JFrog MCP checks failed", report.Failed)
+	}
+	return nil
+}
+
+func installCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	serverDetails, err := cliutils.CreateServerDetailsWithConfigOffer(c, true, commonCliUtils.Platform)
 	if err != nil {
 		return err
 	}
-	return Install(InstallParams{
+	const cmdName = "jf mcp install"
+	wait := usage.StartReport(cmdName, collectFlags(c), serverDetails)
+	defer usage.WaitForReport(cmdName, wait, usage.DefaultReportTimeout)
+
+	params := InstallParams{
 		Agent:         c.String("agent"),
 		ServerName:    serverNameOrDefault(c),
-		McpURL:        mcpURL,
 		ProjectDir:    c.String("project-dir"),
 		Global:        c.Bool("global"),
 		DryRun:        c.Bool("dry-run"),
 		ServerDetails: serverDetails,
 		SkipCheck:     c.Bool("skip-check"),
-	}, os.Stdout)
+		Local:         c.Bool("local"),
+	}
+	if params.Local {
+		if c.IsSet("mcp-url") {
+			return errorutils.CheckErrorf("--mcp-url sets the remote MCP server, so it can't be used with --local")
+		}
+		if params.Command, params.Args, err = localServerCommand(c.String("server-id")); err != nil {
+			return err
+		}
+	} else if params.McpURL, err = ResolveMcpURL(c.String("mcp-url"), serverDetails); err != nil {
+		return err
+	}
+	return Install(params, os.Stdout)
+}
+
+// localServerCommand returns the command line agents launch the local MCP
+// server with: this jf executable, serving with the given server
+// configuration, or the default one.
+func localServerCommand(serverId string) (string, []string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", nil, errorutils.CheckError(err)
+	}
+	args := []string{"mcp", "serve", "--stdio"}
+	if serverId != "" {
+		args = append(args, "--server-id", serverId)
+	}
+	return executable, args, nil
+}
+
+func serveCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	if !c.Bool("stdio") {
+		return errorutils.CheckErrorf("the local MCP server only supports the stdio transport: pass --stdio")
+	}
+	// Stdin carries the protocol, so the server configuration is read as is,
+	// never interactively offered.
+	serverDetails, err := coreconfig.GetSpecificConfig(c.String("server-id"), true, true)
+	if err != nil {
+		return err
+	}
+	const cmdName = "jf mcp serve"
+	wait := usage.StartReport(cmdName, collectFlags(c), serverDetails)
+	defer usage.WaitForReport(cmdName, wait, usage.DefaultReportTimeout)
+
+	log.Info("Serving the JFrog MCP tools over stdio, for server", serverDetails.GetUrl())
+	return NewServer(cliutils.GetVersion(), LocalTools(serverDetails)).Serve(context.Background(), os.Stdin, os.Stdout)
 }
 
 func uninstallCmd(c *cli.Context) error {
@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// configFormat is the file format of an agent's configuration.
+type configFormat string
+
+const (
+	formatJSON configFormat = "json"
+	// formatJSONC is JSON with comments and trailing commas, as written by VS
+	// Code and Zed. Plain JSON configs are read as JSONC too, since agents such
+	// as Cursor accept comments and trailing commas in them.
+	formatJSONC configFormat = "jsonc"
+	formatTOML  configFormat = "toml"
+)
+
+// backupTimeFormat stamps the backup taken of a global config file before it
+// is modified, as <file>.<timestamp>.bak.
+const backupTimeFormat = "20060102-150405"
+
+// readConfigFile returns the content of the agent config file, or nil if it
+// doesn't exist.
+func readConfigFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	return data, nil
+}
+
+// parseConfig decodes the agent config file content into a generic object.
+// Empty content yields an empty object.
+func parseConfig(path string, data []byte, format configFormat) (map[string]interface{}, error) {
+	root := map[string]interface{}{}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return root, nil
+	}
+	var err error
+	if format == formatTOML {
+		err = toml.Unmarshal(data, &root)
+	} else {
+		err = json.Unmarshal(stripJSONC(data), &root)
+	}
+	if err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse existing config %s: %s", path, err.Error())
+	}
+	return root, nil
+}
+
+// readConfig loads the agent config file into a generic object. A missing
+// file yields an empty object.
+func readConfig(path string, format configFormat) (map[string]interface{}, error) {
+	data, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseConfig(path, data, format)
+}
+
+// setServerEntry returns the config file content data with the name server
+// entry under key set to entry. Only that entry is rewritten; the rest of
+// the file, comments included, is kept as is.
+func setServerEntry(data []byte, format configFormat, key, name string, entry map[string]interface{}) ([]byte, error) {
+	if format != formatTOML {
+		return setJSONMember(data, key, name, entry)
+	}
+	out, ok, err := setTomlTable(data, key, name, entry)
+	if err != nil || ok {
+		return out, err
+	}
+	// The entry isn't a table of its own, so re-encode the whole file.
+	root, err := parseConfig("", data, format)
+	if err != nil {
+		return nil, err
+	}
+	serversMap(root, key)[name] = entry
+	return marshalToml(root)
+}
+
+// removeServerEntry returns the config file content data without the name
+// server entry under key, leaving the rest of the file as is.
+func removeServerEntry(data []byte, format configFormat, key, name string) ([]byte, error) {
+	if format != formatTOML {
+		out, _, err := removeJSONMember(data, key, name)
+		return out, err
+	}
+	if out, ok := removeTomlTable(data, key, name); ok && !tomlHasEntry(out, key, name) {
+		return out, nil
+	}
+	root, err := parseConfig("", data, format)
+	if err != nil {
+		return nil, err
+	}
+	delete(serversMap(root, key), name)
+	return marshalToml(root)
+}
+
+// serversMap returns the object holding the MCP server definitions under key,
+// adding it to root when missing.
+func serversMap(root map[string]interface{}, key string) map[string]interface{} {
+	if existing, ok := root[key].(map[string]interface{}); ok {
+		return existing
+	}
+	servers := map[string]interface{}{}
+	root[key] = servers
+	return servers
+}
+
+func marshalToml(root map[string]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(root); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeConfigFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(os.WriteFile(path, data, 0600))
+}
+
+// backupConfigFile copies the existing config file data to a timestamped
+// .bak file next to it, and returns the backup's path.
+func backupConfigFile(path string, data []byte, now time.Time) (string, error) {
+	backup := strings.Join([]string{path, now.Format(backupTimeFormat), "bak"}, ".")
+	if err := os.WriteFile(backup, data, 0600); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return backup, nil
+}
@@ -0,0 +1,531 @@
+package mcp
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	coreconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+)
+
+// Statuses of a doctor check.
+const (
+	CheckPass = "pass"
+	CheckWarn = "warn"
+	CheckFail = "fail"
+	CheckSkip = "skip"
+)
+
+const (
+	// doctorTimeout bounds each network check of 'jf mcp doctor'.
+	doctorTimeout = 15 * time.Second
+	// certExpiryWarning is how long before its expiry a server certificate is
+	// reported.
+	certExpiryWarning = 30 * 24 * time.Hour
+)
+
+// resourceMetadataParam extracts the protected resource metadata URL an MCP
+// server advertises in its WWW-Authenticate challenge (RFC 9728).
+var resourceMetadataParam = regexp.MustCompile(`resource_metadata="([^"]+)"`)
+
+// DoctorCheck is a check of 'jf mcp doctor', in the stable JSON schema it
+// prints with --format json.
+type DoctorCheck struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	// Message tells what was found, and Fix what to do about a failure or a
+	// warning.
+	Message string `json:"message"`
+	Fix     string `json:"fix,omitempty"`
+}
+
+// DoctorReport is the stable JSON schema printed by 'jf mcp doctor --format
+// json'.
+type DoctorReport struct {
+	ServerId string        `json:"serverId,omitempty"`
+	McpUrl   string        `json:"mcpUrl,omitempty"`
+	Checks   []DoctorCheck `json:"checks"`
+	Passed   int           `json:"passed"`
+	Warnings int           `json:"warnings"`
+	Failed   int           `json:"failed"`
+}
+
+// DoctorParams holds the resolved inputs for a doctor operation.
+type DoctorParams struct {
+	// McpURLOverride is the --mcp-url flag value, resolved with ResolveMcpURL.
+	McpURLOverride string
+	ServerDetails  *coreconfig.ServerDetails
+	ProjectDir     string
+	// Agent restricts the agent checks to this agent, whose entry is then
+	// required. All agents with a config file are checked otherwise.
+	Agent      string
+	ServerName string
+}
+
+// doctor accumulates the checks of a doctor run.
+type doctor struct {
+	params DoctorParams
+	mcpURL *url.URL
+	report DoctorReport
+}
+
+func (d *doctor) add(id, title, status, message, fix string) {
+	d.report.Checks = append(d.report.Checks, DoctorCheck{ID: id, Title: title, Status: status, Message: message, Fix: fix})
+	switch status {
+	case CheckPass:
+		d.report.Passed++
+	case CheckWarn:
+		d.report.Warnings++
+	case CheckFail:
+		d.report.Failed++
+	}
+}
+
+// Doctor diagnoses the connection of the agents to the JFrog MCP server, from
+// the network path to the endpoint and its OAuth discovery metadata, to the
+// entries of the agent configs.
+func Doctor(params DoctorParams) DoctorReport {
+	d := &doctor{params: params, report: DoctorReport{ServerId: params.ServerDetails.ServerId}}
+	if d.checkURL() {
+		d.checkNetwork()
+	}
+	d.checkAgents()
+	return d.report
+}
+
+func (d *doctor) checkURL() bool {
+	const id, title = "mcp-url", "MCP endpoint URL"
+	mcpURL, err := ResolveMcpURL(d.params.McpURLOverride, d.params.ServerDetails)
+	if err != nil {
+		d.add(id, title, CheckFail, err.Error(), "Configure a server with 'jf config add', or pass --mcp-url.")
+		return false
+	}
+	d.report.McpUrl = mcpURL
+	if d.mcpURL, err = url.Parse(mcpURL); err != nil || d.mcpURL.Host == "" {
+		d.add(id, title, CheckFail, fmt.Sprintf("%s is not a valid URL", mcpURL), "Pass the full endpoint with --mcp-url, e.g. https://acme.jfrog.io/mcp.")
+		return false
+	}
+	switch d.mcpURL.Scheme {
+	case "https":
+		d.add(id, title, CheckPass, mcpURL, "")
+	case "http":
+		d.add(id, title, CheckWarn, mcpURL+" is not served over TLS", "Agents refuse OAuth over plain HTTP. Use the platform's https:// URL.")
+	default:
+		d.add(id, title, CheckFail, fmt.Sprintf("%s is not an http(s) URL", mcpURL), "Pass the full endpoint with --mcp-url, e.g. https://acme.jfrog.io/mcp.")
+		return false
+	}
+	return true
+}
+
+func (d *doctor) checkNetwork() {
+	proxied := d.checkProxy()
+	if !d.checkDNS(proxied) {
+		d.add("endpoint", "MCP endpoint", CheckSkip, "Skipped: the host name doesn't resolve.", "")
+		return
+	}
+	// Load the JFrog CLI certs directory like jf's other clients, for the
+	// fix of an unknown authority to be checkable here.
+	certsPath, err := coreutils.GetJfrogCertsDir()
+	if err != nil {
+		d.add("endpoint", "MCP endpoint", CheckFail, err.Error(), "")
+		return
+	}
+	client, err := httpclient.ClientBuilder().
+		SetCertificatesPath(certsPath).
+		SetInsecureTls(d.params.ServerDetails.InsecureTls).
+		SetClientCertPath(d.params.ServerDetails.ClientCertPath).
+		SetClientCertKeyPath(d.params.ServerDetails.ClientCertKeyPath).
+		SetOverallRequestTimeout(doctorTimeout).
+		SetRetries(0).
+		Build()
+	if err != nil {
+		d.add("endpoint", "MCP endpoint", CheckFail, err.Error(), "")
+		return
+	}
+	resp, _, _, err := client.SendGet(d.report.McpUrl, true, httputils.HttpClientDetails{}, "")
+	d.checkTLS(resp, err)
+	if !d.checkEndpoint(resp, err) {
+		d.add("oauth-protected-resource", "OAuth protected resource metadata", CheckSkip, "Skipped: the MCP endpoint is not reachable.", "")
+		return
+	}
+	if issuer := d.checkProtectedResource(client, resp); issuer != "" {
+		d.checkAuthorizationServer(client, issuer)
+	}
+}
+
+// checkProxy reports the proxy the environment sets for the MCP endpoint, as
+// Go's HTTP clients, and so jf, pick it. It returns whether one is used.
+func (d *doctor) checkProxy() bool {
+	const id, title = "proxy", "Proxy"
+	variable, proxy := proxyFromEnv(d.mcpURL)
+	if variable == "" {
+		d.add(id, title, CheckPass, fmt.Sprintf("No proxy environment variable applies to %s.", d.mcpURL.Hostname()), "")
+		return false
+	}
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	proxyURL, err := url.Parse(proxy)
+	if err != nil || proxyURL.Host == "" {
+		d.add(id, title, CheckFail, fmt.Sprintf("%s is not a valid proxy URL.", variable), fmt.Sprintf("Fix %s, or add %s to NO_PROXY.", variable, d.mcpURL.Hostname()))
+		return false
+	}
+	d.add(id, title, CheckPass, fmt.Sprintf("Requests to %s go through %s, set by %s. Make sure the agents use it too.", d.mcpURL.Hostname(), proxyURL.Redacted(), variable), "")
+	return true
+}
+
+// proxyFromEnv returns the proxy environment variable applying to u, and its
+// value, following the rules of Go's http.ProxyFromEnvironment.
+func proxyFromEnv(u *url.URL) (variable, proxy string) {
+	host := u.Hostname()
+	if host == "localhost" {
+		return "", ""
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return "", ""
+	}
+	for _, noProxyVar := range []string{"NO_PROXY", "no_proxy"} {
+		if noProxy := os.Getenv(noProxyVar); noProxy != "" {
+			if matchesNoProxy(host, noProxy) {
+				return "", ""
+			}
+			break
+		}
+	}
+	variables := []string{"HTTP_PROXY", "http_proxy"}
+	if u.Scheme == "https" {
+		variables = []string{"HTTPS_PROXY", "https_proxy"}
+	}
+	for _, variable = range variables {
+		if proxy = os.Getenv(variable); proxy != "" {
+			return variable, proxy
+		}
+	}
+	return "", ""
+}
+
+func matchesNoProxy(host, noProxy string) bool {
+	host = strings.ToLower(host)
+	for _, pattern := range strings.Split(noProxy, ",") {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if h, _, err := net.SplitHostPort(pattern); err == nil {
+			pattern = h
+		}
+		if pattern == "*" {
+			return true
+		}
+		if pattern = strings.TrimPrefix(strings.TrimPrefix(pattern, "*"), "."); pattern == "" {
+			continue
+		}
+		if host == pattern || strings.HasSuffix(host, "."+pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDNS resolves the endpoint's host. Behind a proxy, the proxy resolves
+// it, so a local failure is only a warning.
+func (d *doctor) checkDNS(proxied bool) bool {
+	const id, title = "dns", "DNS"
+	host := d.mcpURL.Hostname()
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	switch {
+	case err == nil:
+		if len(addrs) > 3 {
+			addrs = append(addrs[:3], "...")
+		}
+		d.add(id, title, CheckPass, fmt.Sprintf("%s resolves to %s.", host, strings.Join(addrs, ", ")), "")
+	case proxied:
+		d.add(id, title, CheckWarn, fmt.Sprintf("%s doesn't resolve locally: %s. The proxy resolves it.", host, err.Error()), "")
+	default:
+		d.add(id, title, CheckFail, fmt.Sprintf("%s doesn't resolve: %s", host, err.Error()), "Check the platform URL, your DNS settings or VPN connection.")
+		return false
+	}
+	return true
+}
+
+// checkTLS reports the TLS connection of the endpoint request, or why it
+// failed.
+func (d *doctor) checkTLS(resp *http.Response, err error) {
+	const id, title = "tls", "TLS"
+	if d.mcpURL.Scheme != "https" {
+		d.add(id, title, CheckSkip, "Skipped: the endpoint is not served over TLS.", "")
+		return
+	}
+	if err != nil {
+		if message, fix, ok := tlsFailure(err); ok {
+			d.add(id, title, CheckFail, message, fix)
+		} else {
+			d.add(id, title, CheckSkip, "Skipped: no connection to the endpoint.", "")
+		}
+		return
+	}
+	if resp.TLS == nil || len(resp.TLS.PeerCertificates) == 0 {
+		d.add(id, title, CheckSkip, "Skipped: the connection state is unknown.", "")
+		return
+	}
+	cert := resp.TLS.PeerCertificates[0]
+	message := fmt.Sprintf("%s, certificate for %s issued by %s, valid until %s.", tls.VersionName(resp.TLS.Version), cert.Subject.CommonName, cert.Issuer.CommonName, cert.NotAfter.Format(time.DateOnly))
+	switch {
+	case d.params.ServerDetails.InsecureTls:
+		d.add(id, title, CheckWarn, message+" Certificate verification is disabled.", "Agents verify the certificate: make sure they trust its issuer.")
+	case time.Until(cert.NotAfter) < certExpiryWarning:
+		d.add(id, title, CheckWarn, message+" The certificate expires soon.", "Ask the platform administrator to renew the certificate.")
+	default:
+		d.add(id, title, CheckPass, message, "")
+	}
+}
+
+// tlsFailure tells whether err is a TLS failure, and how to fix it.
+func tlsFailure(err error) (message, fix string, ok bool) {
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	switch {
+	case errors.As(err, &unknownAuthority):
+		return "The server certificate is signed by an unknown authority: " + err.Error(), "Add the CA certificate to the JFrog CLI security certs directory (~/.jfrog/security/certs), and to the trust store the agents use.", true
+	case errors.As(err, &hostname):
+		return "The server certificate doesn't match the host: " + err.Error(), "Use the host name the certificate is issued for in the platform URL.", true
+	case errors.As(err, &invalid):
+		return "The server certificate is invalid: " + err.Error(), "Ask the platform administrator to renew the certificate.", true
+	}
+	message = err.Error()
+	if strings.Contains(message, "certificate required") || strings.Contains(message, "bad certificate") {
+		return "The server requires a client certificate: " + message, "Configure one with --client-cert-path and --client-cert-key-path, or in 'jf config add'.", true
+	}
+	if strings.Contains(message, "tls:") || strings.Contains(message, "x509:") {
+		return "The TLS handshake failed: " + message, "", true
+	}
+	return "", "", false
+}
+
+// checkEndpoint reports the response of the MCP endpoint, and returns whether
+// it is reachable.
+func (d *doctor) checkEndpoint(resp *http.Response, err error) bool {
+	const id, title = "endpoint", "MCP endpoint"
+	if err != nil {
+		d.add(id, title, CheckFail, fmt.Sprintf("%s is not reachable: %s", d.report.McpUrl, err.Error()), "Check the platform URL, firewall and proxy settings.")
+		return false
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		d.add(id, title, CheckFail, fmt.Sprintf("%s returned HTTP 404.", d.report.McpUrl), "Verify the platform URL, and ask the platform administrator to enable the MCP server.")
+		return false
+	case resp.StatusCode == http.StatusUnauthorized:
+		d.add(id, title, CheckPass, fmt.Sprintf("%s requires OAuth authorization (HTTP 401).", d.report.McpUrl), "")
+	case resp.StatusCode >= http.StatusInternalServerError:
+		d.add(id, title, CheckFail, fmt.Sprintf("%s returned HTTP %d.", d.report.McpUrl, resp.StatusCode), "The platform failed to serve MCP: retry later, or contact the platform administrator.")
+		return false
+	default:
+		d.add(id, title, CheckPass, fmt.Sprintf("%s answered with HTTP %d.", d.report.McpUrl, resp.StatusCode), "")
+	}
+	return true
+}
+
+type protectedResourceMetadata struct {
+	Resource             string   `json:"resource"`
+	AuthorizationServers []string `json:"authorization_servers"`
+}
+
+// checkProtectedResource fetches the endpoint's OAuth protected resource
+// metadata (RFC 9728), at the URL its challenge advertises or at its
+// well-known URLs, and returns its authorization server.
+func (d *doctor) checkProtectedResource(client *httpclient.HttpClient, resp *http.Response) string {
+	const id, title = "oauth-protected-resource", "OAuth protected resource metadata"
+	var candidates []string
+	if match := resourceMetadataParam.FindStringSubmatch(resp.Header.Get("WWW-Authenticate")); match != nil {
+		candidates = append(candidates, match[1])
+	}
+	candidates = append(candidates, wellKnownURLs(d.mcpURL, "oauth-protected-resource")...)
+
+	var metadata protectedResourceMetadata
+	metadataURL, err := fetchMetadata(client, candidates, &metadata)
+	if err != nil {
+		d.add(id, title, CheckFail, err.Error(), "Agents can't start OAuth without it. Ask the platform administrator to enable OAuth for the MCP server.")
+		return ""
+	}
+	if len(metadata.AuthorizationServers) == 0 {
+		d.add(id, title, CheckFail, metadataURL+" lists no authorization server.", "Ask the platform administrator to check the MCP server's OAuth configuration.")
+		return ""
+	}
+	message := fmt.Sprintf("%s names the authorization server %s.", metadataURL, metadata.AuthorizationServers[0])
+	if metadata.Resource != "" && normalizeURL(metadata.Resource) != normalizeURL(d.report.McpUrl) {
+		d.add(id, title, CheckWarn, message+fmt.Sprintf(" Its resource %s differs from the MCP endpoint.", metadata.Resource), "Agents reject tokens for another resource: configure them with the endpoint "+metadata.Resource+".")
+	} else {
+		d.add(id, title, CheckPass, message, "")
+	}
+	return metadata.AuthorizationServers[0]
+}
+
+type authorizationServerMetadata struct {
+	AuthorizationEndpoint         string   `json:"authorization_endpoint"`
+	TokenEndpoint                 string   `json:"token_endpoint"`
+	RegistrationEndpoint          string   `json:"registration_endpoint"`
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported"`
+}
+
+// checkAuthorizationServer fetches the authorization server metadata of
+// issuer (RFC 8414, or OpenID Connect discovery).
+func (d *doctor) checkAuthorizationServer(client *httpclient.HttpClient, issuer string) {
+	const id, title = "oauth-authorization-server", "OAuth authorization server metadata"
+	issuerURL, err := url.Parse(issuer)
+	if err != nil || issuerURL.Host == "" {
+		d.add(id, title, CheckFail, fmt.Sprintf("The authorization server %s is not a valid URL.", issuer), "Ask the platform administrator to check the MCP server's OAuth configuration.")
+		return
+	}
+	candidates := append(wellKnownURLs(issuerURL, "oauth-authorization-server"), wellKnownURLs(issuerURL, "openid-configuration")...)
+	candidates = append(candidates, strings.TrimRight(issuer, "/")+"/.well-known/openid-configuration")
+
+	var metadata authorizationServerMetadata
+	metadataURL, err := fetchMetadata(client, slices.Compact(candidates), &metadata)
+	if err != nil {
+		d.add(id, title, CheckFail, err.Error(), "Agents can't authorize without it. Ask the platform administrator to check the MCP server's OAuth configuration.")
+		return
+	}
+	switch {
+	case metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "":
+		d.add(id, title, CheckFail, metadataURL+" lacks the authorization or token endpoint.", "Ask the platform administrator to check the MCP server's OAuth configuration.")
+	case len(metadata.CodeChallengeMethodsSupported) > 0 && !slices.Contains(metadata.CodeChallengeMethodsSupported, "S256"):
+		d.add(id, title, CheckWarn, metadataURL+" doesn't support the S256 PKCE method MCP agents use.", "Ask the platform administrator to enable PKCE with S256.")
+	case metadata.RegistrationEndpoint == "":
+		d.add(id, title, CheckWarn, metadataURL+" has no dynamic client registration endpoint.", "Agents that register themselves, as most do, can't authorize: register a client for them with the platform administrator.")
+	default:
+		d.add(id, title, CheckPass, fmt.Sprintf("%s: authorization at %s.", metadataURL, metadata.AuthorizationEndpoint), "")
+	}
+}
+
+// wellKnownURLs returns the well-known URLs of the metadata named suffix for
+// u: with u's path appended, then at the root.
+func wellKnownURLs(u *url.URL, suffix string) []string {
+	root := u.Scheme + "://" + u.Host + "/.well-known/" + suffix
+	if path := strings.TrimRight(u.EscapedPath(), "/"); path != "" {
+		return []string{root + path, root}
+	}
+	return []string{root}
+}
+
+// fetchMetadata decodes into metadata the first of candidates serving JSON,
+// and returns its URL.
+func fetchMetadata(client *httpclient.HttpClient, candidates []string, metadata interface{}) (string, error) {
+	details := httputils.HttpClientDetails{Headers: map[string]string{"Accept": "application/json"}}
+	var failures []string
+	for _, candidate := range candidates {
+		resp, body, _, err := client.SendGet(candidate, true, details, "")
+		switch {
+		case err != nil:
+			failures = append(failures, fmt.Sprintf("%s: %s", candidate, err.Error()))
+		case resp.StatusCode != http.StatusOK:
+			failures = append(failures, fmt.Sprintf("%s: HTTP %d", candidate, resp.StatusCode))
+		case json.Unmarshal(body, metadata) != nil:
+			failures = append(failures, candidate+": not JSON")
+		default:
+			return candidate, nil
+		}
+	}
+	return "", errors.New("no metadata found (" + strings.Join(failures, "; ") + ")")
+}
+
+// checkAgents checks that the agent configs parse, and that their JFrog
+// entry points at the MCP endpoint.
+func (d *doctor) checkAgents() {
+	names := agentNames()
+	if d.params.Agent != "" {
+		_, agentName, err := resolveAgent(d.params.Agent)
+		if err != nil {
+			d.add("agent-config", "Agent configuration", CheckFail, err.Error(), "")
+			return
+		}
+		names = []string{agentName}
+	}
+	var found bool
+	for _, agentName := range names {
+		spec := supportedAgents[agentName]
+		scanned := map[string]bool{}
+		for _, global := range []bool{false, true} {
+			path, err := spec.configFilePath(d.params.ProjectDir, global)
+			if err != nil || scanned[path] {
+				continue
+			}
+			scanned[path] = true
+			if d.checkAgentConfig(agentName, spec, global, path) {
+				found = true
+			}
+		}
+	}
+	switch {
+	case found:
+	case d.params.Agent != "":
+		d.add("agent-config", supportedAgents[names[0]].displayName+" configuration", CheckFail, fmt.Sprintf("No '%s' MCP server entry in the project or global configuration.", d.params.ServerName), fmt.Sprintf("Run 'jf mcp install --agent %s'.", names[0]))
+	default:
+		d.add("agent-config", "Agent configuration", CheckWarn, fmt.Sprintf("No agent configuration has a '%s' MCP server entry.", d.params.ServerName), "Run 'jf mcp install --agent <agent>'.")
+	}
+}
+
+// checkAgentConfig checks an agent config file, if present, and returns
+// whether it has the JFrog entry.
+func (d *doctor) checkAgentConfig(agentName string, spec agentSpec, global bool, path string) bool {
+	const id = "agent-config"
+	title := fmt.Sprintf("%s configuration (%s)", spec.displayName, scopeName(global))
+	install := fmt.Sprintf("jf mcp install --agent %s", agentName)
+	if global {
+		install += " --global"
+	}
+	data, err := readConfigFile(path)
+	if err != nil {
+		d.add(id, title, CheckFail, err.Error(), "")
+		return false
+	}
+	if data == nil {
+		return false
+	}
+	root, err := parseConfig(path, data, spec.format)
+	if err != nil {
+		d.add(id, title, CheckFail, err.Error(), fmt.Sprintf("Fix the syntax of %s: %s can't load any MCP server from it.", path, spec.displayName))
+		return false
+	}
+	servers, _ := root[spec.serversKey].(map[string]interface{})
+	entry, ok := servers[d.params.ServerName].(map[string]interface{})
+	if !ok {
+		if d.params.Agent != "" {
+			d.add(id, title, CheckSkip, fmt.Sprintf("No '%s' entry in %s.", d.params.ServerName, path), "")
+		}
+		return false
+	}
+	if isLocalEntry(entry) {
+		command, _ := entry["command"].(string)
+		if _, err := os.Stat(command); err != nil {
+			d.add(id, title, CheckFail, fmt.Sprintf("%s launches the local MCP server with %s, which is missing.", path, command), fmt.Sprintf("Run '%s --local'.", install))
+		} else {
+			d.add(id, title, CheckPass, fmt.Sprintf("%s launches the local MCP server with %s.", path, command), "")
+		}
+		return true
+	}
+	target := entryURL(entry)
+	switch {
+	case target == "":
+		d.add(id, title, CheckFail, fmt.Sprintf("The '%s' entry of %s has no URL.", d.params.ServerName, path), fmt.Sprintf("Run '%s'.", install))
+	case d.report.McpUrl != "" && normalizeURL(target) != normalizeURL(d.report.McpUrl):
+		d.add(id, title, CheckFail, fmt.Sprintf("%s points at %s, not at %s.", path, target, d.report.McpUrl), fmt.Sprintf("Run '%s'.", install))
+	default:
+		d.add(id, title, CheckPass, fmt.Sprintf("%s points at %s.", path, target), "")
+	}
+	return true
+}
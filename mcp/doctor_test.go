@@ -0,0 +1,198 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	coreconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// isolateDoctorEnv points the global agent configs at an empty home, and
+// clears the proxy environment.
+func isolateDoctorEnv(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	for _, variable := range []string{"HTTP_PROXY", "http_proxy", "HTTPS_PROXY", "https_proxy", "NO_PROXY", "no_proxy"} {
+		t.Setenv(variable, "")
+	}
+}
+
+// checkStatuses maps the ID of each check to its statuses, in order.
+func checkStatuses(report DoctorReport) map[string][]string {
+	statuses := map[string][]string{}
+	for _, check := range report.Checks {
+		statuses[check.ID] = append(statuses[check.ID], check.Status)
+	}
+	return statuses
+}
+
+// newOAuthServer serves an MCP endpoint at /mcp requiring OAuth, with its
+// discovery metadata.
+func newOAuthServer(t *testing.T, authServerMetadata map[string]interface{}) *httptest.Server {
+	var srv *httptest.Server
+	writeJSON := func(w http.ResponseWriter, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(v))
+	}
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mcp":
+			w.Header().Set("WWW-Authenticate", `Bearer resource_metadata="`+srv.URL+`/.well-known/oauth-protected-resource/mcp"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/.well-known/oauth-protected-resource/mcp":
+			writeJSON(w, map[string]interface{}{"resource": srv.URL + "/mcp", "authorization_servers": []string{srv.URL + "/access"}})
+		case "/.well-known/oauth-authorization-server/access":
+			writeJSON(w, authServerMetadata)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDoctor(t *testing.T) {
+	isolateDoctorEnv(t)
+	srv := newOAuthServer(t, map[string]interface{}{
+		"authorization_endpoint":           "https://idp.example/authorize",
+		"token_endpoint":                   "https://idp.example/token",
+		"registration_endpoint":            "https://idp.example/register",
+		"code_challenge_methods_supported": []string{"S256"},
+	})
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".cursor", "mcp.json"), `{"mcpServers": {"jfrog": {"url": "`+srv.URL+`/mcp/"}}}`)
+
+	report := Doctor(DoctorParams{
+		ServerDetails: &coreconfig.ServerDetails{ServerId: "prod", Url: srv.URL},
+		ProjectDir:    dir,
+		ServerName:    DefaultServerName,
+	})
+	assert.Equal(t, "prod", report.ServerId)
+	assert.Equal(t, srv.URL+"/mcp", report.McpUrl)
+	assert.Equal(t, map[string][]string{
+		"mcp-url":                    {CheckWarn},
+		"proxy":                      {CheckPass},
+		"dns":                        {CheckPass},
+		"tls":                        {CheckSkip},
+		"endpoint":                   {CheckPass},
+		"oauth-protected-resource":   {CheckPass},
+		"oauth-authorization-server": {CheckPass},
+		"agent-config":               {CheckPass},
+	}, checkStatuses(report), report.Checks)
+	assert.Equal(t, 0, report.Failed)
+	assert.Equal(t, 1, report.Warnings)
+}
+
+func TestDoctor_AuthorizationServerWarnings(t *testing.T) {
+	isolateDoctorEnv(t)
+	srv := newOAuthServer(t, map[string]interface{}{
+		"authorization_endpoint": "https://idp.example/authorize",
+		"token_endpoint":         "https://idp.example/token",
+	})
+	report := Doctor(DoctorParams{ServerDetails: &coreconfig.ServerDetails{Url: srv.URL}, ProjectDir: t.TempDir(), ServerName: DefaultServerName})
+	statuses := checkStatuses(report)
+	assert.Equal(t, []string{CheckWarn}, statuses["oauth-authorization-server"], report.Checks)
+	assert.Equal(t, []string{CheckWarn}, statuses["agent-config"], "no agent has the entry")
+	assert.Equal(t, 0, report.Failed)
+}
+
+func TestDoctor_Failures(t *testing.T) {
+	isolateDoctorEnv(t)
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".cursor", "mcp.json"), `{"mcpServers": {`)
+	writeFile(t, filepath.Join(dir, ".mcp.json"), `{"mcpServers": {"jfrog": {"type": "http", "url": "https://old.example/mcp"}}}`)
+
+	report := Doctor(DoctorParams{ServerDetails: &coreconfig.ServerDetails{Url: srv.URL}, ProjectDir: dir, ServerName: DefaultServerName})
+	statuses := checkStatuses(report)
+	assert.Equal(t, []string{CheckFail}, statuses["endpoint"])
+	assert.Equal(t, []string{CheckSkip}, statuses["oauth-protected-resource"])
+	assert.Equal(t, []string{CheckFail, CheckFail}, statuses["agent-config"], report.Checks)
+	assert.Equal(t, 3, report.Failed)
+	for _, check := range report.Checks {
+		if check.Status == CheckFail {
+			assert.NotEmpty(t, check.Fix, check.Title)
+		}
+	}
+}
+
+func TestDoctor_RequiredAgent(t *testing.T) {
+	isolateDoctorEnv(t)
+	report := Doctor(DoctorParams{
+		McpURLOverride: "https://platform.example/mcp",
+		ServerDetails:  &coreconfig.ServerDetails{},
+		ProjectDir:     t.TempDir(),
+		Agent:          "gemini",
+		ServerName:     DefaultServerName,
+	})
+	check := report.Checks[len(report.Checks)-1]
+	assert.Equal(t, "agent-config", check.ID)
+	assert.Equal(t, CheckFail, check.Status)
+	assert.Equal(t, "Run 'jf mcp install --agent gemini'.", check.Fix)
+}
+
+func TestDoctor_NoURL(t *testing.T) {
+	isolateDoctorEnv(t)
+	t.Setenv("JFROG_CLI_MCP_URL", "")
+	report := Doctor(DoctorParams{ServerDetails: &coreconfig.ServerDetails{}, ProjectDir: t.TempDir(), ServerName: DefaultServerName})
+	statuses := checkStatuses(report)
+	assert.Equal(t, []string{CheckFail}, statuses["mcp-url"])
+	assert.NotContains(t, statuses, "endpoint", "network checks need a URL")
+}
+
+func TestDoctor_TLS(t *testing.T) {
+	isolateDoctorEnv(t)
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	report := Doctor(DoctorParams{ServerDetails: &coreconfig.ServerDetails{Url: srv.URL}, ProjectDir: t.TempDir(), ServerName: DefaultServerName})
+	statuses := checkStatuses(report)
+	assert.Equal(t, []string{CheckPass}, statuses["mcp-url"])
+	assert.Equal(t, []string{CheckFail}, statuses["tls"], report.Checks)
+	assert.Equal(t, []string{CheckFail}, statuses["endpoint"])
+
+	report = Doctor(DoctorParams{ServerDetails: &coreconfig.ServerDetails{Url: srv.URL, InsecureTls: true}, ProjectDir: t.TempDir(), ServerName: DefaultServerName})
+	for _, check := range report.Checks {
+		if check.ID == "tls" {
+			assert.Equal(t, CheckWarn, check.Status)
+			assert.Contains(t, check.Message, "Certificate verification is disabled")
+		}
+	}
+	assert.Equal(t, []string{CheckPass}, checkStatuses(report)["endpoint"])
+}
+
+func TestProxyFromEnv(t *testing.T) {
+	cases := []struct {
+		name, url, httpsProxy, noProxy, expectedVar string
+	}{
+		{name: "no proxy", url: "https://acme.jfrog.io/mcp"},
+		{name: "https proxy", url: "https://acme.jfrog.io/mcp", httpsProxy: "proxy:3128", expectedVar: "HTTPS_PROXY"},
+		{name: "http url ignores https proxy", url: "http://acme.jfrog.io/mcp", httpsProxy: "proxy:3128"},
+		{name: "no_proxy domain", url: "https://acme.jfrog.io/mcp", httpsProxy: "proxy:3128", noProxy: "example.com, .jfrog.io"},
+		{name: "no_proxy host with port", url: "https://acme.jfrog.io/mcp", httpsProxy: "proxy:3128", noProxy: "acme.jfrog.io:443"},
+		{name: "no_proxy other", url: "https://acme.jfrog.io/mcp", httpsProxy: "proxy:3128", noProxy: "jfrog.com", expectedVar: "HTTPS_PROXY"},
+		{name: "no_proxy wildcard", url: "https://acme.jfrog.io/mcp", httpsProxy: "proxy:3128", noProxy: "*"},
+		{name: "loopback", url: "https://127.0.0.1:8443/mcp", httpsProxy: "proxy:3128"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			isolateDoctorEnv(t)
+			t.Setenv("HTTPS_PROXY", tc.httpsProxy)
+			t.Setenv("NO_PROXY", tc.noProxy)
+			u, err := url.Parse(tc.url)
+			require.NoError(t, err)
+			variable, _ := proxyFromEnv(u)
+			assert.Equal(t, tc.expectedVar, variable)
+		})
+	}
+}
@@ -0,0 +1,463 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Agent JSON configs are edited in place rather than re-marshaled, so that
+// only the MCP server entry changes: the user's comments, key order, trailing
+// commas and indentation are left as they are. The helpers below scan JSONC
+// (JSON with comments and trailing commas) just enough to locate object
+// members by key.
+
+// jsonMember is a member of a JSON object, as offsets into the document.
+type jsonMember struct {
+	key        string
+	keyStart   int
+	valueStart int
+	valueEnd   int
+}
+
+// jsonObject is an object of a JSON document: the offsets of its braces and
+// its members, in document order.
+type jsonObject struct {
+	open, close int
+	members     []jsonMember
+}
+
+// member returns the member with the given key. As with encoding/json, the
+// last of duplicate keys wins.
+func (o jsonObject) member(key string) (int, bool) {
+	for i := len(o.members) - 1; i >= 0; i-- {
+		if o.members[i].key == key {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func jsoncSyntaxError(data []byte, i int, msg string) error {
+	line := bytes.Count(data[:min(i, len(data))], []byte("\n")) + 1
+	return errorutils.CheckErrorf("invalid JSON at line %d: %s", line, msg)
+}
+
+// skipSpace returns the offset of the next character of data from i that
+// isn't whitespace or inside a comment.
+func skipSpace(data []byte, i int) (int, error) {
+	for i < len(data) {
+		switch {
+		case data[i] == ' ' || data[i] == '\t' || data[i] == '\r' || data[i] == '\n':
+			i++
+		case bytes.HasPrefix(data[i:], []byte("//")):
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+		case bytes.HasPrefix(data[i:], []byte("/*")):
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return 0, jsoncSyntaxError(data, i, "unterminated comment")
+			}
+			i += end + 4
+		default:
+			return i, nil
+		}
+	}
+	return i, nil
+}
+
+// skipString returns the offset just past the string starting at data[i].
+func skipString(data []byte, i int) (int, error) {
+	for j := i + 1; j < len(data); j++ {
+		switch data[j] {
+		case '\\':
+			j++
+		case '"':
+			return j + 1, nil
+		}
+	}
+	return 0, jsoncSyntaxError(data, i, "unterminated string")
+}
+
+// skipValue returns the offset just past the value starting at data[i].
+func skipValue(data []byte, i int) (int, error) {
+	if i >= len(data) {
+		return 0, jsoncSyntaxError(data, i, "unexpected end of input")
+	}
+	switch data[i] {
+	case '"':
+		return skipString(data, i)
+	case '{', '[':
+		depth := 0
+		for j := i; j < len(data); {
+			switch data[j] {
+			case '"':
+				end, err := skipString(data, j)
+				if err != nil {
+					return 0, err
+				}
+				j = end
+				continue
+			case '/':
+				end, err := skipSpace(data, j)
+				if err != nil {
+					return 0, err
+				}
+				if end > j {
+					j = end
+					continue
+				}
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return j + 1, nil
+				}
+			}
+			j++
+		}
+		return 0, jsoncSyntaxError(data, i, "unterminated object or array")
+	default:
+		j := i
+		for j < len(data) && !strings.ContainsRune(",}] \t\r\n/", rune(data[j])) {
+			j++
+		}
+		if j == i {
+			return 0, jsoncSyntaxError(data, i, "unexpected character '"+string(data[i])+"'")
+		}
+		return j, nil
+	}
+}
+
+// parseObject scans the object starting at data[open].
+func parseObject(data []byte, open int) (jsonObject, error) {
+	obj := jsonObject{open: open}
+	i := open + 1
+	for {
+		var err error
+		if i, err = skipSpace(data, i); err != nil {
+			return obj, err
+		}
+		if i < len(data) && data[i] == '}' {
+			obj.close = i
+			return obj, nil
+		}
+		if i >= len(data) || data[i] != '"' {
+			return obj, jsoncSyntaxError(data, i, "expected a key")
+		}
+		m := jsonMember{keyStart: i}
+		keyEnd, err := skipString(data, i)
+		if err != nil {
+			return obj, err
+		}
+		if err = json.Unmarshal(data[i:keyEnd], &m.key); err != nil {
+			return obj, jsoncSyntaxError(data, i, err.Error())
+		}
+		if i, err = skipSpace(data, keyEnd); err != nil {
+			return obj, err
+		}
+		if i >= len(data) || data[i] != ':' {
+			return obj, jsoncSyntaxError(data, i, "expected ':' after key")
+		}
+		if m.valueStart, err = skipSpace(data, i+1); err != nil {
+			return obj, err
+		}
+		if m.valueEnd, err = skipValue(data, m.valueStart); err != nil {
+			return obj, err
+		}
+		obj.members = append(obj.members, m)
+		if i, err = skipSpace(data, m.valueEnd); err != nil {
+			return obj, err
+		}
+		if i < len(data) && data[i] == ',' {
+			i++
+		}
+	}
+}
+
+// rootObject scans the document's top-level object.
+func rootObject(data []byte) (jsonObject, error) {
+	i, err := skipSpace(data, 0)
+	if err != nil {
+		return jsonObject{}, err
+	}
+	if i >= len(data) || data[i] != '{' {
+		return jsonObject{}, jsoncSyntaxError(data, i, "expected a top-level object")
+	}
+	return parseObject(data, i)
+}
+
+// lineIndent returns the whitespace the line holding data[i] starts with.
+func lineIndent(data []byte, i int) string {
+	start := bytes.LastIndexByte(data[:i], '\n') + 1
+	end := start
+	for end < len(data) && (data[end] == ' ' || data[end] == '\t') {
+		end++
+	}
+	return string(data[start:end])
+}
+
+// indentUnit guesses the document's indentation step from its first indented
+// line, defaulting to two spaces.
+func indentUnit(data []byte) string {
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed != "" && len(trimmed) < len(line) {
+			return line[:len(line)-len(trimmed)]
+		}
+	}
+	return "  "
+}
+
+// marshalJSONValue renders v as indented JSON continuing a line indented by
+// indent, without escaping HTML characters in URLs.
+func marshalJSONValue(v interface{}, indent, unit string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent(indent, unit)
+	if err := enc.Encode(v); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
+
+func splice(data []byte, start, end int, insert []byte) []byte {
+	out := make([]byte, 0, len(data)-(end-start)+len(insert))
+	out = append(out, data[:start]...)
+	out = append(out, insert...)
+	return append(out, data[end:]...)
+}
+
+// insertMember adds a key: value member as the last member of obj.
+func insertMember(data []byte, obj jsonObject, key string, value interface{}) ([]byte, error) {
+	unit := indentUnit(data)
+	var indent string
+	if len(obj.members) > 0 {
+		indent = lineIndent(data, obj.members[0].keyStart)
+	} else {
+		indent = lineIndent(data, obj.open) + unit
+	}
+	keyJSON, err := marshalJSONValue(key, "", "")
+	if err != nil {
+		return nil, err
+	}
+	valueJSON, err := marshalJSONValue(value, indent, unit)
+	if err != nil {
+		return nil, err
+	}
+	member := "\n" + indent + string(keyJSON) + ": " + string(valueJSON)
+
+	if len(obj.members) == 0 {
+		inner := data[obj.open+1 : obj.close]
+		if len(bytes.TrimSpace(inner)) == 0 {
+			return splice(data, obj.open+1, obj.close, []byte(member+"\n"+lineIndent(data, obj.close))), nil
+		}
+		// Keep any comments in the empty object after the new member.
+		return splice(data, obj.open+1, obj.open+1, []byte(member)), nil
+	}
+
+	// Insert after the last member's line, past its trailing comma and comment
+	// if any, so the comment stays with the member it annotates.
+	last := obj.members[len(obj.members)-1]
+	i := last.valueEnd
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t') {
+		i++
+	}
+	hadComma := i < len(data) && data[i] == ','
+	if hadComma {
+		i++
+		for i < len(data) && (data[i] == ' ' || data[i] == '\t') {
+			i++
+		}
+	}
+	if bytes.HasPrefix(data[i:], []byte("//")) {
+		for i < len(data) && data[i] != '\n' {
+			i++
+		}
+	} else if i < len(data) && data[i] != '\n' && data[i] != '\r' {
+		i = last.valueEnd
+		if hadComma {
+			i = bytes.IndexByte(data[last.valueEnd:], ',') + last.valueEnd + 1
+		}
+	}
+	if i > 0 && data[i-1] == '\r' {
+		i--
+	}
+	if hadComma {
+		// Keep the document's trailing-comma style.
+		return splice(data, i, i, []byte(member+",")), nil
+	}
+	data = splice(data, i, i, []byte(member))
+	return splice(data, last.valueEnd, last.valueEnd, []byte(",")), nil
+}
+
+// removeMember deletes the idx-th member of obj, with its line when it's on
+// a line of its own.
+func removeMember(data []byte, obj jsonObject, idx int) []byte {
+	m := obj.members[idx]
+	start, end := m.keyStart, m.valueEnd
+
+	i := end
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t') {
+		i++
+	}
+	hadComma := i < len(data) && data[i] == ','
+	if hadComma {
+		end = i + 1
+	}
+
+	lineStart := start
+	for lineStart > 0 && (data[lineStart-1] == ' ' || data[lineStart-1] == '\t') {
+		lineStart--
+	}
+	if lineStart == 0 || data[lineStart-1] == '\n' {
+		// The member starts its line: drop the rest of the line too, if it's
+		// only whitespace or a comment.
+		j := end
+		for j < len(data) && (data[j] == ' ' || data[j] == '\t' || data[j] == '\r') {
+			j++
+		}
+		if bytes.HasPrefix(data[j:], []byte("//")) {
+			for j < len(data) && data[j] != '\n' {
+				j++
+			}
+		}
+		if j >= len(data) || data[j] == '\n' {
+			start, end = lineStart, min(j+1, len(data))
+		}
+	}
+	data = splice(data, start, end, nil)
+
+	if !hadComma && idx > 0 {
+		// The removed member was the last one: drop the comma separating it
+		// from the one before, which is now last.
+		prev := obj.members[idx-1]
+		if comma := bytes.IndexByte(data[prev.valueEnd:start], ','); comma >= 0 {
+			data = splice(data, prev.valueEnd+comma, prev.valueEnd+comma+1, nil)
+		}
+	}
+	return data
+}
+
+// setJSONMember sets data's root[key][name] to value, adding the key and
+// name members as needed, and leaving the rest of data as it is.
+func setJSONMember(data []byte, key, name string, value interface{}) ([]byte, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		out, err := marshalJSONValue(map[string]interface{}{key: map[string]interface{}{name: value}}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(out, '\n'), nil
+	}
+	root, err := rootObject(data)
+	if err != nil {
+		return nil, err
+	}
+	idx, ok := root.member(key)
+	if !ok {
+		return insertMember(data, root, key, map[string]interface{}{name: value})
+	}
+	m := root.members[idx]
+	if data[m.valueStart] != '{' {
+		// Replace a null or otherwise invalid servers value.
+		return replaceValue(data, m, map[string]interface{}{name: value})
+	}
+	servers, err := parseObject(data, m.valueStart)
+	if err != nil {
+		return nil, err
+	}
+	if idx, ok = servers.member(name); ok {
+		return replaceValue(data, servers.members[idx], value)
+	}
+	return insertMember(data, servers, name, value)
+}
+
+func replaceValue(data []byte, m jsonMember, value interface{}) ([]byte, error) {
+	valueJSON, err := marshalJSONValue(value, lineIndent(data, m.keyStart), indentUnit(data))
+	if err != nil {
+		return nil, err
+	}
+	return splice(data, m.valueStart, m.valueEnd, valueJSON), nil
+}
+
+// removeJSONMember removes data's root[key][name], reporting whether it was
+// present.
+func removeJSONMember(data []byte, key, name string) ([]byte, bool, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return data, false, nil
+	}
+	root, err := rootObject(data)
+	if err != nil {
+		return nil, false, err
+	}
+	idx, ok := root.member(key)
+	if !ok || data[root.members[idx].valueStart] != '{' {
+		return data, false, nil
+	}
+	servers, err := parseObject(data, root.members[idx].valueStart)
+	if err != nil {
+		return nil, false, err
+	}
+	if idx, ok = servers.member(name); !ok {
+		return data, false, nil
+	}
+	data = removeMember(data, servers, idx)
+	if servers, err = parseObject(data, servers.open); err != nil {
+		return nil, false, err
+	}
+	if len(servers.members) == 0 && len(bytes.TrimSpace(data[servers.open+1:servers.close])) == 0 {
+		data = splice(data, servers.open, servers.close+1, []byte("{}"))
+	}
+	return data, true, nil
+}
+
+// stripJSONC turns JSONC into JSON: line and block comments are blanked and
+// trailing commas before a closing bracket dropped, leaving strings intact.
+func stripJSONC(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		switch {
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				out = append(out, '\n')
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return out
+			}
+			i += end + 3
+		case c == ']' || c == '}':
+			out = append(bytes.TrimRight(out, " \t\r\n"), c)
+			if n := len(out); n >= 2 && out[n-2] == ',' {
+				out = append(out[:n-2], c)
+			}
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,112 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripJSONC(t *testing.T) {
+	cases := []struct {
+		name, in, want string
+	}{
+		{"plain json", `{"a": [1, 2]}`, `{"a": [1, 2]}`},
+		{"line comment", "{\n  // note\n  \"a\": 1\n}", "{\n  \n  \"a\": 1}"},
+		{"block comment", `{/* note */"a": 1}`, `{"a": 1}`},
+		{"trailing commas", `{"a": [1, 2,], "b": {"c": 3,},}`, `{"a": [1, 2], "b": {"c": 3}}`},
+		{"comment markers in strings", `{"url": "https://x.example/*a*/", "s": "a,}"}`, `{"url": "https://x.example/*a*/", "s": "a,}"}`},
+		{"escaped quote", `{"s": "a\" // b"}`, `{"s": "a\" // b"}`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, string(stripJSONC([]byte(tc.in))))
+		})
+	}
+}
+
+func TestSetJSONMember(t *testing.T) {
+	entry := map[string]interface{}{"url": "https://platform.example/mcp?a=1&b=2"}
+	cases := []struct {
+		name, in, want string
+	}{
+		{
+			name: "empty file",
+			in:   "",
+			want: "{\n  \"mcpServers\": {\n    \"jfrog\": {\n      \"url\": \"https://platform.example/mcp?a=1&b=2\"\n    }\n  }\n}\n",
+		},
+		{
+			name: "adds the servers key after the last member, keeping order and comments",
+			in:   "{\n  // editor settings\n  \"zeta\": 1,\n  \"alpha\": true // last\n}\n",
+			want: "{\n  // editor settings\n  \"zeta\": 1,\n  \"alpha\": true, // last\n  \"mcpServers\": {\n    \"jfrog\": {\n      \"url\": \"https://platform.example/mcp?a=1&b=2\"\n    }\n  }\n}\n",
+		},
+		{
+			name: "adds an entry keeping a trailing comma",
+			in:   "{\n\t\"mcpServers\": {\n\t\t\"other\": {\"url\": \"https://other.example\"},\n\t},\n}\n",
+			want: "{\n\t\"mcpServers\": {\n\t\t\"other\": {\"url\": \"https://other.example\"},\n\t\t\"jfrog\": {\n\t\t\t\"url\": \"https://platform.example/mcp?a=1&b=2\"\n\t\t},\n\t},\n}\n",
+		},
+		{
+			name: "fills an empty servers object",
+			in:   "{\n  \"mcpServers\": {}\n}\n",
+			want: "{\n  \"mcpServers\": {\n    \"jfrog\": {\n      \"url\": \"https://platform.example/mcp?a=1&b=2\"\n    }\n  }\n}\n",
+		},
+		{
+			name: "replaces only the existing entry",
+			in:   "{\n  \"mcpServers\": {\n    /* ours */ \"jfrog\": {\"url\": \"https://old.example\", \"type\": \"sse\"},\n    \"other\": {}\n  }\n}\n",
+			want: "{\n  \"mcpServers\": {\n    /* ours */ \"jfrog\": {\n      \"url\": \"https://platform.example/mcp?a=1&b=2\"\n    },\n    \"other\": {}\n  }\n}\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := setJSONMember([]byte(tc.in), "mcpServers", "jfrog", entry)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestSetJSONMember_InvalidJSON(t *testing.T) {
+	for _, in := range []string{`[]`, `{"mcpServers": {`, `{"a" 1}`, `{/* open`} {
+		_, err := setJSONMember([]byte(in), "mcpServers", "jfrog", map[string]interface{}{})
+		assert.Error(t, err, in)
+	}
+}
+
+func TestRemoveJSONMember(t *testing.T) {
+	cases := []struct {
+		name, in, want string
+		removed        bool
+	}{
+		{
+			name:    "first of several, with its comment",
+			in:      "{\n  \"mcpServers\": {\n    \"jfrog\": {\"url\": \"u\"}, // ours\n    \"other\": {}\n  }\n}\n",
+			want:    "{\n  \"mcpServers\": {\n    \"other\": {}\n  }\n}\n",
+			removed: true,
+		},
+		{
+			name:    "last, dropping the separating comma",
+			in:      "{\n  \"mcpServers\": {\n    \"other\": {}, // theirs\n    \"jfrog\": {\"url\": \"u\"}\n  }\n}\n",
+			want:    "{\n  \"mcpServers\": {\n    \"other\": {} // theirs\n  }\n}\n",
+			removed: true,
+		},
+		{
+			name:    "only entry",
+			in:      "{\n  \"mcpServers\": {\n    \"jfrog\": {\"url\": \"u\"},\n  },\n  \"b\": 1\n}\n",
+			want:    "{\n  \"mcpServers\": {},\n  \"b\": 1\n}\n",
+			removed: true,
+		},
+		{
+			name: "absent",
+			in:   "{\"mcpServers\": {\"other\": {}}}",
+			want: "{\"mcpServers\": {\"other\": {}}}",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, removed, err := removeJSONMember([]byte(tc.in), "mcpServers", "jfrog")
+			require.NoError(t, err)
+			assert.Equal(t, tc.removed, removed)
+			assert.Equal(t, tc.want, string(got))
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package mcp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	coreconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// entryURLKeys are the keys agents name a remote server's URL with.
+var entryURLKeys = []string{"url", "serverUrl", "httpUrl"}
+
+// ConfiguredEntry is a JFrog MCP server entry found in an agent config by
+// 'jf mcp list', in the stable JSON schema it prints with --format json.
+type ConfiguredEntry struct {
+	Agent      string `json:"agent"`
+	Scope      string `json:"scope"`
+	ConfigFile string `json:"configFile"`
+	Name       string `json:"name"`
+	McpUrl     string `json:"mcpUrl,omitempty"`
+	// Local is set for an entry launching the local MCP server ('jf mcp serve')
+	// with Command.
+	Local   bool   `json:"local"`
+	Command string `json:"command,omitempty"`
+	// ServerId is the configured server whose MCP endpoint the entry points
+	// at, if any.
+	ServerId string `json:"serverId,omitempty"`
+	// Current is set when the entry points at the MCP endpoint of the current
+	// server, as 'jf mcp show' resolves it.
+	Current bool `json:"current"`
+	// Stale is set when the entry points at the MCP endpoint of no configured
+	// server.
+	Stale bool `json:"stale"`
+	// Duplicate is set when the agent has more than one JFrog entry, across
+	// the project and global scopes.
+	Duplicate bool `json:"duplicate"`
+}
+
+// ListInfo is the stable JSON schema printed by 'jf mcp list --format json'.
+type ListInfo struct {
+	ServerId string            `json:"serverId,omitempty"`
+	McpUrl   string            `json:"mcpUrl,omitempty"`
+	Entries  []ConfiguredEntry `json:"entries"`
+}
+
+// ListParams holds the resolved inputs for a list operation.
+type ListParams struct {
+	ProjectDir string
+	// McpURL is the current server's MCP endpoint, or empty if none resolves.
+	McpURL string
+	// Servers are the configured servers, to attribute entries to.
+	Servers []*coreconfig.ServerDetails
+}
+
+// List scans the project and global config of every supported agent for
+// JFrog MCP server entries: those named after JFrog, or pointing at the MCP
+// endpoint of the current or a configured server. Config files that fail to
+// parse are skipped with a warning.
+func List(params ListParams) []ConfiguredEntry {
+	endpoints := make(map[string]string, len(params.Servers))
+	for _, server := range params.Servers {
+		if url, err := ResolveMcpURL("", server); err == nil {
+			endpoints[normalizeURL(url)] = server.ServerId
+		}
+	}
+	current := normalizeURL(params.McpURL)
+
+	entries := []ConfiguredEntry{}
+	for _, agentName := range agentNames() {
+		spec := supportedAgents[agentName]
+		var agentEntries []ConfiguredEntry
+		scanned := map[string]bool{}
+		for _, global := range []bool{false, true} {
+			path, err := spec.configFilePath(params.ProjectDir, global)
+			if err != nil || scanned[path] {
+				// No config at this scope, or the project is the home directory.
+				continue
+			}
+			scanned[path] = true
+			root, err := readConfig(path, spec.format)
+			if err != nil {
+				log.Warn(fmt.Sprintf("Skipping the %s configuration: %s", agentName, err.Error()))
+				continue
+			}
+			servers, _ := root[spec.serversKey].(map[string]interface{})
+			for _, name := range sortedKeys(servers) {
+				entry, _ := servers[name].(map[string]interface{})
+				url := entryURL(entry)
+				local := isLocalEntry(entry)
+				serverId, known := endpoints[normalizeURL(url)]
+				isCurrent := current != "" && normalizeURL(url) == current
+				if !local && !isCurrent && !known && !strings.Contains(strings.ToLower(name), DefaultServerName) {
+					continue
+				}
+				configured := ConfiguredEntry{
+					Agent:      agentName,
+					Scope:      scopeName(global),
+					ConfigFile: path,
+					Name:       name,
+					McpUrl:     url,
+					Local:      local,
+					ServerId:   serverId,
+					Current:    isCurrent,
+					Stale:      !local && !isCurrent && !known,
+				}
+				if local {
+					configured.Command, _ = entry["command"].(string)
+				}
+				agentEntries = append(agentEntries, configured)
+			}
+		}
+		for i := range agentEntries {
+			agentEntries[i].Duplicate = len(agentEntries) > 1
+		}
+		entries = append(entries, agentEntries...)
+	}
+	return entries
+}
+
+func entryURL(entry map[string]interface{}) string {
+	for _, key := range entryURLKeys {
+		if url, ok := entry[key].(string); ok && url != "" {
+			return url
+		}
+	}
+	return ""
+}
+
+// isLocalEntry tells whether entry launches the local MCP server, with
+// 'mcp serve' arguments, as 'jf mcp install --local' writes it.
+func isLocalEntry(entry map[string]interface{}) bool {
+	args, _ := entry["args"].([]interface{})
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] == "mcp" && args[i+1] == "serve" {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeURL makes MCP endpoints comparable, ignoring a trailing slash and
+// the case of the scheme and host.
+func normalizeURL(url string) string {
+	url = strings.TrimRight(strings.TrimSpace(url), "/")
+	scheme, rest, ok := strings.Cut(url, "://")
+	if !ok {
+		return url
+	}
+	host, path, _ := strings.Cut(rest, "/")
+	normalized := strings.ToLower(scheme) + "://" + strings.ToLower(host)
+	if path != "" {
+		normalized += "/" + path
+	}
+	return normalized
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
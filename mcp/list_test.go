@@ -0,0 +1,89 @@
+package mcp
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	coreconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestList(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	project := t.TempDir()
+
+	// Current, in Cursor's project config, next to an unrelated server.
+	writeFile(t, filepath.Join(project, ".cursor", "mcp.json"), `{"mcpServers": {
+		"jfrog": {"url": "https://Platform.example/mcp/"},
+		"github": {"url": "https://api.github.example/mcp"}
+	}}`)
+	// Another configured server's, under a custom name, in Cursor's global config:
+	// a duplicate of the project entry.
+	writeFile(t, filepath.Join(home, ".cursor", "mcp.json"), `{"mcpServers": {"prod": {"url": "https://prod.example/mcp"}}}`)
+	// Stale, in Windsurf's global config.
+	writeFile(t, filepath.Join(home, ".codeium", "windsurf", "mcp_config.json"), `{"mcpServers": {"jfrog": {"serverUrl": "https://old.example/mcp"}}}`)
+	// Unparsable, skipped.
+	writeFile(t, filepath.Join(project, ".mcp.json"), `{"mcpServers": `)
+
+	entries := List(ListParams{
+		ProjectDir: project,
+		McpURL:     "https://platform.example/mcp",
+		Servers: []*coreconfig.ServerDetails{
+			{ServerId: "dev", Url: "https://platform.example/"},
+			{ServerId: "prod", Url: "https://prod.example"},
+		},
+	})
+	assert.Equal(t, []ConfiguredEntry{
+		{Agent: "cursor", Scope: "project", ConfigFile: filepath.Join(project, ".cursor", "mcp.json"), Name: "jfrog", McpUrl: "https://Platform.example/mcp/", ServerId: "dev", Current: true, Duplicate: true},
+		{Agent: "cursor", Scope: "global", ConfigFile: filepath.Join(home, ".cursor", "mcp.json"), Name: "prod", McpUrl: "https://prod.example/mcp", ServerId: "prod", Duplicate: true},
+		{Agent: "windsurf", Scope: "global", ConfigFile: filepath.Join(home, ".codeium", "windsurf", "mcp_config.json"), Name: "jfrog", McpUrl: "https://old.example/mcp", Stale: true},
+	}, entries)
+}
+
+func TestList_NoEntries(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	assert.Empty(t, List(ListParams{ProjectDir: t.TempDir()}))
+}
+
+func TestList_FindsInstalledEntries(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	project := t.TempDir()
+	const url = "https://platform.example/mcp"
+
+	var out bytes.Buffer
+	for _, agent := range agentNames() {
+		params := InstallParams{Agent: agent, ServerName: DefaultServerName, McpURL: url, ProjectDir: project, SkipCheck: true}
+		params.Global = supportedAgents[agent].projectFile == ""
+		require.NoError(t, Install(params, &out), agent)
+	}
+
+	entries := List(ListParams{ProjectDir: project, McpURL: url})
+	require.Len(t, entries, len(supportedAgents))
+	for _, e := range entries {
+		assert.Equal(t, url, e.McpUrl, e.Agent)
+		assert.True(t, e.Current, e.Agent)
+		assert.False(t, e.Stale, e.Agent)
+		assert.False(t, e.Duplicate, e.Agent)
+	}
+}
+
+func TestNormalizeURL(t *testing.T) {
+	assert.Equal(t, "https://platform.example/Path/mcp", normalizeURL(" HTTPS://Platform.Example/Path/mcp/ "))
+	assert.Equal(t, "https://platform.example", normalizeURL("https://platform.example/"))
+	assert.Equal(t, "not a url", normalizeURL("not a url"))
+}
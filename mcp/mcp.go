@@ -1,14 +1,12 @@
 package mcp
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
-	"path/filepath"
-	"sort"
 	"strings"
+	"time"
 
 	coreconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
@@ -18,78 +16,9 @@ import (
 	"github.com/jfrog/jfrog-client-go/utils/log"
 )
 
-// mcpServersKey is the top-level key used by both Cursor and Claude to hold MCP
-// server definitions.
-const mcpServersKey = "mcpServers"
-
 // DefaultServerName is the entry name written to the agent configuration.
 const DefaultServerName = "jfrog"
 
-// agentSpec describes where and how to write the MCP server entry for a given
-// AI agent. The config schema is agent-specific: Cursor omits the "type" field
-// for remote servers, while Claude requires "type": "http".
-type agentSpec struct {
-	// projectFile is the config file path relative to the project directory.
-	projectFile string
-	// globalFile is the user-level config file path (may start with "~").
-	globalFile string
-	// includeType controls whether the entry includes "type": "http" (Claude).
-	includeType bool
-}
-
-// supportedAgents maps the user-facing agent name to its configuration spec.
-// Scope is intentionally limited to cursor and claude.
-var supportedAgents = map[string]agentSpec{
-	"cursor": {projectFile: filepath.Join(".cursor", "mcp.json"), globalFile: filepath.Join("~", ".cursor", "mcp.json"), includeType: false},
-	"claude": {projectFile: ".mcp.json", globalFile: filepath.Join("~", ".claude.json"), includeType: true},
-}
-
-// SupportedAgentNames returns the supported agent names, sorted, for help and
-// error messages.
-func SupportedAgentNames() string {
-	names := make([]string, 0, len(supportedAgents))
-	for name := range supportedAgents {
-		names = append(names, name)
-	}
-	sort.Strings(names)
-	return strings.Join(names, ", ")
-}
-
-func resolveAgent(name string) (agentSpec, string, error) {
-	normalized := strings.ToLower(strings.TrimSpace(name))
-	if normalized == "" {
-		return agentSpec{}, "", errorutils.CheckErrorf("the --agent flag is required. Supported agents: %s", SupportedAgentNames())
-	}
-	spec, ok := supportedAgents[normalized]
-	if !ok {
-		return agentSpec{}, "", errorutils.CheckErrorf("unsupported agent %q. Supported agents: %s", name, SupportedAgentNames())
-	}
-	return spec, normalized, nil
-}
-
-// configFilePath resolves the absolute config file path for the agent at the
-// requested scope.
-func (a agentSpec) configFilePath(projectDir string, global bool) (string, error) {
-	if global {
-		return expandHome(a.globalFile)
-	}
-	if projectDir == "" {
-		projectDir = "."
-	}
-	return filepath.Abs(filepath.Join(projectDir, a.projectFile))
-}
-
-func expandHome(path string) (string, error) {
-	if path == "~" || strings.HasPrefix(path, "~"+string(os.PathSeparator)) {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return "", errorutils.CheckError(err)
-		}
-		path = filepath.Join(home, strings.TrimPrefix(path, "~"))
-	}
-	return filepath.Abs(path)
-}
-
 // ResolveMcpURL determines the remote MCP endpoint. Precedence: explicit override
 // (flag value, passed in as urlOverride) > JFROG_CLI_MCP_URL env var > derived
 // from the configured platform URL as <platform-url>/mcp.
@@ -113,6 +42,46 @@ type EndpointInfo struct {
 	PlatformUrl string `json:"platformUrl,omitempty"`
 	McpUrl      string `json:"mcpUrl"`
 	Transport   string `json:"transport"`
+	// Agent is set when an agent is requested with 'jf mcp show --agent'.
+	Agent *AgentConfig `json:"agent,omitempty"`
+}
+
+// AgentConfig describes where and how 'jf mcp install' configures an agent.
+type AgentConfig struct {
+	Name       string                 `json:"name"`
+	Scope      string                 `json:"scope"`
+	ConfigFile string                 `json:"configFile"`
+	ServersKey string                 `json:"serversKey"`
+	Format     string                 `json:"format"`
+	Entry      map[string]interface{} `json:"entry"`
+}
+
+// DescribeAgent returns the configuration 'jf mcp install' would write for the
+// agent at the requested scope, with the entry pointing at mcpURL.
+func DescribeAgent(agent, projectDir string, global bool, mcpURL string) (*AgentConfig, error) {
+	spec, agentName, err := resolveAgent(agent)
+	if err != nil {
+		return nil, err
+	}
+	path, err := spec.configFilePath(projectDir, global)
+	if err != nil {
+		return nil, err
+	}
+	return &AgentConfig{
+		Name:       agentName,
+		Scope:      scopeName(global),
+		ConfigFile: path,
+		ServersKey: spec.serversKey,
+		Format:     string(spec.format),
+		Entry:      spec.entry(mcpURL),
+	}, nil
+}
+
+func scopeName(global bool) string {
+	if global {
+		return "global"
+	}
+	return "project"
 }
 
 // CheckAvailability verifies the MCP endpoint is reachable. Any HTTP response —
@@ -144,50 +113,6 @@ func CheckAvailability(serverDetails *coreconfig.ServerDetails, mcpURL string) e
 	return nil
 }
 
-// readConfig loads the agent config file into a generic JSON object. A missing
-// file yields an empty object.
-func readConfig(path string) (map[string]interface{}, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return map[string]interface{}{}, nil
-		}
-		return nil, errorutils.CheckError(err)
-	}
-	if len(strings.TrimSpace(string(data))) == 0 {
-		return map[string]interface{}{}, nil
-	}
-	root := map[string]interface{}{}
-	if err := json.Unmarshal(data, &root); err != nil {
-		return nil, errorutils.CheckErrorf("failed to parse existing config %s: %s", path, err.Error())
-	}
-	return root, nil
-}
-
-func mcpServersMap(root map[string]interface{}) map[string]interface{} {
-	if existing, ok := root[mcpServersKey].(map[string]interface{}); ok {
-		return existing
-	}
-	servers := map[string]interface{}{}
-	root[mcpServersKey] = servers
-	return servers
-}
-
-func marshalConfig(root map[string]interface{}) ([]byte, error) {
-	data, err := json.MarshalIndent(root, "", "  ")
-	if err != nil {
-		return nil, errorutils.CheckError(err)
-	}
-	return append(data, '\n'), nil
-}
-
-func writeConfigFile(path string, data []byte) error {
-	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
-		return errorutils.CheckError(err)
-	}
-	return errorutils.CheckError(os.WriteFile(path, data, 0600))
-}
-
 // fprintf writes a formatted message to out and returns a checked error, so
 // callers report write failures instead of silently ignoring them.
 func fprintf(out io.Writer, format string, a ...interface{}) error {
@@ -205,6 +130,11 @@ type InstallParams struct {
 	DryRun        bool
 	ServerDetails *coreconfig.ServerDetails
 	SkipCheck     bool
+	// Local registers the local MCP server ('jf mcp serve --stdio'), launched
+	// by the agent as Command with Args, instead of the remote McpURL.
+	Local   bool
+	Command string
+	Args    []string
 }
 
 // Install writes (or previews) the JFrog MCP server entry into the agent config.
@@ -213,7 +143,7 @@ func Install(params InstallParams, out io.Writer) error {
 	if err != nil {
 		return err
 	}
-	if !params.SkipCheck {
+	if !params.SkipCheck && !params.Local {
 		if err := CheckAvailability(params.ServerDetails, params.McpURL); err != nil {
 			return err
 		}
@@ -223,18 +153,19 @@ func Install(params InstallParams, out io.Writer) error {
 		return err
 	}
 
-	root, err := readConfig(path)
+	original, err := readConfigFile(path)
 	if err != nil {
 		return err
 	}
-	servers := mcpServersMap(root)
-	entry := map[string]interface{}{"url": params.McpURL}
-	if spec.includeType {
-		entry["type"] = "http"
+	// Refuse to edit a file that doesn't parse, rather than risk corrupting it.
+	if _, err = parseConfig(path, original, spec.format); err != nil {
+		return err
 	}
-	servers[params.ServerName] = entry
-
-	data, err := marshalConfig(root)
+	entry := spec.entry(params.McpURL)
+	if params.Local {
+		entry = spec.localEntry(params.Command, params.Args)
+	}
+	data, err := setServerEntry(original, spec.format, spec.serversKey, params.ServerName, entry)
 	if err != nil {
 		return err
 	}
@@ -242,25 +173,37 @@ func Install(params InstallParams, out io.Writer) error {
 	if params.DryRun {
 		return fprintf(out, "[Dry run] %s would be updated to:\n%s", path, data)
 	}
-	if err := writeConfigFile(path, data); err != nil {
+	if err := updateConfigFile(path, original, data, params.Global, out); err != nil {
 		return err
 	}
 
-	scope := "project"
-	if params.Global {
-		scope = "global"
+	msg := fmt.Sprintf("Configured the '%s' MCP server for %s (%s scope): %s\n\n", params.ServerName, agentName, scopeName(params.Global), path)
+	if params.Local {
+		msg += fmt.Sprintf("Next step: restart %s (or reload its MCP servers) to start the local server.\n", spec.displayName)
+		msg += "  It runs with the credentials of your JFrog CLI server configuration; no OAuth authorization is needed.\n"
+		return fprintf(out, "%s", msg)
 	}
-	msg := fmt.Sprintf("Configured the '%s' MCP server for %s (%s scope): %s\n\n", params.ServerName, agentName, scope, path)
 	msg += "Next step: the connection is not active until you complete OAuth authorization.\n"
-	switch agentName {
-	case "claude":
-		msg += "  Run /mcp inside Claude Code and complete the browser login.\n"
-	case "cursor":
-		msg += "  Reload Cursor and approve the JFrog MCP server when prompted.\n"
-	}
+	msg += "  " + spec.nextStep + "\n"
 	return fprintf(out, "%s", msg)
 }
 
+// updateConfigFile writes the updated content of an agent config file. A
+// global config is shared by all the user's projects, and often holds other
+// settings too, so its original content is backed up first.
+func updateConfigFile(path string, original, updated []byte, global bool, out io.Writer) error {
+	if global && original != nil {
+		backup, err := backupConfigFile(path, original, time.Now())
+		if err != nil {
+			return err
+		}
+		if err := fprintf(out, "Backed up %s to %s\n", path, backup); err != nil {
+			return err
+		}
+	}
+	return writeConfigFile(path, updated)
+}
+
 // UninstallParams holds the resolved inputs for an uninstall operation.
 type UninstallParams struct {
 	Agent      string
@@ -282,31 +225,33 @@ func Uninstall(params UninstallParams, out io.Writer) error {
 		return err
 	}
 
-	if _, statErr := os.Stat(path); os.IsNotExist(statErr) {
+	original, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+	if original == nil {
 		return fprintf(out, "No %s configuration found at %s; nothing to remove.\n", agentName, path)
 	}
-
-	root, err := readConfig(path)
+	root, err := parseConfig(path, original, spec.format)
 	if err != nil {
 		return err
 	}
-	servers, ok := root[mcpServersKey].(map[string]interface{})
+	servers, ok := root[spec.serversKey].(map[string]interface{})
 	if !ok {
 		return fprintf(out, "No MCP servers configured in %s; nothing to remove.\n", path)
 	}
 	if _, present := servers[params.ServerName]; !present {
 		return fprintf(out, "No '%s' MCP server entry in %s; nothing to remove.\n", params.ServerName, path)
 	}
-	delete(servers, params.ServerName)
 
-	data, err := marshalConfig(root)
+	data, err := removeServerEntry(original, spec.format, spec.serversKey, params.ServerName)
 	if err != nil {
 		return err
 	}
 	if params.DryRun {
 		return fprintf(out, "[Dry run] %s would be updated to:\n%s", path, data)
 	}
-	if err := writeConfigFile(path, data); err != nil {
+	if err := updateConfigFile(path, original, data, params.Global, out); err != nil {
 		return err
 	}
 	return fprintf(out, "Removed the '%s' MCP server entry from %s (%s).\n", params.ServerName, path, agentName)
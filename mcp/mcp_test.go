@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	coreconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
@@ -207,7 +208,7 @@ func TestInstall_ReadinessFailureAbortsWithoutWriting(t *testing.T) {
 
 func TestInstall_UnsupportedAgent(t *testing.T) {
 	var out bytes.Buffer
-	err := Install(InstallParams{Agent: "windsurf", ServerName: DefaultServerName, SkipCheck: true}, &out)
+	err := Install(InstallParams{Agent: "notepad", ServerName: DefaultServerName, SkipCheck: true}, &out)
 	require.Error(t, err)
 }
 
@@ -240,3 +241,267 @@ func TestUninstall_MissingFileIsNoOp(t *testing.T) {
 	}, &out))
 	assert.Contains(t, out.String(), "nothing to remove")
 }
+
+// readAgentServers reads the server entries from an agent's config file, in
+// the agent's format and under its key.
+func readAgentServers(t *testing.T, agent, path string) map[string]interface{} {
+	t.Helper()
+	spec := supportedAgents[agent]
+	root, err := readConfig(path, spec.format)
+	require.NoError(t, err)
+	servers, ok := root[spec.serversKey].(map[string]interface{})
+	require.True(t, ok, "expected a %s object in %s", spec.serversKey, path)
+	return servers
+}
+
+func TestInstall_AgentEntries(t *testing.T) {
+	const url = "https://platform.example/mcp"
+	cases := []struct {
+		agent string
+		file  string
+		entry map[string]interface{}
+	}{
+		{"vscode", filepath.Join(".vscode", "mcp.json"), map[string]interface{}{"type": "http", "url": url}},
+		{"gemini", filepath.Join(".gemini", "settings.json"), map[string]interface{}{"httpUrl": url}},
+		{"codex", filepath.Join(".codex", "config.toml"), map[string]interface{}{"url": url}},
+		{"zed", filepath.Join(".zed", "settings.json"), map[string]interface{}{"url": url}},
+		{"jetbrains", filepath.Join(".junie", "mcp", "mcp.json"), map[string]interface{}{"url": url}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.agent, func(t *testing.T) {
+			dir := t.TempDir()
+			var out bytes.Buffer
+			require.NoError(t, Install(InstallParams{
+				Agent:      tc.agent,
+				ServerName: DefaultServerName,
+				McpURL:     url,
+				ProjectDir: dir,
+				SkipCheck:  true,
+			}, &out))
+
+			servers := readAgentServers(t, tc.agent, filepath.Join(dir, tc.file))
+			assert.Equal(t, tc.entry, servers["jfrog"])
+			assert.Contains(t, out.String(), supportedAgents[tc.agent].nextStep)
+		})
+	}
+}
+
+func TestInstall_VSCode_UsesServersKey(t *testing.T) {
+	dir := t.TempDir()
+	var out bytes.Buffer
+	require.NoError(t, Install(InstallParams{
+		Agent:      "vscode",
+		ServerName: DefaultServerName,
+		McpURL:     "https://platform.example/mcp",
+		ProjectDir: dir,
+		SkipCheck:  true,
+	}, &out))
+
+	data, err := os.ReadFile(filepath.Join(dir, ".vscode", "mcp.json"))
+	require.NoError(t, err)
+	root := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(data, &root))
+	assert.Contains(t, root, "servers")
+	assert.NotContains(t, root, mcpServersKey)
+}
+
+func TestInstall_Zed_ReadsSettingsWithComments(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".zed", "settings.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	existing := `// Zed settings
+{
+  "theme": "One Dark", /* the "theme" // stays */
+  "context_servers": {
+    "other": {"url": "https://other.example/mcp"},
+  },
+}
+`
+	require.NoError(t, os.WriteFile(path, []byte(existing), 0600))
+
+	var out bytes.Buffer
+	require.NoError(t, Install(InstallParams{
+		Agent:      "zed",
+		ServerName: DefaultServerName,
+		McpURL:     "https://platform.example/mcp",
+		ProjectDir: dir,
+		SkipCheck:  true,
+	}, &out))
+
+	servers := readAgentServers(t, "zed", path)
+	assert.Contains(t, servers, "other")
+	assert.Contains(t, servers, "jfrog")
+	root, err := readConfig(path, formatJSON)
+	require.NoError(t, err)
+	assert.Equal(t, "One Dark", root["theme"])
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "// Zed settings\n{\n  \"theme\": \"One Dark\", /* the \"theme\" // stays */\n"), "comments and key order must be kept:\n%s", data)
+}
+
+func TestInstall_GlobalBacksUpExistingFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	path := filepath.Join(home, ".cursor", "mcp.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	existing := "{\n  // keep me\n  \"mcpServers\": {\"other\": {\"url\": \"https://other.example/mcp\"},},\n}\n"
+	require.NoError(t, os.WriteFile(path, []byte(existing), 0600))
+
+	var out bytes.Buffer
+	params := InstallParams{Agent: "cursor", ServerName: DefaultServerName, McpURL: "https://platform.example/mcp", Global: true, SkipCheck: true}
+	require.NoError(t, Install(params, &out))
+
+	backups, err := filepath.Glob(path + ".*.bak")
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	backup, err := os.ReadFile(backups[0])
+	require.NoError(t, err)
+	assert.Equal(t, existing, string(backup))
+	assert.Contains(t, out.String(), backups[0])
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "// keep me")
+	servers := readAgentServers(t, "cursor", path)
+	assert.Contains(t, servers, "other")
+	assert.Contains(t, servers, "jfrog")
+}
+
+func TestInstall_ProjectAndNewFilesAreNotBackedUp(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".cursor", "mcp.json")
+	var out bytes.Buffer
+	params := InstallParams{Agent: "cursor", ServerName: DefaultServerName, McpURL: "https://platform.example/mcp", ProjectDir: dir, SkipCheck: true}
+	require.NoError(t, Install(params, &out))
+	require.NoError(t, Install(params, &out))
+
+	backups, err := filepath.Glob(path + ".*.bak")
+	require.NoError(t, err)
+	assert.Empty(t, backups)
+}
+
+func TestInstall_InvalidConfigIsLeftUntouched(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".cursor", "mcp.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, []byte(`{"mcpServers": {`), 0600))
+
+	var out bytes.Buffer
+	err := Install(InstallParams{Agent: "cursor", ServerName: DefaultServerName, McpURL: "https://platform.example/mcp", ProjectDir: dir, SkipCheck: true}, &out)
+	require.Error(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"mcpServers": {`, string(data))
+}
+
+func TestInstall_Codex_PreservesToml(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".codex", "config.toml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	existing := `model = "o3"
+
+[mcp_servers.other]
+command = "npx"
+args = ["-y", "other-mcp"]
+`
+	require.NoError(t, os.WriteFile(path, []byte(existing), 0600))
+
+	var out bytes.Buffer
+	require.NoError(t, Install(InstallParams{
+		Agent:      "codex",
+		ServerName: DefaultServerName,
+		McpURL:     "https://platform.example/mcp",
+		ProjectDir: dir,
+		SkipCheck:  true,
+	}, &out))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "[mcp_servers.jfrog]")
+	root, err := readConfig(path, formatTOML)
+	require.NoError(t, err)
+	assert.Equal(t, "o3", root["model"])
+
+	require.NoError(t, Uninstall(UninstallParams{Agent: "codex", ServerName: DefaultServerName, ProjectDir: dir}, &out))
+	servers := readAgentServers(t, "codex", path)
+	assert.NotContains(t, servers, "jfrog")
+	assert.Contains(t, servers, "other")
+}
+
+func TestInstall_Windsurf_RequiresGlobal(t *testing.T) {
+	var out bytes.Buffer
+	err := Install(InstallParams{
+		Agent:      "windsurf",
+		ServerName: DefaultServerName,
+		McpURL:     "https://platform.example/mcp",
+		ProjectDir: t.TempDir(),
+		SkipCheck:  true,
+	}, &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--global")
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	require.NoError(t, Install(InstallParams{
+		Agent:      "windsurf",
+		ServerName: DefaultServerName,
+		McpURL:     "https://platform.example/mcp",
+		Global:     true,
+		SkipCheck:  true,
+	}, &out))
+	servers := readAgentServers(t, "windsurf", filepath.Join(home, ".codeium", "windsurf", "mcp_config.json"))
+	assert.Equal(t, map[string]interface{}{"serverUrl": "https://platform.example/mcp"}, servers["jfrog"])
+}
+
+func TestDescribeAgent(t *testing.T) {
+	dir := t.TempDir()
+	info, err := DescribeAgent("VSCode", dir, false, "https://platform.example/mcp")
+	require.NoError(t, err)
+	assert.Equal(t, &AgentConfig{
+		Name:       "vscode",
+		Scope:      "project",
+		ConfigFile: filepath.Join(dir, ".vscode", "mcp.json"),
+		ServersKey: "servers",
+		Format:     "jsonc",
+		Entry:      map[string]interface{}{"type": "http", "url": "https://platform.example/mcp"},
+	}, info)
+
+	_, err = DescribeAgent("notepad", dir, false, "https://platform.example/mcp")
+	assert.Error(t, err)
+}
+
+func TestInstall_Local(t *testing.T) {
+	dir := t.TempDir()
+	var out bytes.Buffer
+	args := []string{"mcp", "serve", "--stdio", "--server-id", "prod"}
+	for _, agent := range []string{"cursor", "claude", "codex"} {
+		require.NoError(t, Install(InstallParams{
+			Agent:      agent,
+			ServerName: DefaultServerName,
+			ProjectDir: dir,
+			Local:      true,
+			Command:    "/usr/local/bin/jf",
+			Args:       args,
+		}, &out), agent)
+	}
+	assert.Contains(t, out.String(), "no OAuth authorization is needed")
+
+	cursor := readAgentServers(t, "cursor", filepath.Join(dir, ".cursor", "mcp.json"))
+	assert.Equal(t, map[string]interface{}{"command": "/usr/local/bin/jf", "args": []interface{}{"mcp", "serve", "--stdio", "--server-id", "prod"}}, cursor["jfrog"])
+	claude := readAgentServers(t, "claude", filepath.Join(dir, ".mcp.json"))
+	assert.Equal(t, "stdio", claude["jfrog"].(map[string]interface{})["type"])
+	codex := readAgentServers(t, "codex", filepath.Join(dir, ".codex", "config.toml"))
+	assert.Equal(t, "/usr/local/bin/jf", codex["jfrog"].(map[string]interface{})["command"])
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	entries := List(ListParams{ProjectDir: dir, McpURL: "https://platform.example/mcp"})
+	require.Len(t, entries, 3)
+	for _, e := range entries {
+		assert.True(t, e.Local, e.Agent)
+		assert.False(t, e.Stale, e.Agent)
+		assert.Equal(t, "/usr/local/bin/jf", e.Command, e.Agent)
+	}
+}
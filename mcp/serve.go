@@ -0,0 +1,169 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The local MCP server ('jf mcp serve --stdio') speaks MCP's JSON-RPC 2.0
+// over the stdio transport: one message per line on stdin and stdout, with
+// logs on stderr. It serves tools only, for agents that can launch a local
+// process but can't reach the platform's remote /mcp endpoint.
+
+// protocolVersions are the MCP revisions the local server speaks, latest
+// first. A client asking for another one is answered with the latest.
+var protocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+const (
+	localServerName = "jfrog-cli"
+	jsonRPCVersion  = "2.0"
+
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// toolResult is the result of a tools/call request. A failing tool reports
+// its error as the content of a result flagged isError, so the agent can
+// read it, rather than as a protocol error.
+type toolResult struct {
+	Content []toolContent `json:"content"`
+	IsError bool          `json:"isError,omitempty"`
+}
+
+type toolContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Server is the local MCP server, serving a set of tools.
+type Server struct {
+	version string
+	tools   []Tool
+}
+
+// NewServer returns a local MCP server serving tools, reporting version as
+// its own.
+func NewServer(version string, tools []Tool) *Server {
+	return &Server{version: version, tools: tools}
+}
+
+// Serve answers the requests read from in on out, one per line, until in is
+// exhausted or ctx is done.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	reader := bufio.NewReader(in)
+	for ctx.Err() == nil {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			if resp := s.handle(ctx, line); resp != nil {
+				data, marshalErr := json.Marshal(resp)
+				if marshalErr != nil {
+					return errorutils.CheckError(marshalErr)
+				}
+				if _, writeErr := out.Write(append(data, '\n')); writeErr != nil {
+					return errorutils.CheckError(writeErr)
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
+
+// handle answers a single message, or returns nil for a notification.
+func (s *Server) handle(ctx context.Context, line []byte) *rpcResponse {
+	var req rpcRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		return errorResponse(nil, rpcParseError, "parse error: "+err.Error())
+	}
+	if req.JSONRPC != jsonRPCVersion || req.Method == "" {
+		return errorResponse(req.ID, rpcInvalidRequest, "invalid request: expected a JSON-RPC 2.0 request with a method")
+	}
+	if len(req.ID) == 0 {
+		// Notifications, such as notifications/initialized, need no answer.
+		log.Debug("MCP notification:", req.Method)
+		return nil
+	}
+
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+		version := protocolVersions[0]
+		if slices.Contains(protocolVersions, params.ProtocolVersion) {
+			version = params.ProtocolVersion
+		}
+		return resultResponse(req.ID, map[string]interface{}{
+			"protocolVersion": version,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{"listChanged": false}},
+			"serverInfo":      map[string]interface{}{"name": localServerName, "version": s.version},
+			"instructions":    "JFrog Platform tools, run by the JFrog CLI with the credentials of its configured server. Search the API catalog with api_docs_search and api_docs_describe before calling an endpoint with api_request.",
+		})
+	case "ping":
+		return resultResponse(req.ID, map[string]interface{}{})
+	case "tools/list":
+		return resultResponse(req.ID, map[string]interface{}{"tools": s.tools})
+	case "tools/call":
+		var params struct {
+			Name      string                 `json:"name"`
+			Arguments map[string]interface{} `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return errorResponse(req.ID, rpcInvalidParams, "invalid tools/call params: "+err.Error())
+		}
+		idx := slices.IndexFunc(s.tools, func(t Tool) bool { return t.Name == params.Name })
+		if idx < 0 {
+			return errorResponse(req.ID, rpcInvalidParams, fmt.Sprintf("unknown tool '%s'", params.Name))
+		}
+		return resultResponse(req.ID, s.tools[idx].call(ctx, params.Arguments))
+	default:
+		return errorResponse(req.ID, rpcMethodNotFound, fmt.Sprintf("method '%s' not found", req.Method))
+	}
+}
+
+func resultResponse(id json.RawMessage, result interface{}) *rpcResponse {
+	return &rpcResponse{JSONRPC: jsonRPCVersion, ID: id, Result: result}
+}
+
+func errorResponse(id json.RawMessage, code int, message string) *rpcResponse {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &rpcResponse{JSONRPC: jsonRPCVersion, ID: id, Error: &rpcError{Code: code, Message: message}}
+}
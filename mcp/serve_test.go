@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func echoTool() Tool {
+	return Tool{
+		Name:        "echo",
+		Description: "Echo the text argument.",
+		InputSchema: objectSchema([]string{"text"}, map[string]interface{}{"text": property("string", "Text.")}),
+		run: func(_ context.Context, args toolArgs) (string, error) {
+			text, err := args.string("text", true)
+			if text == "fail" {
+				return "", errors.New("failed as asked")
+			}
+			return text, err
+		},
+	}
+}
+
+// serve runs the server over the given request lines and returns its
+// responses, decoded.
+func serve(t *testing.T, lines ...string) []map[string]interface{} {
+	t.Helper()
+	var out strings.Builder
+	server := NewServer("1.2.3", []Tool{echoTool()})
+	require.NoError(t, server.Serve(context.Background(), strings.NewReader(strings.Join(lines, "\n")), &out))
+
+	var responses []map[string]interface{}
+	scanner := bufio.NewScanner(strings.NewReader(out.String()))
+	for scanner.Scan() {
+		resp := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &resp), scanner.Text())
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+func TestServe_Initialize(t *testing.T) {
+	responses := serve(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":"two","method":"initialize","params":{"protocolVersion":"1999-01-01"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"ping"}`,
+	)
+	require.Len(t, responses, 3, "notifications are not answered")
+
+	result := responses[0]["result"].(map[string]interface{})
+	assert.Equal(t, float64(1), responses[0]["id"])
+	assert.Equal(t, "2025-03-26", result["protocolVersion"])
+	assert.Equal(t, map[string]interface{}{"name": localServerName, "version": "1.2.3"}, result["serverInfo"])
+	assert.Contains(t, result["capabilities"], "tools")
+
+	assert.Equal(t, "two", responses[1]["id"])
+	assert.Equal(t, protocolVersions[0], responses[1]["result"].(map[string]interface{})["protocolVersion"])
+
+	assert.Equal(t, map[string]interface{}{}, responses[2]["result"])
+}
+
+func TestServe_Tools(t *testing.T) {
+	responses := serve(t,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo","arguments":{"text":"hello"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"echo","arguments":{"text":"fail"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"echo","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"nope"}}`,
+	)
+	require.Len(t, responses, 5)
+
+	tools := responses[0]["result"].(map[string]interface{})["tools"].([]interface{})
+	require.Len(t, tools, 1)
+	assert.Equal(t, "echo", tools[0].(map[string]interface{})["name"])
+	assert.Equal(t, "object", tools[0].(map[string]interface{})["inputSchema"].(map[string]interface{})["type"])
+
+	assert.Equal(t, map[string]interface{}{"content": []interface{}{map[string]interface{}{"type": "text", "text": "hello"}}}, responses[1]["result"])
+
+	failed := responses[2]["result"].(map[string]interface{})
+	assert.Equal(t, true, failed["isError"])
+	assert.Equal(t, "failed as asked", failed["content"].([]interface{})[0].(map[string]interface{})["text"])
+
+	missing := responses[3]["result"].(map[string]interface{})
+	assert.Equal(t, true, missing["isError"])
+	assert.Contains(t, missing["content"].([]interface{})[0].(map[string]interface{})["text"], "missing required argument 'text'")
+
+	assert.Equal(t, float64(rpcInvalidParams), responses[4]["error"].(map[string]interface{})["code"])
+}
+
+func TestServe_ProtocolErrors(t *testing.T) {
+	responses := serve(t,
+		`{not json`,
+		`{"jsonrpc":"1.0","id":1,"method":"ping"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"resources/list"}`,
+	)
+	require.Len(t, responses, 3)
+	assert.Nil(t, responses[0]["id"])
+	assert.Equal(t, float64(rpcParseError), responses[0]["error"].(map[string]interface{})["code"])
+	assert.Equal(t, float64(rpcInvalidRequest), responses[1]["error"].(map[string]interface{})["code"])
+	assert.Equal(t, float64(rpcMethodNotFound), responses[2]["error"].(map[string]interface{})["code"])
+}
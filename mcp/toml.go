@@ -0,0 +1,147 @@
+package mcp
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Like JSON configs, TOML configs are edited in place: the server entry is
+// written as its own [<key>.<name>] table, replacing that table (and its
+// sub-tables) if present, so the rest of the file keeps its comments and
+// layout.
+
+var bareTomlKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func tomlKey(key string) string {
+	if bareTomlKey.MatchString(key) {
+		return key
+	}
+	return strconv.Quote(key)
+}
+
+// tomlHeaderKey returns the dotted key of a [table] header line, with the
+// whitespace around its parts removed, or false if line isn't a table header.
+func tomlHeaderKey(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "[") || strings.HasPrefix(line, "[[") {
+		return "", strings.HasPrefix(line, "[[")
+	}
+	end := strings.Index(line, "]")
+	if end < 0 {
+		return "", false
+	}
+	parts := strings.Split(line[1:end], ".")
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+		if unquoted, err := strconv.Unquote(parts[i]); err == nil && bareTomlKey.MatchString(unquoted) {
+			parts[i] = unquoted
+		}
+	}
+	return strings.Join(parts, "."), true
+}
+
+// tomlTable returns the line range of the [key.name] table and its
+// sub-tables, excluding trailing blank and comment lines, which are taken to
+// belong to the table that follows.
+func tomlTable(lines []string, key, name string) (start, end int, ok bool) {
+	header := tomlKey(key) + "." + tomlKey(name)
+	start = -1
+	for i, line := range lines {
+		dotted, isHeader := tomlHeaderKey(line)
+		if !isHeader {
+			continue
+		}
+		if start < 0 {
+			if dotted == header {
+				start = i
+			}
+			continue
+		}
+		if dotted != header && !strings.HasPrefix(dotted, header+".") {
+			end = i
+			break
+		}
+	}
+	if start < 0 {
+		return 0, 0, false
+	}
+	if end == 0 {
+		end = len(lines)
+	}
+	for end > start+1 {
+		trimmed := strings.TrimSpace(lines[end-1])
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			break
+		}
+		end--
+	}
+	return start, end, true
+}
+
+func renderTomlTable(key, name string, entry map[string]interface{}) ([]string, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(entry); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	body := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	return append([]string{"[" + tomlKey(key) + "." + tomlKey(name) + "]"}, body...), nil
+}
+
+// setTomlTable sets data's [key.name] table to entry. It reports false when
+// the entry is defined some other way (inline, or with dotted keys), which
+// can't be edited in place.
+func setTomlTable(data []byte, key, name string, entry map[string]interface{}) ([]byte, bool, error) {
+	table, err := renderTomlTable(key, name, entry)
+	if err != nil {
+		return nil, false, err
+	}
+	lines := strings.Split(string(data), "\n")
+	if start, end, ok := tomlTable(lines, key, name); ok {
+		lines = append(lines[:start], append(table, lines[end:]...)...)
+	} else {
+		for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+			lines = lines[:len(lines)-1]
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, table...)
+		lines = append(lines, "")
+	}
+	out := []byte(strings.Join(lines, "\n"))
+	return out, tomlHasEntry(out, key, name), nil
+}
+
+// removeTomlTable removes data's [key.name] table, reporting false if there's
+// no such table.
+func removeTomlTable(data []byte, key, name string) ([]byte, bool) {
+	lines := strings.Split(string(data), "\n")
+	start, end, ok := tomlTable(lines, key, name)
+	if !ok {
+		return data, false
+	}
+	// Drop the blank line separating the table from the one before.
+	if start > 0 && strings.TrimSpace(lines[start-1]) == "" && (end == len(lines) || strings.TrimSpace(lines[end]) == "") {
+		start--
+	}
+	lines = append(lines[:start], lines[end:]...)
+	return []byte(strings.Join(lines, "\n")), true
+}
+
+func tomlHasEntry(data []byte, key, name string) bool {
+	root := map[string]interface{}{}
+	if _, err := toml.Decode(string(data), &root); err != nil {
+		return false
+	}
+	servers, ok := root[key].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, ok = servers[name]
+	return ok
+}
@@ -0,0 +1,77 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const codexConfig = `# Codex settings
+model = "o3"
+
+[mcp_servers.jfrog]
+url = "https://old.example/mcp"
+
+[mcp_servers.jfrog.env]
+A = "1"
+
+# Local tools
+[mcp_servers.other]
+command = "npx"
+`
+
+func TestSetTomlTable(t *testing.T) {
+	entry := map[string]interface{}{"url": "https://platform.example/mcp"}
+
+	t.Run("replaces the table and its sub-tables", func(t *testing.T) {
+		got, ok, err := setTomlTable([]byte(codexConfig), "mcp_servers", "jfrog", entry)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, `# Codex settings
+model = "o3"
+
+[mcp_servers.jfrog]
+url = "https://platform.example/mcp"
+
+# Local tools
+[mcp_servers.other]
+command = "npx"
+`, string(got))
+	})
+
+	t.Run("appends a new table", func(t *testing.T) {
+		got, ok, err := setTomlTable([]byte("model = \"o3\"\n\n"), "mcp_servers", "my server", entry)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "model = \"o3\"\n\n[mcp_servers.\"my server\"]\nurl = \"https://platform.example/mcp\"\n", string(got))
+	})
+
+	t.Run("inline entry can't be edited in place", func(t *testing.T) {
+		_, ok, err := setTomlTable([]byte("[mcp_servers]\njfrog = { url = \"https://old.example\" }\n"), "mcp_servers", "jfrog", entry)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestRemoveTomlTable(t *testing.T) {
+	got, ok := removeTomlTable([]byte(codexConfig), "mcp_servers", "jfrog")
+	assert.True(t, ok)
+	assert.Equal(t, `# Codex settings
+model = "o3"
+
+# Local tools
+[mcp_servers.other]
+command = "npx"
+`, string(got))
+
+	_, ok = removeTomlTable([]byte(codexConfig), "mcp_servers", "missing")
+	assert.False(t, ok)
+}
+
+func TestSetServerEntry_TomlInlineFallsBackToReencoding(t *testing.T) {
+	got, err := setServerEntry([]byte("[mcp_servers]\njfrog = { url = \"https://old.example\" }\n"), formatTOML, "mcp_servers", "jfrog", map[string]interface{}{"url": "https://platform.example/mcp"})
+	require.NoError(t, err)
+	assert.True(t, tomlHasEntry(got, "mcp_servers", "jfrog"))
+	assert.Contains(t, string(got), "https://platform.example/mcp")
+}
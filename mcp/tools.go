@@ -0,0 +1,257 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	coreconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-cli/general/api"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Tool is a tool of the local MCP server: its name, description and JSON
+// schema of its arguments, as listed to the agent, and the function running
+// it.
+type Tool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+	run         func(ctx context.Context, args toolArgs) (string, error)
+}
+
+func (t Tool) call(ctx context.Context, args map[string]interface{}) toolResult {
+	text, err := t.run(ctx, args)
+	if err != nil {
+		return toolResult{Content: []toolContent{{Type: "text", Text: err.Error()}}, IsError: true}
+	}
+	return toolResult{Content: []toolContent{{Type: "text", Text: text}}}
+}
+
+// LocalTools returns the tools of the local MCP server. Those calling the
+// platform use serverDetails' credentials.
+func LocalTools(serverDetails *coreconfig.ServerDetails) []Tool {
+	return []Tool{
+		apiRequestTool(serverDetails),
+		apiDocsSearchTool(),
+		apiDocsDescribeTool(),
+		rtSearchTool(serverDetails),
+		buildInfoTool(serverDetails),
+	}
+}
+
+func apiRequestTool(serverDetails *coreconfig.ServerDetails) Tool {
+	return Tool{
+		Name:        "api_request",
+		Description: "Call a JFrog Platform REST API endpoint, as 'jf api' does, and return the response body. Find the endpoint with api_docs_search first.",
+		InputSchema: objectSchema([]string{"path"}, map[string]interface{}{
+			"method": enumProperty("HTTP method. Defaults to GET.", http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead),
+			"path":   property("string", "Endpoint path relative to the platform URL, with its query string, e.g. /access/api/v2/users?limit=10."),
+			"body":   map[string]interface{}{"description": "Request body, sent as JSON: an object, an array, or a JSON string."},
+		}),
+		run: func(ctx context.Context, args toolArgs) (string, error) {
+			method, err := args.string("method", false)
+			if err != nil {
+				return "", err
+			}
+			if method = strings.ToUpper(method); method == "" {
+				method = http.MethodGet
+			}
+			path, err := args.string("path", true)
+			if err != nil {
+				return "", err
+			}
+			body, err := args.body("body")
+			if err != nil {
+				return "", err
+			}
+			return platformRequest(ctx, serverDetails, method, path, body)
+		},
+	}
+}
+
+func apiDocsSearchTool() Tool {
+	return Tool{
+		Name:        "api_docs_search",
+		Description: "Search the JFrog Platform REST API catalog embedded in the JFrog CLI, as 'jf api docs search' does. Returns the best matching operations with their parameters, request body schema and a ready 'jf api' command line.",
+		InputSchema: objectSchema([]string{"query"}, map[string]interface{}{
+			"query":  property("string", "Words describing the operation, e.g. 'create user' or 'repository permissions'."),
+			"tag":    property("string", "Only search operations with this tag, e.g. 'Users'."),
+			"method": property("string", "Only search operations with this HTTP method."),
+			"limit":  property("integer", "Maximum number of operations returned. Defaults to 10."),
+		}),
+		run: func(_ context.Context, args toolArgs) (string, error) {
+			query, err := args.string("query", true)
+			if err != nil {
+				return "", err
+			}
+			tag, err := args.string("tag", false)
+			if err != nil {
+				return "", err
+			}
+			method, err := args.string("method", false)
+			if err != nil {
+				return "", err
+			}
+			limit, err := args.int("limit")
+			if err != nil {
+				return "", err
+			}
+			data, err := api.SearchDocs(query, tag, method, limit)
+			return string(data), err
+		},
+	}
+}
+
+func apiDocsDescribeTool() Tool {
+	return Tool{
+		Name:        "api_docs_describe",
+		Description: "Describe a JFrog Platform REST API operation of the embedded catalog, as 'jf api docs describe' does: its parameters, request body and responses.",
+		InputSchema: objectSchema([]string{"method", "path"}, map[string]interface{}{
+			"method": property("string", "HTTP method of the operation."),
+			"path":   property("string", "Path of the operation, with its {placeholders}, as returned by api_docs_search."),
+		}),
+		run: func(_ context.Context, args toolArgs) (string, error) {
+			method, err := args.string("method", true)
+			if err != nil {
+				return "", err
+			}
+			path, err := args.string("path", true)
+			if err != nil {
+				return "", err
+			}
+			data, err := api.DescribeOperation(method, path)
+			return string(data), err
+		},
+	}
+}
+
+func buildInfoTool(serverDetails *coreconfig.ServerDetails) Tool {
+	return Tool{
+		Name:        "build_info",
+		Description: "Get a build-info published to Artifactory. Without a build number, list the build's runs instead.",
+		InputSchema: objectSchema([]string{"name"}, map[string]interface{}{
+			"name":    property("string", "Build name."),
+			"number":  property("string", "Build number."),
+			"project": property("string", "JFrog project key the build belongs to."),
+		}),
+		run: func(ctx context.Context, args toolArgs) (string, error) {
+			name, err := args.string("name", true)
+			if err != nil {
+				return "", err
+			}
+			number, err := args.string("number", false)
+			if err != nil {
+				return "", err
+			}
+			project, err := args.string("project", false)
+			if err != nil {
+				return "", err
+			}
+			path := "/artifactory/api/build/" + url.PathEscape(name)
+			if number != "" {
+				path += "/" + url.PathEscape(number)
+			}
+			if project != "" {
+				path += "?" + url.Values{"project": {project}}.Encode()
+			}
+			return platformRequest(ctx, serverDetails, http.MethodGet, path, nil)
+		},
+	}
+}
+
+// platformRequest sends a request to the platform, failing on an error
+// status with the response body as the error.
+func platformRequest(ctx context.Context, serverDetails *coreconfig.ServerDetails, method, path string, body []byte) (string, error) {
+	status, respBody, err := api.Request(ctx, serverDetails, method, path, body)
+	if err != nil {
+		return "", err
+	}
+	if status < 200 || status > 399 {
+		return "", errorutils.CheckErrorf("%s %s returned HTTP %d: %s", method, path, status, strings.TrimSpace(string(respBody)))
+	}
+	if len(respBody) == 0 {
+		return fmt.Sprintf("HTTP %d", status), nil
+	}
+	return string(respBody), nil
+}
+
+func objectSchema(required []string, properties map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"type": "object", "properties": properties, "required": required}
+}
+
+func property(typ, description string) map[string]interface{} {
+	return map[string]interface{}{"type": typ, "description": description}
+}
+
+func enumProperty(description string, values ...string) map[string]interface{} {
+	return map[string]interface{}{"type": "string", "description": description, "enum": values}
+}
+
+// toolArgs are the arguments of a tools/call request.
+type toolArgs map[string]interface{}
+
+func (a toolArgs) string(name string, required bool) (string, error) {
+	value, ok := a[name]
+	if !ok || value == nil {
+		if required {
+			return "", errorutils.CheckErrorf("missing required argument '%s'", name)
+		}
+		return "", nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", errorutils.CheckErrorf("argument '%s' must be a string", name)
+	}
+	s = strings.TrimSpace(s)
+	if s == "" && required {
+		return "", errorutils.CheckErrorf("missing required argument '%s'", name)
+	}
+	return s, nil
+}
+
+// int returns an optional integer argument, or 0.
+func (a toolArgs) int(name string) (int, error) {
+	value, ok := a[name]
+	if !ok || value == nil {
+		return 0, nil
+	}
+	n, ok := value.(float64)
+	if !ok || n != float64(int(n)) {
+		return 0, errorutils.CheckErrorf("argument '%s' must be an integer", name)
+	}
+	return int(n), nil
+}
+
+// bool returns an optional boolean argument, or def.
+func (a toolArgs) bool(name string, def bool) (bool, error) {
+	value, ok := a[name]
+	if !ok || value == nil {
+		return def, nil
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return false, errorutils.CheckErrorf("argument '%s' must be a boolean", name)
+	}
+	return b, nil
+}
+
+// body returns an optional request body argument as JSON: a string is taken
+// to be JSON already.
+func (a toolArgs) body(name string) ([]byte, error) {
+	value, ok := a[name]
+	if !ok || value == nil {
+		return nil, nil
+	}
+	if s, ok := value.(string); ok {
+		if !json.Valid([]byte(s)) {
+			return nil, errorutils.CheckErrorf("argument '%s' must be valid JSON", name)
+		}
+		return []byte(s), nil
+	}
+	data, err := json.Marshal(value)
+	return data, errorutils.CheckError(err)
+}
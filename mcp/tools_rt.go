@@ -0,0 +1,72 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jfrog/jfrog-cli-artifactory/artifactory/commands/generic"
+	"github.com/jfrog/jfrog-cli-core/v2/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/v2/common/spec"
+	coreconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// defaultRtSearchLimit bounds the artifacts rt_search returns, to keep its
+// result within an agent's context.
+const defaultRtSearchLimit = 100
+
+func rtSearchTool(serverDetails *coreconfig.ServerDetails) Tool {
+	return Tool{
+		Name:        "rt_search",
+		Description: "Search Artifactory for artifacts matching a wildcard pattern, as 'jf rt search' does. Returns each artifact's path, type, size, dates, checksums and properties.",
+		InputSchema: objectSchema([]string{"pattern"}, map[string]interface{}{
+			"pattern":   property("string", "Wildcard pattern of the artifacts, starting with the repository, e.g. 'libs-release-local/org/acme/*.jar'."),
+			"recursive": property("boolean", "Search the pattern's sub-directories too. Defaults to true."),
+			"limit":     property("integer", "Maximum number of artifacts returned. Defaults to 100."),
+		}),
+		run: func(_ context.Context, args toolArgs) (string, error) {
+			pattern, err := args.string("pattern", true)
+			if err != nil {
+				return "", err
+			}
+			recursive, err := args.bool("recursive", true)
+			if err != nil {
+				return "", err
+			}
+			limit, err := args.int("limit")
+			if err != nil {
+				return "", err
+			}
+			if limit <= 0 {
+				limit = defaultRtSearchLimit
+			}
+			return rtSearch(serverDetails, spec.NewBuilder().Pattern(pattern).Recursive(recursive).Limit(limit).BuildSpec())
+		},
+	}
+}
+
+func rtSearch(serverDetails *coreconfig.ServerDetails, searchSpec *spec.SpecFiles) (result string, err error) {
+	searchCmd := generic.NewSearchCommand()
+	searchCmd.SetServerDetails(serverDetails).SetSpec(searchSpec)
+	reader, err := searchCmd.Search()
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if closeErr := reader.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	items := []utils.SearchResult{}
+	for item := new(utils.SearchResult); reader.NextRecord(item) == nil; item = new(utils.SearchResult) {
+		items = append(items, *item)
+	}
+	if err = reader.GetError(); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return string(data), nil
+}
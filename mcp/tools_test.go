@@ -0,0 +1,124 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	coreconfig "github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findTool(t *testing.T, tools []Tool, name string) Tool {
+	t.Helper()
+	for _, tool := range tools {
+		if tool.Name == name {
+			return tool
+		}
+	}
+	require.Failf(t, "tool not found", name)
+	return Tool{}
+}
+
+func resultText(t *testing.T, result toolResult) string {
+	t.Helper()
+	require.Len(t, result.Content, 1)
+	return result.Content[0].Text
+}
+
+func TestLocalTools(t *testing.T) {
+	var names []string
+	for _, tool := range LocalTools(&coreconfig.ServerDetails{}) {
+		names = append(names, tool.Name)
+		assert.NotEmpty(t, tool.Description, tool.Name)
+		assert.Equal(t, "object", tool.InputSchema["type"], tool.Name)
+	}
+	assert.Equal(t, []string{"api_request", "api_docs_search", "api_docs_describe", "rt_search", "build_info"}, names)
+}
+
+func TestApiRequestTool(t *testing.T) {
+	var gotMethod, gotPath, gotContentType, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath, gotContentType = r.Method, r.URL.RequestURI(), r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[{"message":"not found"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+	tool := findTool(t, LocalTools(&coreconfig.ServerDetails{Url: srv.URL + "/"}), "api_request")
+
+	result := tool.call(context.Background(), map[string]interface{}{
+		"method": "post",
+		"path":   "access/api/v2/users?limit=1",
+		"body":   map[string]interface{}{"username": "ann"},
+	})
+	assert.False(t, result.IsError, resultText(t, result))
+	assert.Equal(t, `{"ok":true}`, resultText(t, result))
+	assert.Equal(t, http.MethodPost, gotMethod)
+	assert.Equal(t, "/access/api/v2/users?limit=1", gotPath)
+	assert.Equal(t, "application/json", gotContentType)
+	assert.JSONEq(t, `{"username":"ann"}`, gotBody)
+
+	result = tool.call(context.Background(), map[string]interface{}{"path": "/missing"})
+	assert.True(t, result.IsError)
+	assert.Equal(t, http.MethodGet, gotMethod)
+	assert.Contains(t, resultText(t, result), "HTTP 404")
+	assert.Contains(t, resultText(t, result), "not found")
+
+	result = tool.call(context.Background(), map[string]interface{}{"path": "/x", "body": "{not json"})
+	assert.True(t, result.IsError)
+}
+
+func TestBuildInfoTool(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.RequestURI()
+		_, _ = w.Write([]byte(`{"buildInfo":{}}`))
+	}))
+	defer srv.Close()
+	tool := findTool(t, LocalTools(&coreconfig.ServerDetails{Url: srv.URL}), "build_info")
+
+	result := tool.call(context.Background(), map[string]interface{}{"name": "my build", "number": "7", "project": "acme"})
+	assert.False(t, result.IsError, resultText(t, result))
+	assert.Equal(t, "/artifactory/api/build/my%20build/7?project=acme", gotPath)
+
+	result = tool.call(context.Background(), map[string]interface{}{"name": "app"})
+	assert.False(t, result.IsError, resultText(t, result))
+	assert.Equal(t, "/artifactory/api/build/app", gotPath)
+}
+
+func TestApiDocsTools(t *testing.T) {
+	tools := LocalTools(&coreconfig.ServerDetails{})
+
+	result := findTool(t, tools, "api_docs_search").call(context.Background(), map[string]interface{}{"query": "users", "limit": float64(2)})
+	require.False(t, result.IsError, resultText(t, result))
+	var search struct {
+		Matches []struct {
+			Method string `json:"method"`
+			Path   string `json:"path"`
+		} `json:"matches"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &search))
+	require.NotEmpty(t, search.Matches)
+	assert.LessOrEqual(t, len(search.Matches), 2)
+
+	describe := findTool(t, tools, "api_docs_describe")
+	result = describe.call(context.Background(), map[string]interface{}{"method": search.Matches[0].Method, "path": search.Matches[0].Path})
+	require.False(t, result.IsError, resultText(t, result))
+	assert.Contains(t, resultText(t, result), search.Matches[0].Path)
+
+	result = describe.call(context.Background(), map[string]interface{}{"method": "GET", "path": "/no/such/path"})
+	assert.True(t, result.IsError)
+
+	result = findTool(t, tools, "api_docs_search").call(context.Background(), map[string]interface{}{"query": "users", "limit": "two"})
+	assert.True(t, result.IsError)
+}
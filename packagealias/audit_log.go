@@ -0,0 +1,368 @@
+package packagealias
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	auditLogFileName = "audit.log"
+	// auditLogMaxSize is the size past which the audit log is rotated
+	auditLogMaxSize = 10 << 20
+	// auditLogMaxBackups is the number of rotated files kept, as audit.log.1
+	// (the newest) to audit.log.<auditLogMaxBackups>
+	auditLogMaxBackups = 3
+	// auditLogLockFileName guards the rotation of the audit log, not its writes
+	auditLogLockFileName = ".audit.lock"
+	// auditLogStaleLockAge is the age past which a rotation lock is
+	// considered left behind by a crashed process
+	auditLogStaleLockAge = time.Minute
+
+	redactedValue = "****"
+)
+
+// How an intercepted invocation ran.
+const (
+	ranJF     = "jf"
+	ranNative = "native"
+)
+
+// Why an intercepted invocation ran the way it did.
+const (
+	// JFROG_CLI_GHOST_FROG=audit runs every tool natively
+	reasonAudit = "audit"
+	// Package aliasing is disabled, globally or for the project
+	reasonDisabled = "disabled"
+	// A tool_modes or subcommand_modes policy decided the mode
+	reasonPolicy = "policy"
+	// No policy applies, so the tool's default mode did
+	reasonDefault = "default"
+)
+
+// AuditLogEntry records one invocation of an aliased tool, as a line of the
+// audit log.
+type AuditLogEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Tool      string    `json:"tool"`
+	// Argv holds the tool's arguments, with secrets redacted
+	Argv []string `json:"argv"`
+	// Mode is the mode the config decides for the invocation
+	Mode AliasMode `json:"mode"`
+	// Ran is how the invocation actually ran: through jf, or natively
+	Ran    string `json:"ran"`
+	Reason string `json:"reason"`
+	// Policy is the policy that decided the mode, for reasonPolicy
+	Policy string `json:"policy,omitempty"`
+	// Source is the config file that decided the mode, or enabled the tool
+	Source string `json:"source,omitempty"`
+	Cwd    string `json:"cwd"`
+	// ExitCode and DurationMs are unknown, and omitted, when the native tool
+	// replaced the jf process, as it does outside Windows.
+	ExitCode   *int   `json:"exit_code,omitempty"`
+	DurationMs *int64 `json:"duration_ms,omitempty"`
+}
+
+// pendingAuditLogEntry is the entry of the current dispatch, written once its
+// exit code is known.
+var pendingAuditLogEntry *AuditLogEntry
+
+// startAuditLogEntry records the decision of the current dispatch. The entry
+// is written by RecordDispatchResult.
+func startAuditLogEntry(tool string, args []string, decision dispatchDecision, ran, reason string) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		log.Debug(fmt.Sprintf("%s Failed to get the working directory for the audit log: %v", ghostFrogLogPrefix, err))
+	}
+	entry := &AuditLogEntry{
+		Timestamp: time.Now(),
+		Tool:      tool,
+		Argv:      redactArgs(tool, args),
+		Mode:      decision.mode,
+		Ran:       ran,
+		Reason:    reason,
+		Cwd:       cwd,
+	}
+	switch reason {
+	case reasonDisabled:
+		entry.Source = decision.enabledSource
+	case reasonPolicy:
+		entry.Policy = decision.policy
+		entry.Source = decision.source
+	}
+	pendingAuditLogEntry = entry
+}
+
+// RecordDispatchResult appends the entry of the current dispatch to the audit
+// log, with the exit code the invocation ended with. It does nothing when the
+// process wasn't invoked through an alias, or the entry was already written.
+// Failing to write the log never fails the invocation.
+func RecordDispatchResult(exitCode int) {
+	writePendingAuditLogEntry(&exitCode)
+}
+
+// recordDispatchBeforeExec appends the entry of the current dispatch to the
+// audit log, before the native tool replaces the jf process.
+func recordDispatchBeforeExec() {
+	writePendingAuditLogEntry(nil)
+}
+
+func writePendingAuditLogEntry(exitCode *int) {
+	entry := pendingAuditLogEntry
+	if entry == nil {
+		return
+	}
+	pendingAuditLogEntry = nil
+	if exitCode != nil {
+		durationMs := time.Since(entry.Timestamp).Milliseconds()
+		entry.ExitCode = exitCode
+		entry.DurationMs = &durationMs
+	}
+
+	aliasDir, err := GetAliasHomeDir()
+	if err == nil {
+		err = appendAuditLogEntry(aliasDir, entry)
+	}
+	if err != nil {
+		log.Warn(fmt.Sprintf("%s Failed to write the audit log, the invocation of '%s' is not recorded: %v", ghostFrogLogPrefix, entry.Tool, err))
+	}
+}
+
+func getAuditLogPath(aliasDir string) string {
+	return filepath.Join(aliasDir, auditLogFileName)
+}
+
+// appendAuditLogEntry appends an entry to the audit log, rotating it first if
+// the entry would take it past auditLogMaxSize. The entry is a single write to
+// a file opened with O_APPEND, so concurrent invocations don't interleave
+// their entries, and don't need to wait for a lock.
+func appendAuditLogEntry(aliasDir string, entry *AuditLogEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	line = append(line, '\n')
+	logPath := getAuditLogPath(aliasDir)
+	if info, statErr := os.Stat(logPath); statErr == nil && info.Size()+int64(len(line)) > auditLogMaxSize {
+		tryRotateAuditLog(aliasDir, logPath, int64(len(line)))
+	}
+	// #nosec G304 -- logPath is under the package-alias home directory
+	logFile, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	_, err = logFile.Write(line)
+	return errorutils.CheckError(errors.Join(err, logFile.Close()))
+}
+
+// tryRotateAuditLog rotates the audit log, unless another invocation holds
+// the rotation lock. It never waits for the lock: the entry then goes to the
+// current log, which may briefly exceed auditLogMaxSize.
+func tryRotateAuditLog(aliasDir, logPath string, lineSize int64) {
+	lockPath := filepath.Join(aliasDir, auditLogLockFileName)
+	lockFile, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > auditLogStaleLockAge {
+			log.Debug(fmt.Sprintf("%s Removing stale audit log lock: %s", ghostFrogLogPrefix, lockPath))
+			_ = os.Remove(lockPath)
+		}
+		return
+	}
+	_ = lockFile.Close()
+	defer func() {
+		_ = os.Remove(lockPath)
+	}()
+
+	// Another invocation may have rotated the log since it was checked.
+	if info, statErr := os.Stat(logPath); statErr != nil || info.Size()+lineSize <= auditLogMaxSize {
+		return
+	}
+	if err = rotateAuditLog(logPath); err != nil {
+		log.Warn(fmt.Sprintf("%s Failed to rotate the audit log: %v", ghostFrogLogPrefix, err))
+	}
+}
+
+// rotateAuditLog shifts audit.log to audit.log.1, audit.log.1 to audit.log.2
+// and so on, dropping the oldest file.
+func rotateAuditLog(logPath string) error {
+	for index := auditLogMaxBackups - 1; index >= 0; index-- {
+		source := logPath
+		if index > 0 {
+			source = fmt.Sprintf("%s.%d", logPath, index)
+		}
+		if err := os.Rename(source, fmt.Sprintf("%s.%d", logPath, index+1)); err != nil && !os.IsNotExist(err) {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
+
+// readAuditLog returns the entries of the audit log and its rotated files,
+// the oldest first. Lines that aren't valid entries are skipped.
+func readAuditLog(aliasDir string) ([]AuditLogEntry, error) {
+	logPath := getAuditLogPath(aliasDir)
+	var entries []AuditLogEntry
+	for index := auditLogMaxBackups; index >= 0; index-- {
+		path := logPath
+		if index > 0 {
+			path = fmt.Sprintf("%s.%d", logPath, index)
+		}
+		fileEntries, err := readAuditLogFile(path)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fileEntries...)
+	}
+	return entries, nil
+}
+
+func readAuditLogFile(path string) ([]AuditLogEntry, error) {
+	// #nosec G304 -- path is under the package-alias home directory
+	logFile, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = logFile.Close()
+	}()
+
+	var entries []AuditLogEntry
+	scanner := bufio.NewScanner(logFile)
+	scanner.Buffer(make([]byte, 0, 64*1024), auditLogMaxSize)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		var entry AuditLogEntry
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			log.Debug(fmt.Sprintf("Skipping invalid audit log entry at %s:%d: %v", path, lineNumber, err))
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, errorutils.CheckError(scanner.Err())
+}
+
+// sensitiveArgNames are the parts of flag, property and variable names whose
+// values are redacted from the audit log.
+var sensitiveArgNames = []string{"password", "passwd", "token", "secret", "apikey", "api-key", "api_key",
+	"access-key", "access_key", "private-key", "private_key", "credential", "_auth"}
+
+// shortPasswordFlag is the short flag a tool takes a password with, in the
+// given subcommands, or in all of them when there are none. Other
+// subcommands may use it for something else, e.g. docker run -p.
+type shortPasswordFlag struct {
+	flag        string
+	subcommands []string
+}
+
+var shortPasswordFlags = map[string]shortPasswordFlag{
+	"docker": {flag: "-p", subcommands: []string{"login"}},
+	"helm":   {flag: "-p", subcommands: []string{"login"}},
+	"conan":  {flag: "-p", subcommands: []string{"login", "user"}},
+	"twine":  {flag: "-p"},
+}
+
+// userFlags take a user name, whose password may follow as user:password.
+var userFlags = []string{"-u", "--user", "--username"}
+
+// urlCredentialsPattern matches the password of a URL's user info.
+var urlCredentialsPattern = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://[^/\s:@]+):[^/\s@]+@`)
+
+// redactArgs returns a copy of the arguments of tool with secrets redacted:
+//   - the values of flags, Java properties and variable assignments with
+//     sensitive names, e.g. --password x, -Dtoken=x or --build-arg NPM_TOKEN=x;
+//   - the values of the tool's short password flag, e.g. docker login -p x;
+//   - the passwords of user flags, e.g. -u me:x, and of URLs.
+func redactArgs(tool string, args []string) []string {
+	passwordFlag := getShortPasswordFlag(tool, args)
+	redacted := make([]string, len(args))
+	var redactNext func(value string) string
+	for index, arg := range args {
+		if redactNext != nil && !isFlagArg(arg) {
+			redacted[index] = redactNext(arg)
+			redactNext = nil
+			continue
+		}
+		redactNext = nil
+		name, value, hasValue := strings.Cut(arg, "=")
+		switch {
+		case hasValue && isSensitiveArgName(name):
+			redacted[index] = name + "=" + redactedValue
+		case passwordFlag != "" && arg == passwordFlag:
+			redacted[index] = arg
+			redactNext = redactValue
+		case passwordFlag != "" && strings.HasPrefix(arg, passwordFlag):
+			// -p=x or -px
+			separator := ""
+			if hasValue && name == passwordFlag {
+				separator = "="
+			}
+			redacted[index] = passwordFlag + separator + redactedValue
+		case slices.Contains(userFlags, arg):
+			redacted[index] = arg
+			redactNext = redactUserPassword
+		case hasValue && slices.Contains(userFlags, name):
+			redacted[index] = name + "=" + redactUserPassword(value)
+		case strings.HasPrefix(arg, "-") && isSensitiveArgName(arg):
+			redacted[index] = arg
+			// Flags such as docker login --password-stdin take no value.
+			if !strings.HasSuffix(strings.ToLower(arg), "-stdin") {
+				redactNext = redactValue
+			}
+		default:
+			redacted[index] = urlCredentialsPattern.ReplaceAllString(arg, "${1}:"+redactedValue+"@")
+		}
+	}
+	return redacted
+}
+
+// getShortPasswordFlag returns the short password flag of tool in an
+// invocation with args, or "" if it has none.
+func getShortPasswordFlag(tool string, args []string) string {
+	passwordFlag, found := shortPasswordFlags[tool]
+	if !found {
+		return ""
+	}
+	if len(passwordFlag.subcommands) == 0 {
+		return passwordFlag.flag
+	}
+	for _, arg := range args {
+		if !isFlagArg(arg) && slices.Contains(passwordFlag.subcommands, strings.ToLower(arg)) {
+			return passwordFlag.flag
+		}
+	}
+	return ""
+}
+
+func redactValue(string) string {
+	return redactedValue
+}
+
+// redactUserPassword redacts the password of a user:password value.
+func redactUserPassword(value string) string {
+	if user, _, found := strings.Cut(value, ":"); found {
+		return user + ":" + redactedValue
+	}
+	return value
+}
+
+func isSensitiveArgName(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitiveName := range sensitiveArgNames {
+		if strings.Contains(name, sensitiveName) {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,8 @@
 package packagealias
 
 import (
+	"strings"
+
 	"github.com/jfrog/jfrog-cli-core/v2/common/commands"
 	corecommon "github.com/jfrog/jfrog-cli-core/v2/docs/common"
 	"github.com/jfrog/jfrog-cli/utils/cliutils"
@@ -49,6 +51,96 @@ func GetCommands() []cli.Command {
 			Action:       statusCmd,
 			BashComplete: corecommon.CreateBashCompletionFunc(),
 		},
+		{
+			Name:         "set",
+			Usage:        "Set the mode of an aliased tool or of one of its subcommands",
+			HelpName:     corecommon.CreateUsage("package-alias set", "Set the mode of an aliased tool or of one of its subcommands", []string{"package-alias set <tool[.subcommand]> <jf|pass>"}),
+			ArgsUsage:    "<tool[.subcommand]> <jf|pass>",
+			Category:     packageAliasCategory,
+			Action:       setCmd,
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+		},
+		{
+			Name:         "unset",
+			Usage:        "Remove the mode of an aliased tool or of one of its subcommands",
+			HelpName:     corecommon.CreateUsage("package-alias unset", "Remove the mode of an aliased tool or of one of its subcommands", []string{"package-alias unset <tool[.subcommand]>"}),
+			ArgsUsage:    "<tool[.subcommand]>",
+			Category:     packageAliasCategory,
+			Action:       unsetCmd,
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+		},
+		{
+			Name:         "enable",
+			Usage:        "Enable package aliasing",
+			HelpName:     corecommon.CreateUsage("package-alias enable", "Enable package aliasing", []string{}),
+			ArgsUsage:    "",
+			Category:     packageAliasCategory,
+			Action:       enableCmd,
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+		},
+		{
+			Name:         "disable",
+			Usage:        "Disable package aliasing, running aliased tools natively",
+			HelpName:     corecommon.CreateUsage("package-alias disable", "Disable package aliasing, running aliased tools natively", []string{}),
+			ArgsUsage:    "",
+			Category:     packageAliasCategory,
+			Action:       disableCmd,
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+		},
+		{
+			Name:         "add-tools",
+			Usage:        "Alias more package managers",
+			HelpName:     corecommon.CreateUsage("package-alias add-tools", "Alias more package managers", []string{"package-alias add-tools <tool>[,<tool>...]"}),
+			ArgsUsage:    "<tool>[,<tool>...]",
+			Category:     packageAliasCategory,
+			Action:       addToolsCmd,
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+		},
+		{
+			Name:         "remove-tools",
+			Usage:        "Remove the aliases of some package managers",
+			HelpName:     corecommon.CreateUsage("package-alias remove-tools", "Remove the aliases of some package managers", []string{"package-alias remove-tools <tool>[,<tool>...]"}),
+			ArgsUsage:    "<tool>[,<tool>...]",
+			Category:     packageAliasCategory,
+			Action:       removeToolsCmd,
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+		},
+		{
+			Name:      "log",
+			Usage:     "Show the audit log of the intercepted package manager invocations",
+			HelpName:  corecommon.CreateUsage("package-alias log", "Show the audit log of the intercepted package manager invocations", []string{}),
+			ArgsUsage: "",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "tool",
+					Usage: "[Optional] Only show the invocations of this package manager",
+				},
+				cli.StringFlag{
+					Name:  "since",
+					Usage: "[Optional] Only show the invocations since this time, given as a duration back from now (e.g. 24h) or an RFC 3339 timestamp",
+				},
+				cli.BoolFlag{
+					Name:  "failed",
+					Usage: "[Default: false] Only show the invocations that exited with a non-zero code. Native runs on Linux and macOS replace the jf process, so their exit code is unknown and they're never shown",
+				},
+				cli.IntFlag{
+					Name:  "limit",
+					Value: defaultLogLimit,
+					Usage: "[Default: 20] Number of most recent invocations to show, 0 for all of them. Ignored with --summary",
+				},
+				cli.BoolFlag{
+					Name:  "summary",
+					Usage: "[Default: false] Summarize the invocations of each package manager. Native runs on Linux and macOS, whose exit code is unknown, are counted apart from the failed ones",
+				},
+				cli.StringFlag{
+					Name:  "format",
+					Usage: "[Default: table] Output format: table or json",
+				},
+			},
+			Category:     packageAliasCategory,
+			Action:       logCmd,
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+		},
 	})
 }
 
@@ -66,3 +158,65 @@ func statusCmd(c *cli.Context) error {
 	statusCmd := NewStatusCommand()
 	return commands.Exec(statusCmd)
 }
+
+func setCmd(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	setCmd := NewSetModeCommand(c.Args().Get(0), AliasMode(c.Args().Get(1)))
+	return commands.Exec(setCmd)
+}
+
+func unsetCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	unsetCmd := NewUnsetModeCommand(c.Args().Get(0))
+	return commands.Exec(unsetCmd)
+}
+
+func enableCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	return commands.Exec(NewEnableCommand())
+}
+
+func disableCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	return commands.Exec(NewDisableCommand())
+}
+
+// addToolsCmd accepts the tools as a comma-separated list, as separate
+// arguments, or both.
+func addToolsCmd(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	addToolsCmd := NewAddToolsCommand(strings.Join(c.Args(), ","))
+	return commands.Exec(addToolsCmd)
+}
+
+func removeToolsCmd(c *cli.Context) error {
+	if c.NArg() == 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	removeToolsCmd := NewRemoveToolsCommand(strings.Join(c.Args(), ","))
+	return commands.Exec(removeToolsCmd)
+}
+
+func logCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.WrongNumberOfArgumentsHandler(c)
+	}
+	logCmd := NewLogCommand().
+		SetTool(c.String("tool")).
+		SetSince(c.String("since")).
+		SetFailedOnly(c.Bool("failed")).
+		SetLimit(c.Int("limit")).
+		SetSummary(c.Bool("summary")).
+		SetFormat(c.String("format"))
+	return commands.Exec(logCmd)
+}
@@ -26,9 +26,21 @@ const (
 
 // defaultPassTools lists tools that default to ModePass (run natively) when not explicitly configured
 var defaultPassTools = map[string]struct{}{
-	"pnpm":   {},
-	"gem":    {},
-	"bundle": {},
+	"pnpm":      {},
+	"gem":       {},
+	"bundle":    {},
+	"cargo":     {},
+	"conda":     {},
+	"uv":        {},
+	"terraform": {},
+	"twine":     {},
+}
+
+// nativeOnlyTools lists aliased tools that have no JFrog CLI integration yet.
+// They always run natively, even when configured to ModeJF.
+var nativeOnlyTools = map[string]struct{}{
+	"cargo": {},
+	"conda": {},
 }
 
 func newDefaultConfig() *Config {
@@ -245,63 +257,55 @@ func validateAliasMode(mode AliasMode) bool {
 	}
 }
 
-func getGoSubcommandPolicyKeys(args []string) []string {
-	if len(args) == 0 {
-		return []string{"go"}
-	}
-	subcommandParts := make([]string, 0, len(args))
-	for _, arg := range args {
-		if strings.HasPrefix(arg, "-") {
-			break
-		}
-		subcommandParts = append(subcommandParts, strings.ToLower(arg))
-	}
-	if len(subcommandParts) == 0 {
-		return []string{"go"}
-	}
-	keys := make([]string, 0, len(subcommandParts)+1)
-	for index := len(subcommandParts); index >= 1; index-- {
-		keys = append(keys, "go."+strings.Join(subcommandParts[:index], "."))
-	}
-	keys = append(keys, "go")
-	return keys
+func getModeForTool(config *Config, tool string, args []string) AliasMode {
+	mode, _ := resolveToolMode(config, tool, args)
+	return mode
 }
 
-func getModeForTool(config *Config, tool string, args []string) AliasMode {
+// resolveToolMode returns the effective mode for a tool, and the policy that
+// decided it, such as "tool_modes.npm" or "subcommand_modes.npm.publish". The
+// policy is empty when the tool's default mode applies.
+//
+// Precedence, from highest to lowest:
+//  1. Tools without a JFrog CLI integration always run natively.
+//  2. The policy keys of the invocation, as getSubcommandPolicyKeys derives
+//     them, from the most specific to the tool itself. For each key, a
+//     subcommand_modes entry is checked before a tool_modes one. Invalid
+//     modes are skipped with a warning.
+//  3. The tool's default mode: ModePass for defaultPassTools, ModeJF otherwise.
+//
+// A project policy file overrides the global config key by key, before these
+// rules apply.
+func resolveToolMode(config *Config, tool string, args []string) (AliasMode, string) {
 	config = normalizeConfig(config)
-	if tool == "go" {
-		keys := getGoSubcommandPolicyKeys(args)
-		for _, key := range keys {
-			mode, found := config.SubcommandModes[key]
-			if found {
-				if validateAliasMode(mode) {
-					return mode
-				}
-				log.Warn(fmt.Sprintf("Invalid subcommand mode '%s' for key '%s'. Falling back to defaults.", mode, key))
-			}
-			mode, found = config.ToolModes[key]
-			if found {
-				if validateAliasMode(mode) {
-					return mode
-				}
-				log.Warn(fmt.Sprintf("Invalid tool mode '%s' for key '%s'. Falling back to defaults.", mode, key))
-			}
+	if _, isNativeOnly := nativeOnlyTools[tool]; isNativeOnly {
+		if mode, found := config.ToolModes[tool]; found && mode == ModeJF {
+			log.Warn(fmt.Sprintf("JFrog CLI has no '%s' integration. Running it natively.", tool))
 		}
-		return ModeJF
+		return ModePass, ""
 	}
 
-	mode, found := config.ToolModes[tool]
-	if !found {
-		if _, isDefaultPass := defaultPassTools[tool]; isDefaultPass {
-			return ModePass
+	for _, key := range getSubcommandPolicyKeys(tool, args) {
+		mode, found := config.SubcommandModes[key]
+		if found {
+			if validateAliasMode(mode) {
+				return mode, subcommandModePolicy(key)
+			}
+			log.Warn(fmt.Sprintf("Invalid subcommand mode '%s' for key '%s'. Falling back to defaults.", mode, key))
+		}
+		mode, found = config.ToolModes[key]
+		if found {
+			if validateAliasMode(mode) {
+				return mode, toolModePolicy(key)
+			}
+			log.Warn(fmt.Sprintf("Invalid tool mode '%s' for key '%s'. Falling back to defaults.", mode, key))
 		}
-		return ModeJF
 	}
-	if !validateAliasMode(mode) {
-		log.Warn(fmt.Sprintf("Invalid mode '%s' for tool '%s'. Falling back to default.", mode, tool))
-		return ModeJF
+
+	if _, isDefaultPass := defaultPassTools[tool]; isDefaultPass {
+		return ModePass, ""
 	}
-	return mode
+	return ModeJF, ""
 }
 
 func getEnabledState(aliasDir string) bool {
@@ -38,7 +38,7 @@ func TestParsePackageList(t *testing.T) {
 }
 
 func TestGetGoSubcommandPolicyKeys(t *testing.T) {
-	keys := getGoSubcommandPolicyKeys([]string{"mod", "tidy", "-v"})
+	keys := getSubcommandPolicyKeys("go", []string{"mod", "tidy", "-v"})
 	require.Equal(t, []string{"go.mod.tidy", "go.mod", "go"}, keys)
 }
 
@@ -129,3 +129,33 @@ func TestGetEnabledStateFalseWhenDisabledInConfig(t *testing.T) {
 	}))
 	require.False(t, getEnabledState(aliasDir))
 }
+
+func TestGetModeForToolDefaultModes(t *testing.T) {
+	config := &Config{}
+	for _, tool := range []string{"helm", "conan", "nix", "nix-env", "nix-build", "nix-channel"} {
+		require.Equal(t, ModeJF, getModeForTool(config, tool, nil), tool)
+	}
+	for _, tool := range []string{"cargo", "conda", "uv", "terraform", "twine"} {
+		require.Equal(t, ModePass, getModeForTool(config, tool, nil), tool)
+	}
+}
+
+func TestGetModeForToolNativeOnlyToolsIgnoreJFMode(t *testing.T) {
+	config := &Config{
+		ToolModes: map[string]AliasMode{
+			"cargo":     ModeJF,
+			"conda":     ModeJF,
+			"terraform": ModeJF,
+		},
+	}
+	require.Equal(t, ModePass, getModeForTool(config, "cargo", []string{"build"}))
+	require.Equal(t, ModePass, getModeForTool(config, "conda", []string{"install"}))
+	require.Equal(t, ModeJF, getModeForTool(config, "terraform", []string{"publish"}))
+}
+
+func TestNativeOnlyToolsDefaultToPass(t *testing.T) {
+	for tool := range nativeOnlyTools {
+		require.True(t, isSupportedTool(tool), tool)
+		require.Contains(t, defaultPassTools, tool)
+	}
+}
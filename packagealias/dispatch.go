@@ -1,6 +1,7 @@
 package packagealias
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -26,6 +27,10 @@ const ghostFrogLogPrefix = "[GHOST_FROG]"
 //	unset / "" / "false" - bypass alias interception entirely (opt-in: Ghost Frog is off by default)
 //	"true"               - normal interception
 //	"audit"              - log what would happen but run the native tool unchanged
+//
+// Every intercepted invocation is recorded in the audit log: once main()
+// passes its exit code to RecordDispatchResult, or right before the native
+// tool replaces the process.
 func DispatchIfAlias() error {
 	raw := os.Getenv(GhostFrogEnvVar)
 	if raw == "" {
@@ -51,62 +56,107 @@ func DispatchIfAlias() error {
 		log.Warn(fmt.Sprintf("%s Failed to filter PATH: %v", ghostFrogLogPrefix, pathFilterErr))
 	}
 
+	args := os.Args[1:]
+	decision := resolveDispatch(tool, args)
+
 	if auditMode {
-		mode := getToolMode(tool, os.Args[1:])
-		log.Info(fmt.Sprintf("%s [AUDIT] Would intercept '%s' (mode=%s) -- passing to native tool instead", ghostFrogLogPrefix, tool, mode))
+		log.Info(fmt.Sprintf("%s [AUDIT] Would intercept '%s' (mode=%s) -- passing to native tool instead", ghostFrogLogPrefix, tool, decision.mode))
+		startAuditLogEntry(tool, args, decision, ranNative, reasonAudit)
 		if pathFilterErr != nil {
 			return fmt.Errorf("%s cannot run native %s in audit mode: failed to remove alias from PATH (would cause recursion): %w", ghostFrogLogPrefix, tool, pathFilterErr)
 		}
-		return execRealTool(tool, os.Args[1:])
+		return execRealTool(tool, args)
 	}
 
-	if !isEnabled() {
+	if !decision.enabled {
 		log.Info(fmt.Sprintf("%s Package aliasing is disabled -- running native '%s'", ghostFrogLogPrefix, tool))
+		startAuditLogEntry(tool, args, decision, ranNative, reasonDisabled)
 		if pathFilterErr != nil {
 			return fmt.Errorf("%s cannot run native %s: failed to remove alias from PATH (would cause recursion): %w", ghostFrogLogPrefix, tool, pathFilterErr)
 		}
-		return execRealTool(tool, os.Args[1:])
+		return execRealTool(tool, args)
 	}
 
 	log.Info(fmt.Sprintf("%s Intercepting '%s' command", ghostFrogLogPrefix, tool))
 
-	mode := getToolMode(tool, os.Args[1:])
-
-	switch mode {
-	case ModeJF:
-		return runJFMode(tool, os.Args[1:])
-	case ModePass:
+	reason := reasonDefault
+	if decision.policy != "" {
+		reason = reasonPolicy
+	}
+	if decision.mode == ModePass {
+		startAuditLogEntry(tool, args, decision, ranNative, reason)
 		if pathFilterErr != nil {
 			return fmt.Errorf("%s cannot run native %s: failed to remove alias from PATH (would cause recursion): %w", ghostFrogLogPrefix, tool, pathFilterErr)
 		}
-		return execRealTool(tool, os.Args[1:])
-	default:
-		return runJFMode(tool, os.Args[1:])
+		return execRealTool(tool, args)
 	}
+	startAuditLogEntry(tool, args, decision, ranJF, reason)
+	return runJFMode(tool, args)
 }
 
-// isEnabled checks if package aliasing is enabled
-func isEnabled() bool {
-	aliasDir, err := GetAliasHomeDir()
-	if err != nil {
-		return false
-	}
-	return getEnabledState(aliasDir)
+// dispatchDecision is what the effective config decides for an invocation of
+// an aliased tool, and which file decided it.
+type dispatchDecision struct {
+	enabled       bool
+	enabledSource string
+	mode          AliasMode
+	// policy is the policy that decided mode, or "" for the tool's default
+	policy string
+	source string
 }
 
-// getToolMode returns the effective mode for a tool.
-func getToolMode(tool string, args []string) AliasMode {
+// resolveDispatch decides how to run an invocation of tool, in the current
+// project. If the config can't be read, aliasing is considered disabled.
+func resolveDispatch(tool string, args []string) dispatchDecision {
+	decision := dispatchDecision{mode: ModeJF, enabledSource: defaultModeSource, source: defaultModeSource}
 	aliasDir, err := GetAliasHomeDir()
 	if err != nil {
-		return ModeJF
+		return decision
 	}
-
-	config, err := loadConfig(aliasDir)
+	config, err := loadEffectiveConfig(aliasDir)
 	if err != nil {
 		log.Warn(fmt.Sprintf("Failed to read package-alias config: %v. Falling back to default mode.", err))
-		return ModeJF
+		return decision
 	}
-	return getModeForTool(config, tool, args)
+	decision.enabled = config.Enabled
+	decision.enabledSource = config.EnabledSource
+	decision.mode, decision.policy = resolveToolMode(config, tool, args)
+	if decision.policy != "" {
+		decision.source = config.getModeSource(decision.policy)
+	}
+	return decision
+}
+
+// isEnabled checks if package aliasing is enabled, for the current project
+func isEnabled() bool {
+	return resolveDispatch("", nil).enabled
+}
+
+// getToolMode returns the effective mode for a tool, in the current project.
+func getToolMode(tool string, args []string) AliasMode {
+	return resolveDispatch(tool, args).mode
+}
+
+// jfCommandPrefixes maps tools that JFrog CLI exposes under a dispatcher
+// command, rather than as a top-level `jf <tool>`, to that command.
+var jfCommandPrefixes = map[string]string{
+	// RubyGems (gem) and Bundler (bundle) run through `jf ruby <tool>`.
+	"gem":    "ruby",
+	"bundle": "ruby",
+	// The classic Nix binaries run through `jf nix <tool>`, which picks the
+	// native binary from its first argument.
+	"nix-build":   "nix",
+	"nix-channel": "nix",
+	"nix-env":     "nix",
+}
+
+// getJFCommand returns the jf command line an aliased tool is rewritten to,
+// without the tool's own arguments.
+func getJFCommand(tool string) []string {
+	if prefix, found := jfCommandPrefixes[tool]; found {
+		return []string{prefix, tool}
+	}
+	return []string{tool}
 }
 
 // runJFMode rewrites invocation to `jf <tool> <args>`.
@@ -116,20 +166,16 @@ func runJFMode(tool string, args []string) error {
 		return fmt.Errorf("%s could not determine executable path: %w", ghostFrogLogPrefix, err)
 	}
 
-	newArgs := make([]string, 0, len(os.Args)+2)
-	newArgs = append(newArgs, execPath) // Use actual executable path
-	// RubyGems (gem) and Bundler (bundle) are exposed under the single
-	// `jf ruby <tool>` dispatcher rather than top-level `jf gem`/`jf bundle`.
-	if tool == "gem" || tool == "bundle" {
-		newArgs = append(newArgs, "ruby")
-	}
-	newArgs = append(newArgs, tool)    // Add tool name as first argument
-	newArgs = append(newArgs, args...) // Add remaining arguments
+	jfCommand := getJFCommand(tool)
+	newArgs := make([]string, 0, len(args)+len(jfCommand)+1)
+	newArgs = append(newArgs, execPath)     // Use actual executable path
+	newArgs = append(newArgs, jfCommand...) // Add the jf command running the tool
+	newArgs = append(newArgs, args...)      // Add remaining arguments
 
 	os.Args = newArgs
 
 	log.Debug(fmt.Sprintf("%s Running in JF mode: %v", ghostFrogLogPrefix, os.Args))
-	log.Info(fmt.Sprintf("%s Transforming '%s' to 'jf %s'", ghostFrogLogPrefix, tool, tool))
+	log.Info(fmt.Sprintf("%s Transforming '%s' to 'jf %s'", ghostFrogLogPrefix, tool, strings.Join(jfCommand, " ")))
 
 	commands.SetPackageAliasContext(tool)
 	return nil
@@ -158,13 +204,17 @@ func execRealTool(tool string, args []string) error {
 		return execRealToolWindows(realPath, argv)
 	}
 
+	// The tool replaces this process, so its audit log entry is written now,
+	// without an exit code.
+	recordDispatchBeforeExec()
 	// #nosec G204 G702 -- realPath is resolved via exec.LookPath from a controlled tool name, not arbitrary user input.
 	return syscall.Exec(realPath, argv, os.Environ())
 }
 
 // execRealToolWindows runs the real tool as a child process and exits with the child's exit code.
 // Used because syscall.Exec is not supported on Windows (returns EWINDOWS).
-// On success, exits with 0 (never returns). On failure, returns coreutils.CliError so the caller's ExitOnErr can exit with the correct code.
+// Once the child ran, this never returns, so that its failure isn't reported as a jf error.
+// On failure to start it, returns coreutils.CliError so the caller's ExitOnErr can exit with the correct code.
 func execRealToolWindows(realPath string, argv []string) error {
 	// #nosec G204 G702 -- realPath is resolved via exec.LookPath from a controlled tool name, not arbitrary user input.
 	cmd := exec.Command(realPath, argv[1:]...)
@@ -173,15 +223,15 @@ func execRealToolWindows(realPath string, argv []string) error {
 	cmd.Stderr = os.Stderr
 	cmd.Env = os.Environ()
 
-	err := cmd.Run()
-	if err == nil {
-		os.Exit(0)
-	}
-	if exitErr, ok := err.(*exec.ExitError); ok {
-		return coreutils.CliError{
-			ExitCode: coreutils.ExitCode{Code: exitErr.ExitCode()},
-			ErrorMsg: err.Error(),
+	exitCode := 0
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return coreutils.CliError{ExitCode: coreutils.ExitCodeError, ErrorMsg: err.Error()}
 		}
+		exitCode = exitErr.ExitCode()
 	}
-	return coreutils.CliError{ExitCode: coreutils.ExitCodeError, ErrorMsg: err.Error()}
+	RecordDispatchResult(exitCode)
+	os.Exit(exitCode)
+	return nil
 }
@@ -1,8 +1,13 @@
 package packagealias
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -49,3 +54,79 @@ func TestGetToolModeInvalidFallsBackToDefault(t *testing.T) {
 
 	require.Equal(t, ModeJF, getToolMode("npm", []string{"install"}))
 }
+
+func TestGetJFCommand(t *testing.T) {
+	require.Equal(t, []string{"npm"}, getJFCommand("npm"))
+	require.Equal(t, []string{"helm"}, getJFCommand("helm"))
+	require.Equal(t, []string{"nix"}, getJFCommand("nix"))
+	require.Equal(t, []string{"ruby", "gem"}, getJFCommand("gem"))
+	require.Equal(t, []string{"ruby", "bundle"}, getJFCommand("bundle"))
+	require.Equal(t, []string{"nix", "nix-env"}, getJFCommand("nix-env"))
+	require.Equal(t, []string{"nix", "nix-build"}, getJFCommand("nix-build"))
+	require.Equal(t, []string{"nix", "nix-channel"}, getJFCommand("nix-channel"))
+}
+
+func TestJFCommandPrefixesTargetSupportedTools(t *testing.T) {
+	for tool := range jfCommandPrefixes {
+		require.True(t, isSupportedTool(tool), "dispatched tool %q must be in SupportedTools", tool)
+	}
+}
+
+// dispatchHelperEnv makes the test binary act as main() does when it runs as
+// an alias, for TestDispatchPassModeKeepsStderrClean.
+const dispatchHelperEnv = "PACKAGE_ALIAS_DISPATCH_HELPER"
+
+func TestDispatchPassModeKeepsStderrClean(t *testing.T) {
+	if os.Getenv(dispatchHelperEnv) == "1" {
+		err := DispatchIfAlias()
+		exitCode := 0
+		if err != nil {
+			exitCode = 1
+			_, _ = fmt.Fprintf(os.Stderr, "Package alias execution failed: %v\n", err)
+		}
+		RecordDispatchResult(exitCode)
+		os.Exit(exitCode)
+	}
+	if runtime.GOOS == "windows" {
+		t.Skip("the native tool is a shell script")
+	}
+
+	testHomeDir := t.TempDir()
+	aliasDir := filepath.Join(testHomeDir, "package-alias")
+	binDir := filepath.Join(aliasDir, "bin")
+	require.NoError(t, os.MkdirAll(binDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(aliasDir, configFile), []byte("enabled: true\ntool_modes:\n  npm: pass\n"), 0600))
+
+	testBinary, err := os.Executable()
+	require.NoError(t, err)
+	require.NoError(t, os.Symlink(testBinary, filepath.Join(binDir, "npm")))
+	realDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(realDir, "npm"), []byte("#!/bin/sh\necho native npm\nexit 3\n"), 0755))
+
+	// #nosec G204 -- runs the test binary through its alias
+	cmd := exec.Command(filepath.Join(binDir, "npm"), "-test.run=^TestDispatchPassModeKeepsStderrClean$")
+	cmd.Dir = t.TempDir()
+	cmd.Env = append(os.Environ(),
+		dispatchHelperEnv+"=1",
+		"JFROG_CLI_HOME_DIR="+testHomeDir,
+		GhostFrogEnvVar+"=true",
+		"PATH="+binDir+string(os.PathListSeparator)+realDir)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+
+	var exitErr *exec.ExitError
+	require.True(t, errors.As(err, &exitErr), "unexpected error: %v, stderr: %s", err, stderr.String())
+	require.Equal(t, 3, exitErr.ExitCode())
+	require.Equal(t, "native npm\n", stdout.String())
+	require.NotContains(t, stderr.String(), "exit status")
+	require.NotContains(t, stderr.String(), "Package alias execution failed")
+	require.NotContains(t, stderr.String(), "[Error]")
+
+	entries, err := readAuditLog(aliasDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, ranNative, entries[0].Ran)
+	require.Nil(t, entries[0].ExitCode, "the tool replaced the process, so its exit code is unknown")
+}
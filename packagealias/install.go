@@ -41,15 +41,10 @@ func (ic *InstallCommand) Run() error {
 		return errorutils.CheckError(err)
 	}
 
-	jfPath, err := os.Executable()
-	if err != nil {
-		return errorutils.CheckError(fmt.Errorf("could not determine executable path: %w", err))
-	}
-	jfPath, err = filepath.EvalSymlinks(jfPath)
+	jfPath, err := getJfBinaryPath()
 	if err != nil {
-		return errorutils.CheckError(fmt.Errorf("could not resolve executable path: %w", err))
+		return err
 	}
-	log.Debug(fmt.Sprintf("Using jf binary at: %s", jfPath))
 
 	selectedTools, err := parsePackageList(ic.packagesArg)
 	if err != nil {
@@ -72,32 +67,18 @@ func (ic *InstallCommand) Run() error {
 		}
 
 		for _, tool := range SupportedTools {
-			aliasPath := filepath.Join(binDir, tool)
-			if runtime.GOOS == "windows" {
-				aliasPath += ".exe"
-			}
-
 			if _, shouldInstall := selectedToolsSet[tool]; !shouldInstall {
-				if removeErr := os.Remove(aliasPath); removeErr != nil && !os.IsNotExist(removeErr) {
+				if removeErr := removeAlias(binDir, tool); removeErr != nil {
 					log.Warn(fmt.Sprintf("Failed to remove alias for %s: %v", tool, removeErr))
 				}
 				continue
 			}
 
-			if runtime.GOOS == "windows" {
-				if copyErr := copyFile(jfPath, aliasPath); copyErr != nil {
-					log.Warn(fmt.Sprintf("Failed to create alias for %s: %v", tool, copyErr))
-					continue
-				}
-			} else {
-				_ = os.Remove(aliasPath)
-				if symlinkErr := os.Symlink(jfPath, aliasPath); symlinkErr != nil {
-					log.Warn(fmt.Sprintf("Failed to create alias for %s: %v", tool, symlinkErr))
-					continue
-				}
+			if createErr := createAlias(jfPath, binDir, tool); createErr != nil {
+				log.Warn(fmt.Sprintf("Failed to create alias for %s: %v", tool, createErr))
+				continue
 			}
 			createdCount++
-			log.Debug(fmt.Sprintf("Created alias: %s -> %s", aliasPath, jfPath))
 		}
 
 		cfg, loadErr := loadConfig(aliasDir)
@@ -105,15 +86,7 @@ func (ic *InstallCommand) Run() error {
 			return loadErr
 		}
 
-		for _, tool := range selectedTools {
-			if _, exists := cfg.ToolModes[tool]; !exists {
-				if _, isDefaultPass := defaultPassTools[tool]; isDefaultPass {
-					cfg.ToolModes[tool] = ModePass
-				} else {
-					cfg.ToolModes[tool] = ModeJF
-				}
-			}
-		}
+		setDefaultToolModes(cfg, selectedTools)
 
 		cfg.EnabledTools = append([]string(nil), selectedTools...)
 		cfg.JfBinarySHA256 = jfHash
@@ -146,6 +119,61 @@ func (ic *InstallCommand) ServerDetails() (*config.ServerDetails, error) {
 	return nil, nil
 }
 
+// getJfBinaryPath returns the resolved path of the running jf binary, which
+// aliases link to.
+func getJfBinaryPath() (string, error) {
+	jfPath, err := os.Executable()
+	if err != nil {
+		return "", errorutils.CheckError(fmt.Errorf("could not determine executable path: %w", err))
+	}
+	jfPath, err = filepath.EvalSymlinks(jfPath)
+	if err != nil {
+		return "", errorutils.CheckError(fmt.Errorf("could not resolve executable path: %w", err))
+	}
+	log.Debug(fmt.Sprintf("Using jf binary at: %s", jfPath))
+	return jfPath, nil
+}
+
+// createAlias creates the alias of a tool in binDir: a symlink to jfPath, or
+// a copy of it on Windows.
+func createAlias(jfPath, binDir, tool string) error {
+	aliasPath := filepath.Join(binDir, addExecutableSuffix(tool))
+	if runtime.GOOS == "windows" {
+		if err := copyFile(jfPath, aliasPath); err != nil {
+			return err
+		}
+	} else {
+		_ = os.Remove(aliasPath)
+		if err := os.Symlink(jfPath, aliasPath); err != nil {
+			return err
+		}
+	}
+	log.Debug(fmt.Sprintf("Created alias: %s -> %s", aliasPath, jfPath))
+	return nil
+}
+
+// removeAlias removes the alias of a tool from binDir, if present.
+func removeAlias(binDir, tool string) error {
+	if err := os.Remove(filepath.Join(binDir, addExecutableSuffix(tool))); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// setDefaultToolModes records the default mode of the tools that have none
+// configured yet.
+func setDefaultToolModes(cfg *Config, tools []string) {
+	for _, tool := range tools {
+		if _, exists := cfg.ToolModes[tool]; !exists {
+			if _, isDefaultPass := defaultPassTools[tool]; isDefaultPass {
+				cfg.ToolModes[tool] = ModePass
+			} else {
+				cfg.ToolModes[tool] = ModeJF
+			}
+		}
+	}
+}
+
 func copyFile(src, dst string) error {
 	// #nosec G304 -- src is the resolved jf binary path, not user input
 	srcFile, err := os.Open(src)
@@ -87,3 +87,52 @@ func TestGetStatusModeDetailsShowsGoPolicies(t *testing.T) {
 		"go.mod.tidy mode=pass",
 	}, details)
 }
+
+func TestGetStatusModeDetailsShowsDispatchAndNativeOnlyTools(t *testing.T) {
+	config := &Config{
+		ToolModes: map[string]AliasMode{
+			"nix-build": ModePass,
+		},
+	}
+	require.Equal(t, []string{"runs as 'jf nix nix-env'"}, getStatusModeDetails(config, "nix-env"))
+	require.Equal(t, []string{"runs as 'jf ruby gem'"}, getStatusModeDetails(&Config{ToolModes: map[string]AliasMode{"gem": ModeJF}}, "gem"))
+	require.Empty(t, getStatusModeDetails(config, "nix-build"))
+	require.Empty(t, getStatusModeDetails(config, "helm"))
+	require.Equal(t, []string{"no JFrog CLI integration, always runs natively"}, getStatusModeDetails(config, "cargo"))
+}
+
+func TestInstallNewToolsWritesDefaultModes(t *testing.T) {
+	testHomeDir := t.TempDir()
+	t.Setenv("JFROG_CLI_HOME_DIR", testHomeDir)
+
+	require.NoError(t, NewInstallCommand("helm,nix-env,cargo,terraform").Run())
+
+	config, err := loadConfig(filepath.Join(testHomeDir, "package-alias"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]AliasMode{
+		"helm":      ModeJF,
+		"nix-env":   ModeJF,
+		"cargo":     ModePass,
+		"terraform": ModePass,
+	}, config.ToolModes)
+	for _, tool := range []string{"helm", "nix-env", "cargo", "terraform"} {
+		_, statErr := os.Lstat(filepath.Join(testHomeDir, "package-alias", "bin", addExecutableSuffix(tool)))
+		require.NoError(t, statErr, tool)
+	}
+}
+
+func TestGetStatusModeDetailsShowsSubcommandPolicies(t *testing.T) {
+	config := &Config{
+		SubcommandModes: map[string]AliasMode{
+			"npm.publish":  ModeJF,
+			"npm.run":      ModePass,
+			"npmx.run":     ModeJF,
+			"docker.build": AliasMode("bogus"),
+		},
+	}
+	require.Equal(t, []string{
+		"npm.publish mode=jf",
+		"npm.run mode=pass",
+	}, getStatusModeDetails(config, "npm"))
+	require.Equal(t, []string{"docker.build mode=bogus (invalid, ignored)"}, getStatusModeDetails(config, "docker"))
+}
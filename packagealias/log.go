@@ -0,0 +1,313 @@
+package packagealias
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const defaultLogLimit = 20
+
+// LogCommand queries the audit log of the invocations of aliased tools.
+type LogCommand struct {
+	tool       string
+	since      string
+	failedOnly bool
+	limit      int
+	summary    bool
+	format     string
+}
+
+func NewLogCommand() *LogCommand {
+	return &LogCommand{limit: defaultLogLimit}
+}
+
+func (lc *LogCommand) SetTool(tool string) *LogCommand {
+	lc.tool = tool
+	return lc
+}
+
+// SetSince keeps the entries after a time, given as a duration back from now
+// (e.g. 24h) or an RFC 3339 timestamp.
+func (lc *LogCommand) SetSince(since string) *LogCommand {
+	lc.since = since
+	return lc
+}
+
+func (lc *LogCommand) SetFailedOnly(failedOnly bool) *LogCommand {
+	lc.failedOnly = failedOnly
+	return lc
+}
+
+// SetLimit sets the number of most recent entries shown, 0 for all of them.
+func (lc *LogCommand) SetLimit(limit int) *LogCommand {
+	lc.limit = limit
+	return lc
+}
+
+func (lc *LogCommand) SetSummary(summary bool) *LogCommand {
+	lc.summary = summary
+	return lc
+}
+
+func (lc *LogCommand) SetFormat(format string) *LogCommand {
+	lc.format = format
+	return lc
+}
+
+func (lc *LogCommand) CommandName() string {
+	return "package_alias_log"
+}
+
+func (lc *LogCommand) Run() error {
+	filter, err := lc.getFilter()
+	if err != nil {
+		return err
+	}
+	if lc.limit < 0 {
+		return errorutils.CheckError(fmt.Errorf("invalid limit %d. Use 0 to show all entries", lc.limit))
+	}
+	jsonFormat := strings.EqualFold(lc.format, "json")
+	if !jsonFormat && lc.format != "" && !strings.EqualFold(lc.format, "table") {
+		return errorutils.CheckError(fmt.Errorf("invalid format '%s'. Valid formats: table, json", lc.format))
+	}
+
+	aliasDir, err := GetAliasHomeDir()
+	if err != nil {
+		return err
+	}
+	entries, err := readAuditLog(aliasDir)
+	if err != nil {
+		return err
+	}
+	if lc.failedOnly {
+		// Entries without an exit code can't be told to have failed.
+		unknownFilter := filter
+		unknownFilter.failedOnly = false
+		if unknown := countUnknownExitCodes(unknownFilter.apply(entries)); unknown > 0 {
+			log.Info(fmt.Sprintf("%d native invocation(s) with an unknown exit code are not shown. On Linux and macOS, native runs replace the jf process, which can't record how they exited.", unknown))
+		}
+	}
+	entries = filter.apply(entries)
+
+	if lc.summary {
+		summaries := summarizeAuditLog(entries)
+		if jsonFormat {
+			return outputJSON(summaries)
+		}
+		if len(summaries) == 0 {
+			log.Info("No matching audit log entries")
+			return nil
+		}
+		log.Output(formatAuditLogSummaries(summaries))
+		return nil
+	}
+
+	if lc.limit > 0 && len(entries) > lc.limit {
+		entries = entries[len(entries)-lc.limit:]
+	}
+	if jsonFormat {
+		if entries == nil {
+			entries = []AuditLogEntry{}
+		}
+		return outputJSON(entries)
+	}
+	if len(entries) == 0 {
+		log.Info("No matching audit log entries")
+		return nil
+	}
+	log.Output(formatAuditLogEntries(entries))
+	return nil
+}
+
+func (lc *LogCommand) getFilter() (auditLogFilter, error) {
+	filter := auditLogFilter{tool: strings.ToLower(strings.TrimSpace(lc.tool)), failedOnly: lc.failedOnly}
+	if filter.tool != "" && !isSupportedTool(filter.tool) {
+		return filter, errorutils.CheckError(fmt.Errorf("unsupported package manager: %s. Supported package managers: %s", filter.tool, strings.Join(SupportedTools, ", ")))
+	}
+	if lc.since == "" {
+		return filter, nil
+	}
+	if duration, err := time.ParseDuration(lc.since); err == nil && duration > 0 {
+		filter.since = time.Now().Add(-duration)
+		return filter, nil
+	}
+	since, err := time.Parse(time.RFC3339, lc.since)
+	if err != nil {
+		return filter, errorutils.CheckError(fmt.Errorf("invalid --since value '%s'. Expected a duration such as 24h, or an RFC 3339 timestamp", lc.since))
+	}
+	filter.since = since
+	return filter, nil
+}
+
+func (lc *LogCommand) SetRepo(repo string) *LogCommand {
+	return lc
+}
+
+func (lc *LogCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+type auditLogFilter struct {
+	tool       string
+	since      time.Time
+	failedOnly bool
+}
+
+func (f auditLogFilter) apply(entries []AuditLogEntry) []AuditLogEntry {
+	var filtered []AuditLogEntry
+	for _, entry := range entries {
+		if f.tool != "" && entry.Tool != f.tool {
+			continue
+		}
+		if !f.since.IsZero() && entry.Timestamp.Before(f.since) {
+			continue
+		}
+		if f.failedOnly && !entry.failed() {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+	return filtered
+}
+
+// AuditLogSummary sums up the audit log entries of a tool.
+type AuditLogSummary struct {
+	Tool        string `json:"tool"`
+	Invocations int    `json:"invocations"`
+	RanJF       int    `json:"ran_jf"`
+	RanNative   int    `json:"ran_native"`
+	Failed      int    `json:"failed"`
+	// UnknownExit counts the invocations whose exit code isn't known: the
+	// native runs on Linux and macOS, which replace the jf process. They're
+	// counted neither as failed nor as succeeded.
+	UnknownExit int `json:"unknown_exit"`
+	// AvgDurationMs only counts the invocations whose duration is known
+	AvgDurationMs int64     `json:"avg_duration_ms"`
+	LastRun       time.Time `json:"last_run"`
+}
+
+// summarizeAuditLog returns the summary of each tool in entries, sorted by
+// tool.
+func summarizeAuditLog(entries []AuditLogEntry) []AuditLogSummary {
+	summariesByTool := make(map[string]*AuditLogSummary)
+	totalDurations := make(map[string]int64)
+	timedInvocations := make(map[string]int64)
+	for _, entry := range entries {
+		summary, found := summariesByTool[entry.Tool]
+		if !found {
+			summary = &AuditLogSummary{Tool: entry.Tool}
+			summariesByTool[entry.Tool] = summary
+		}
+		summary.Invocations++
+		if entry.Ran == ranJF {
+			summary.RanJF++
+		} else {
+			summary.RanNative++
+		}
+		if entry.ExitCode == nil {
+			summary.UnknownExit++
+		} else if entry.failed() {
+			summary.Failed++
+		}
+		if entry.Timestamp.After(summary.LastRun) {
+			summary.LastRun = entry.Timestamp
+		}
+		if entry.DurationMs != nil {
+			totalDurations[entry.Tool] += *entry.DurationMs
+			timedInvocations[entry.Tool]++
+		}
+	}
+
+	summaries := make([]AuditLogSummary, 0, len(summariesByTool))
+	for tool, summary := range summariesByTool {
+		if timedInvocations[tool] > 0 {
+			summary.AvgDurationMs = totalDurations[tool] / timedInvocations[tool]
+		}
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Tool < summaries[j].Tool
+	})
+	return summaries
+}
+
+func countUnknownExitCodes(entries []AuditLogEntry) int {
+	count := 0
+	for _, entry := range entries {
+		if entry.ExitCode == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// failed reports whether the invocation is known to have exited with a
+// non-zero code.
+func (e AuditLogEntry) failed() bool {
+	return e.ExitCode != nil && *e.ExitCode != 0
+}
+
+func formatAuditLogEntries(entries []AuditLogEntry) string {
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "TIME\tRAN\tEXIT\tDURATION\tREASON\tCOMMAND")
+	for _, entry := range entries {
+		reason := entry.Reason
+		if entry.Policy != "" {
+			reason += " (" + entry.Policy + ")"
+		}
+		exitCode, duration := "-", "-"
+		if entry.ExitCode != nil {
+			exitCode = strconv.Itoa(*entry.ExitCode)
+		}
+		if entry.DurationMs != nil {
+			duration = (time.Duration(*entry.DurationMs) * time.Millisecond).String()
+		}
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.Timestamp.Local().Format(time.DateTime),
+			entry.Ran,
+			exitCode,
+			duration,
+			reason,
+			strings.Join(append([]string{entry.Tool}, entry.Argv...), " "))
+	}
+	_ = writer.Flush()
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+func formatAuditLogSummaries(summaries []AuditLogSummary) string {
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "TOOL\tINVOCATIONS\tJF\tNATIVE\tFAILED\tUNKNOWN EXIT\tAVG DURATION\tLAST RUN")
+	for _, summary := range summaries {
+		_, _ = fmt.Fprintf(writer, "%s\t%d\t%d\t%d\t%d\t%d\t%s\t%s\n",
+			summary.Tool,
+			summary.Invocations,
+			summary.RanJF,
+			summary.RanNative,
+			summary.Failed,
+			summary.UnknownExit,
+			time.Duration(summary.AvgDurationMs)*time.Millisecond,
+			summary.LastRun.Local().Format(time.DateTime))
+	}
+	_ = writer.Flush()
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+func outputJSON(value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(string(data))
+	return nil
+}
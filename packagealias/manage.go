@@ -0,0 +1,416 @@
+package packagealias
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// removedAliasSuffix is appended to the aliases remove-tools moves aside
+// until the config is written.
+const removedAliasSuffix = ".removed"
+
+// SetModeCommand sets the mode of a tool, or of one of its subcommands, in
+// the global config.
+type SetModeCommand struct {
+	policyKey string
+	mode      AliasMode
+}
+
+func NewSetModeCommand(policyKey string, mode AliasMode) *SetModeCommand {
+	return &SetModeCommand{policyKey: policyKey, mode: mode}
+}
+
+func (sc *SetModeCommand) CommandName() string {
+	return "package_alias_set"
+}
+
+func (sc *SetModeCommand) Run() error {
+	tool, key, err := parsePolicyKey(sc.policyKey)
+	if err != nil {
+		return err
+	}
+	mode := AliasMode(strings.ToLower(strings.TrimSpace(string(sc.mode))))
+	if !validateAliasMode(mode) {
+		return errorutils.CheckError(fmt.Errorf("invalid mode '%s'. Valid modes: %s, %s", sc.mode, ModeJF, ModePass))
+	}
+	if _, isNativeOnly := nativeOnlyTools[tool]; isNativeOnly && mode == ModeJF {
+		log.Warn(fmt.Sprintf("JFrog CLI has no '%s' integration yet, so it will keep running natively.", tool))
+	}
+
+	if err = updateConfig(func(cfg *Config) error {
+		if key == tool {
+			cfg.ToolModes[key] = mode
+		} else {
+			cfg.SubcommandModes[key] = mode
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("Set %s to %s mode", key, mode))
+	return nil
+}
+
+func (sc *SetModeCommand) SetRepo(repo string) *SetModeCommand {
+	return sc
+}
+
+func (sc *SetModeCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+// UnsetModeCommand removes the mode of a tool, or of one of its subcommands,
+// from the global config, so that the next less specific policy applies.
+type UnsetModeCommand struct {
+	policyKey string
+}
+
+func NewUnsetModeCommand(policyKey string) *UnsetModeCommand {
+	return &UnsetModeCommand{policyKey: policyKey}
+}
+
+func (uc *UnsetModeCommand) CommandName() string {
+	return "package_alias_unset"
+}
+
+func (uc *UnsetModeCommand) Run() error {
+	_, key, err := parsePolicyKey(uc.policyKey)
+	if err != nil {
+		return err
+	}
+
+	var removed bool
+	if err = updateConfig(func(cfg *Config) error {
+		_, inToolModes := cfg.ToolModes[key]
+		_, inSubcommandModes := cfg.SubcommandModes[key]
+		removed = inToolModes || inSubcommandModes
+		delete(cfg.ToolModes, key)
+		delete(cfg.SubcommandModes, key)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if !removed {
+		log.Info(fmt.Sprintf("No mode is set for %s", key))
+		return nil
+	}
+	log.Info(fmt.Sprintf("Unset the mode of %s", key))
+	return nil
+}
+
+func (uc *UnsetModeCommand) SetRepo(repo string) *UnsetModeCommand {
+	return uc
+}
+
+func (uc *UnsetModeCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+// ToggleCommand enables or disables package aliasing globally, without
+// removing the installed aliases.
+type ToggleCommand struct {
+	enabled bool
+}
+
+func NewEnableCommand() *ToggleCommand {
+	return &ToggleCommand{enabled: true}
+}
+
+func NewDisableCommand() *ToggleCommand {
+	return &ToggleCommand{enabled: false}
+}
+
+func (tc *ToggleCommand) CommandName() string {
+	if tc.enabled {
+		return "package_alias_enable"
+	}
+	return "package_alias_disable"
+}
+
+func (tc *ToggleCommand) Run() error {
+	if err := updateConfig(func(cfg *Config) error {
+		cfg.Enabled = tc.enabled
+		return nil
+	}); err != nil {
+		return err
+	}
+	if tc.enabled {
+		log.Info("Package aliasing is enabled")
+	} else {
+		log.Info("Package aliasing is disabled. Aliased tools now run natively.")
+	}
+	return nil
+}
+
+func (tc *ToggleCommand) SetRepo(repo string) *ToggleCommand {
+	return tc
+}
+
+func (tc *ToggleCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+// AddToolsCommand creates the aliases of more tools in an existing install.
+type AddToolsCommand struct {
+	packagesArg string
+}
+
+func NewAddToolsCommand(packagesArg string) *AddToolsCommand {
+	return &AddToolsCommand{packagesArg: packagesArg}
+}
+
+func (ac *AddToolsCommand) CommandName() string {
+	return "package_alias_add_tools"
+}
+
+func (ac *AddToolsCommand) Run() error {
+	tools, err := parseToolsArg(ac.packagesArg)
+	if err != nil {
+		return err
+	}
+	aliasDir, err := GetAliasHomeDir()
+	if err != nil {
+		return err
+	}
+	binDir, err := getInstalledBinDir()
+	if err != nil {
+		return err
+	}
+	jfPath, err := getJfBinaryPath()
+	if err != nil {
+		return err
+	}
+	jfHash, err := computeFileSHA256(jfPath)
+	if err != nil {
+		return errorutils.CheckError(fmt.Errorf("failed computing jf binary hash: %w", err))
+	}
+
+	var added []string
+	if err = withConfigLock(aliasDir, func() error {
+		cfg, err := loadConfig(aliasDir)
+		if err != nil {
+			return err
+		}
+		// The existing aliases link to (or, on Windows, are copies of) the jf
+		// binary that was installed, so mixing in aliases of another one is refused.
+		if cfg.JfBinarySHA256 != "" && cfg.JfBinarySHA256 != jfHash {
+			return errorutils.CheckError(fmt.Errorf("the running jf binary differs from the one the aliases were installed with. Run 'jf package-alias install' to refresh all aliases"))
+		}
+		configuredTools := getConfiguredTools(cfg)
+		for _, tool := range tools {
+			if !slices.Contains(configuredTools, tool) {
+				added = append(added, tool)
+			}
+		}
+		if len(added) == 0 {
+			return nil
+		}
+
+		var created []string
+		rollback := func() {
+			for _, tool := range created {
+				if err := removeAlias(binDir, tool); err != nil {
+					log.Warn(fmt.Sprintf("Failed to remove alias for %s: %v", tool, err))
+				}
+			}
+		}
+		for _, tool := range added {
+			// Recorded before creating it, to also clean up a partially created alias.
+			created = append(created, tool)
+			if err := createAlias(jfPath, binDir, tool); err != nil {
+				rollback()
+				return errorutils.CheckError(fmt.Errorf("failed to create alias for %s: %w", tool, err))
+			}
+		}
+		setDefaultToolModes(cfg, added)
+		cfg.EnabledTools = append(configuredTools, added...)
+		cfg.JfBinarySHA256 = jfHash
+		if err = writeConfig(aliasDir, cfg); err != nil {
+			rollback()
+			return errorutils.CheckError(err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(added) == 0 {
+		log.Info("All given tools are already aliased")
+		return nil
+	}
+	log.Info(fmt.Sprintf("Added aliases: %s", strings.Join(added, ", ")))
+	return nil
+}
+
+func (ac *AddToolsCommand) SetRepo(repo string) *AddToolsCommand {
+	return ac
+}
+
+func (ac *AddToolsCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+// RemoveToolsCommand removes the aliases of some tools from an existing
+// install. Their modes are kept, in case they are added back.
+type RemoveToolsCommand struct {
+	packagesArg string
+}
+
+func NewRemoveToolsCommand(packagesArg string) *RemoveToolsCommand {
+	return &RemoveToolsCommand{packagesArg: packagesArg}
+}
+
+func (rc *RemoveToolsCommand) CommandName() string {
+	return "package_alias_remove_tools"
+}
+
+func (rc *RemoveToolsCommand) Run() error {
+	tools, err := parseToolsArg(rc.packagesArg)
+	if err != nil {
+		return err
+	}
+	aliasDir, err := GetAliasHomeDir()
+	if err != nil {
+		return err
+	}
+	binDir, err := getInstalledBinDir()
+	if err != nil {
+		return err
+	}
+
+	var removed []string
+	if err = withConfigLock(aliasDir, func() error {
+		cfg, err := loadConfig(aliasDir)
+		if err != nil {
+			return err
+		}
+		remainingTools := make([]string, 0, len(SupportedTools))
+		for _, tool := range getConfiguredTools(cfg) {
+			if slices.Contains(tools, tool) {
+				removed = append(removed, tool)
+				continue
+			}
+			remainingTools = append(remainingTools, tool)
+		}
+		if len(remainingTools) == 0 {
+			return errorutils.CheckError(fmt.Errorf("can't remove all aliased tools. Run 'jf package-alias uninstall' instead"))
+		}
+		if len(removed) == 0 {
+			return nil
+		}
+
+		// The aliases are moved aside until the config is written, so that
+		// they can be restored as they were if anything fails.
+		var movedAliases []string
+		restore := func() {
+			for _, aliasPath := range movedAliases {
+				if err := os.Rename(aliasPath+removedAliasSuffix, aliasPath); err != nil {
+					log.Warn(fmt.Sprintf("Failed to restore alias %s: %v", aliasPath, err))
+				}
+			}
+		}
+		for _, tool := range removed {
+			aliasPath := filepath.Join(binDir, addExecutableSuffix(tool))
+			if err := os.Rename(aliasPath, aliasPath+removedAliasSuffix); err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				restore()
+				return errorutils.CheckError(fmt.Errorf("failed to remove alias for %s: %w", tool, err))
+			}
+			movedAliases = append(movedAliases, aliasPath)
+		}
+		cfg.EnabledTools = remainingTools
+		if err = writeConfig(aliasDir, cfg); err != nil {
+			restore()
+			return errorutils.CheckError(err)
+		}
+		for _, aliasPath := range movedAliases {
+			if err := os.Remove(aliasPath + removedAliasSuffix); err != nil {
+				log.Warn(fmt.Sprintf("Failed to remove %s: %v", aliasPath+removedAliasSuffix, err))
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(removed) == 0 {
+		log.Info("None of the given tools is aliased")
+		return nil
+	}
+	log.Info(fmt.Sprintf("Removed aliases: %s", strings.Join(removed, ", ")))
+	return nil
+}
+
+func (rc *RemoveToolsCommand) SetRepo(repo string) *RemoveToolsCommand {
+	return rc
+}
+
+func (rc *RemoveToolsCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+// updateConfig applies a change to the global config while holding the
+// config lock, so that concurrent changes don't overwrite each other.
+func updateConfig(mutate func(cfg *Config) error) error {
+	aliasDir, err := GetAliasHomeDir()
+	if err != nil {
+		return err
+	}
+	// #nosec G301 -- matches the permissions install creates the directory with
+	if err = os.MkdirAll(aliasDir, 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return withConfigLock(aliasDir, func() error {
+		cfg, err := loadConfig(aliasDir)
+		if err != nil {
+			return err
+		}
+		if err = mutate(cfg); err != nil {
+			return err
+		}
+		return writeConfig(aliasDir, cfg)
+	})
+}
+
+// parsePolicyKey splits a "tool[.subcommand]" policy key, as used by
+// tool_modes and subcommand_modes, and returns the tool and the normalized key.
+func parsePolicyKey(policyKey string) (tool, key string, err error) {
+	key = strings.ToLower(strings.TrimSpace(policyKey))
+	tool, subcommand, hasSubcommand := strings.Cut(key, ".")
+	if !isSupportedTool(tool) {
+		return "", "", errorutils.CheckError(fmt.Errorf("unsupported package manager: %s. Supported package managers: %s", tool, strings.Join(SupportedTools, ", ")))
+	}
+	if hasSubcommand && (subcommand == "" || slices.Contains(strings.Split(subcommand, "."), "")) {
+		return "", "", errorutils.CheckError(fmt.Errorf("invalid policy key '%s'. Expected <tool>[.<subcommand>]", policyKey))
+	}
+	return tool, key, nil
+}
+
+// parseToolsArg parses the tools given to add-tools and remove-tools. Unlike
+// install's --packages, an empty list is an error rather than every tool.
+func parseToolsArg(packagesArg string) ([]string, error) {
+	if strings.Trim(packagesArg, ", ") == "" {
+		return nil, errorutils.CheckError(fmt.Errorf("no package managers provided"))
+	}
+	return parsePackageList(packagesArg)
+}
+
+// getInstalledBinDir returns the aliases directory, failing if package
+// aliasing isn't installed.
+func getInstalledBinDir() (string, error) {
+	binDir, err := GetAliasBinDir()
+	if err != nil {
+		return "", err
+	}
+	if _, err = os.Stat(binDir); os.IsNotExist(err) {
+		return "", errorutils.CheckError(fmt.Errorf("package aliases are not installed. Run 'jf package-alias install' first"))
+	}
+	return binDir, nil
+}
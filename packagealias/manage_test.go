@@ -0,0 +1,171 @@
+package packagealias
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetAndUnsetMode(t *testing.T) {
+	testHomeDir := t.TempDir()
+	t.Setenv("JFROG_CLI_HOME_DIR", testHomeDir)
+	aliasDir := filepath.Join(testHomeDir, "package-alias")
+
+	require.NoError(t, NewSetModeCommand("npm", ModePass).Run())
+	require.NoError(t, NewSetModeCommand("NPM.Publish", "JF").Run())
+	require.NoError(t, NewSetModeCommand("docker.image.push", ModeJF).Run())
+
+	config, err := loadConfig(aliasDir)
+	require.NoError(t, err)
+	assert.Equal(t, ModePass, config.ToolModes["npm"])
+	assert.Equal(t, ModeJF, config.SubcommandModes["npm.publish"])
+	assert.Equal(t, ModeJF, config.SubcommandModes["docker.image.push"])
+	assert.Equal(t, ModeJF, getModeForTool(config, "npm", []string{"publish"}))
+	assert.Equal(t, ModePass, getModeForTool(config, "npm", []string{"install"}))
+
+	require.NoError(t, NewUnsetModeCommand("npm.publish").Run())
+	require.NoError(t, NewUnsetModeCommand("npm").Run())
+	// Unsetting a policy that isn't set is not an error.
+	require.NoError(t, NewUnsetModeCommand("mvn").Run())
+
+	config, err = loadConfig(aliasDir)
+	require.NoError(t, err)
+	assert.NotContains(t, config.ToolModes, "npm")
+	assert.NotContains(t, config.SubcommandModes, "npm.publish")
+	assert.Contains(t, config.SubcommandModes, "docker.image.push")
+}
+
+func TestSetModeRejectsInvalidInput(t *testing.T) {
+	t.Setenv("JFROG_CLI_HOME_DIR", t.TempDir())
+
+	assert.Error(t, NewSetModeCommand("not-a-tool", ModeJF).Run())
+	assert.Error(t, NewSetModeCommand("npm", "maybe").Run())
+	assert.Error(t, NewSetModeCommand("npm.", ModeJF).Run())
+	assert.Error(t, NewSetModeCommand("docker..push", ModeJF).Run())
+	assert.Error(t, NewUnsetModeCommand("not-a-tool").Run())
+}
+
+func TestEnableAndDisable(t *testing.T) {
+	testHomeDir := t.TempDir()
+	t.Setenv("JFROG_CLI_HOME_DIR", testHomeDir)
+	aliasDir := filepath.Join(testHomeDir, "package-alias")
+
+	require.NoError(t, NewDisableCommand().Run())
+	assert.False(t, getEnabledState(aliasDir))
+
+	require.NoError(t, NewEnableCommand().Run())
+	assert.True(t, getEnabledState(aliasDir))
+}
+
+func TestAddAndRemoveTools(t *testing.T) {
+	testHomeDir := t.TempDir()
+	t.Setenv("JFROG_CLI_HOME_DIR", testHomeDir)
+	aliasDir := filepath.Join(testHomeDir, "package-alias")
+	binDir := filepath.Join(aliasDir, "bin")
+
+	// Not installed yet.
+	assert.Error(t, NewAddToolsCommand("helm").Run())
+
+	require.NoError(t, NewInstallCommand("mvn,npm").Run())
+	require.NoError(t, NewAddToolsCommand("helm,uv").Run())
+
+	config, err := loadConfig(aliasDir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"mvn", "npm", "helm", "uv"}, config.EnabledTools)
+	assert.Equal(t, ModeJF, config.ToolModes["helm"])
+	assert.Equal(t, ModePass, config.ToolModes["uv"])
+	assertAliasExists(t, binDir, "helm", true)
+
+	require.NoError(t, NewRemoveToolsCommand("npm,helm").Run())
+	config, err = loadConfig(aliasDir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"mvn", "uv"}, config.EnabledTools)
+	assertAliasExists(t, binDir, "helm", false)
+	assertAliasExists(t, binDir, "npm", false)
+	assertAliasExists(t, binDir, "mvn", true)
+
+	// At least one tool must stay aliased.
+	assert.Error(t, NewRemoveToolsCommand("mvn,uv").Run())
+	assert.Error(t, NewAddToolsCommand(" , ").Run())
+	assert.Error(t, NewAddToolsCommand("not-a-tool").Run())
+}
+
+func TestAddToolsRollsBackOnFailure(t *testing.T) {
+	testHomeDir := t.TempDir()
+	t.Setenv("JFROG_CLI_HOME_DIR", testHomeDir)
+	aliasDir := filepath.Join(testHomeDir, "package-alias")
+	binDir := filepath.Join(aliasDir, "bin")
+	require.NoError(t, NewInstallCommand("mvn").Run())
+
+	// A non-empty directory in place of the helm alias can't be replaced.
+	require.NoError(t, os.MkdirAll(filepath.Join(binDir, addExecutableSuffix("helm"), "keep"), 0755))
+	assert.Error(t, NewAddToolsCommand("uv,helm").Run())
+
+	config, err := loadConfig(aliasDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mvn"}, config.EnabledTools)
+	assert.NotContains(t, config.ToolModes, "uv")
+	assertAliasExists(t, binDir, "uv", false)
+	assertAliasExists(t, binDir, "mvn", true)
+}
+
+func TestAddToolsRefusesAnotherJfBinary(t *testing.T) {
+	testHomeDir := t.TempDir()
+	t.Setenv("JFROG_CLI_HOME_DIR", testHomeDir)
+	aliasDir := filepath.Join(testHomeDir, "package-alias")
+	binDir := filepath.Join(aliasDir, "bin")
+	require.NoError(t, NewInstallCommand("mvn").Run())
+
+	config, err := loadConfig(aliasDir)
+	require.NoError(t, err)
+	installedHash := config.JfBinarySHA256
+	require.NotEmpty(t, installedHash)
+	config.JfBinarySHA256 = "another-binary"
+	require.NoError(t, writeConfig(aliasDir, config))
+
+	assert.ErrorContains(t, NewAddToolsCommand("npm").Run(), "jf package-alias install")
+	assertAliasExists(t, binDir, "npm", false)
+
+	// An install from before the hash was recorded gets it from add-tools.
+	config.JfBinarySHA256 = ""
+	require.NoError(t, writeConfig(aliasDir, config))
+	require.NoError(t, NewAddToolsCommand("npm").Run())
+	config, err = loadConfig(aliasDir)
+	require.NoError(t, err)
+	assert.Equal(t, installedHash, config.JfBinarySHA256)
+	assertAliasExists(t, binDir, "npm", true)
+}
+
+func TestRemoveToolsRestoresAliasesOnFailure(t *testing.T) {
+	testHomeDir := t.TempDir()
+	t.Setenv("JFROG_CLI_HOME_DIR", testHomeDir)
+	aliasDir := filepath.Join(testHomeDir, "package-alias")
+	binDir := filepath.Join(aliasDir, "bin")
+	require.NoError(t, NewInstallCommand("mvn,npm,uv").Run())
+
+	// A non-empty directory where the npm alias is moved aside fails its removal.
+	npmAliasPath := filepath.Join(binDir, addExecutableSuffix("npm"))
+	require.NoError(t, os.MkdirAll(filepath.Join(npmAliasPath+removedAliasSuffix, "keep"), 0755))
+	assert.Error(t, NewRemoveToolsCommand("mvn,npm").Run())
+
+	config, err := loadConfig(aliasDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mvn", "npm", "uv"}, config.EnabledTools)
+	assertAliasExists(t, binDir, "mvn", true)
+	assertAliasExists(t, binDir, "npm", true)
+	_, err = os.Lstat(filepath.Join(binDir, addExecutableSuffix("mvn")+removedAliasSuffix))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func assertAliasExists(t *testing.T, binDir, tool string, expected bool) {
+	aliasPath := filepath.Join(binDir, tool)
+	if runtime.GOOS == "windows" {
+		aliasPath += ".exe"
+	}
+	_, err := os.Lstat(aliasPath)
+	assert.Equal(t, expected, err == nil, aliasPath)
+}
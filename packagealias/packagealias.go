@@ -34,6 +34,17 @@ var SupportedTools = []string{
 	"gem",
 	"bundle",
 	"apk",
+	"cargo",
+	"conda",
+	"uv",
+	"helm",
+	"terraform",
+	"twine",
+	"conan",
+	"nix",
+	"nix-build",
+	"nix-channel",
+	"nix-env",
 }
 
 // AliasMode represents how a tool should be handled
@@ -53,6 +64,15 @@ type Config struct {
 	SubcommandModes map[string]AliasMode `json:"subcommand_modes,omitempty" yaml:"subcommand_modes,omitempty"`
 	EnabledTools    []string             `json:"enabled_tools,omitempty" yaml:"enabled_tools,omitempty"`
 	JfBinarySHA256  string               `json:"jf_binary_sha256,omitempty" yaml:"jf_binary_sha256,omitempty"`
+
+	// The fields below are set by loadEffectiveConfig and never written.
+
+	// ProjectConfigPath is the project policy file merged over the global config
+	ProjectConfigPath string `json:"-" yaml:"-"`
+	// EnabledSource is the file that set Enabled
+	EnabledSource string `json:"-" yaml:"-"`
+	// modeSources maps tool and subcommand policies to the file that set them
+	modeSources map[string]string
 }
 
 // GetAliasHomeDir returns the base package-alias directory
@@ -0,0 +1,138 @@
+package packagealias
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jfrog/jfrog-cli-core/v2/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	projectConfigDir  = ".jfrog"
+	projectConfigFile = "package-alias.yaml"
+
+	// defaultModeSource is reported as the source of modes no config file sets
+	defaultModeSource = "default"
+)
+
+// ProjectConfig holds the per-project policies of a .jfrog/package-alias.yaml
+// file, merged over the global Config. Aliases are installed globally, so a
+// project can't change which tools are aliased.
+type ProjectConfig struct {
+	Enabled         *bool                `yaml:"enabled,omitempty"`
+	ToolModes       map[string]AliasMode `yaml:"tool_modes,omitempty"`
+	SubcommandModes map[string]AliasMode `yaml:"subcommand_modes,omitempty"`
+}
+
+// findProjectConfigPath walks up from startDir looking for a
+// .jfrog/package-alias.yaml file and returns its path, or "" if there is
+// none. The JFrog CLI home directory, usually ~/.jfrog, is not a project.
+func findProjectConfigPath(startDir string) string {
+	jfrogHomeDir, err := coreutils.GetJfrogHomeDir()
+	if err == nil {
+		jfrogHomeDir = filepath.Clean(jfrogHomeDir)
+	}
+	dir := filepath.Clean(startDir)
+	for {
+		configDir := filepath.Join(dir, projectConfigDir)
+		if !pathsEqual(configDir, jfrogHomeDir) {
+			candidate := filepath.Join(configDir, projectConfigFile)
+			if info, statErr := os.Stat(candidate); statErr == nil && !info.IsDir() {
+				return candidate
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func loadProjectConfig(path string) (*ProjectConfig, error) {
+	// #nosec G304 -- path is a discovered .jfrog/package-alias.yaml file
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	projectConfig := &ProjectConfig{}
+	if err = yaml.Unmarshal(data, projectConfig); err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("failed parsing %s: %w", path, err))
+	}
+	return projectConfig, nil
+}
+
+// loadEffectiveConfig returns the global config merged with the project
+// policy file found from the working directory, if any, recording which file
+// set each mode. It is only meant for deciding modes: the global config is
+// written with loadConfig's result, never with this one.
+func loadEffectiveConfig(aliasDir string) (*Config, error) {
+	config, err := loadConfig(aliasDir)
+	if err != nil {
+		return nil, err
+	}
+	config.EnabledSource = defaultModeSource
+	globalPath := getConfigPath(aliasDir)
+	if _, statErr := os.Stat(globalPath); statErr == nil {
+		config.EnabledSource = globalPath
+		config.setModeSources(globalPath, config.ToolModes, config.SubcommandModes)
+	}
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	projectPath := findProjectConfigPath(workingDir)
+	if projectPath == "" {
+		return config, nil
+	}
+	projectConfig, err := loadProjectConfig(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	config.ProjectConfigPath = projectPath
+	if projectConfig.Enabled != nil {
+		config.Enabled = *projectConfig.Enabled
+		config.EnabledSource = projectPath
+	}
+	for key, mode := range projectConfig.ToolModes {
+		config.ToolModes[key] = mode
+	}
+	for key, mode := range projectConfig.SubcommandModes {
+		config.SubcommandModes[key] = mode
+	}
+	config.setModeSources(projectPath, projectConfig.ToolModes, projectConfig.SubcommandModes)
+	return config, nil
+}
+
+func (c *Config) setModeSources(source string, toolModes, subcommandModes map[string]AliasMode) {
+	if c.modeSources == nil {
+		c.modeSources = make(map[string]string)
+	}
+	for key := range toolModes {
+		c.modeSources[toolModePolicy(key)] = source
+	}
+	for key := range subcommandModes {
+		c.modeSources[subcommandModePolicy(key)] = source
+	}
+}
+
+// getModeSource returns the file that set a policy, as returned by
+// resolveToolMode.
+func (c *Config) getModeSource(policy string) string {
+	if source, found := c.modeSources[policy]; found {
+		return source
+	}
+	return defaultModeSource
+}
+
+func toolModePolicy(key string) string {
+	return "tool_modes." + key
+}
+
+func subcommandModePolicy(key string) string {
+	return "subcommand_modes." + key
+}
@@ -0,0 +1,136 @@
+package packagealias
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeProjectConfig(t *testing.T, projectDir, content string) string {
+	t.Helper()
+	configDir := filepath.Join(projectDir, projectConfigDir)
+	require.NoError(t, os.MkdirAll(configDir, 0755))
+	path := filepath.Join(configDir, projectConfigFile)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestFindProjectConfigPathWalksUp(t *testing.T) {
+	t.Setenv("JFROG_CLI_HOME_DIR", t.TempDir())
+	projectDir := t.TempDir()
+	nestedDir := filepath.Join(projectDir, "services", "api")
+	require.NoError(t, os.MkdirAll(nestedDir, 0755))
+
+	require.Empty(t, findProjectConfigPath(nestedDir))
+
+	path := writeProjectConfig(t, projectDir, "tool_modes:\n  npm: pass\n")
+	require.Equal(t, path, findProjectConfigPath(nestedDir))
+	require.Equal(t, path, findProjectConfigPath(projectDir))
+
+	nestedPath := writeProjectConfig(t, nestedDir, "enabled: false\n")
+	require.Equal(t, nestedPath, findProjectConfigPath(nestedDir))
+}
+
+func TestFindProjectConfigPathSkipsJFrogHomeDir(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("JFROG_CLI_HOME_DIR", filepath.Join(homeDir, projectConfigDir))
+	writeProjectConfig(t, homeDir, "enabled: false\n")
+	projectDir := filepath.Join(homeDir, "src", "app")
+	require.NoError(t, os.MkdirAll(projectDir, 0755))
+
+	require.Empty(t, findProjectConfigPath(projectDir))
+}
+
+func TestLoadEffectiveConfigMergesProjectPolicies(t *testing.T) {
+	testHomeDir := t.TempDir()
+	t.Setenv("JFROG_CLI_HOME_DIR", testHomeDir)
+	aliasDir := filepath.Join(testHomeDir, "package-alias")
+	require.NoError(t, os.MkdirAll(aliasDir, 0755))
+	require.NoError(t, writeConfig(aliasDir, &Config{
+		Enabled: true,
+		ToolModes: map[string]AliasMode{
+			"npm": ModeJF,
+			"mvn": ModeJF,
+		},
+		SubcommandModes: map[string]AliasMode{
+			"go.mod.tidy": ModePass,
+		},
+		EnabledTools: []string{"npm", "mvn", "go"},
+	}))
+	globalPath := getConfigPath(aliasDir)
+
+	projectDir := t.TempDir()
+	projectPath := writeProjectConfig(t, projectDir, `enabled: false
+tool_modes:
+  npm: pass
+subcommand_modes:
+  go.mod: pass
+enabled_tools:
+  - docker
+`)
+	nestedDir := filepath.Join(projectDir, "web")
+	require.NoError(t, os.MkdirAll(nestedDir, 0755))
+	t.Chdir(nestedDir)
+
+	config, err := loadEffectiveConfig(aliasDir)
+	require.NoError(t, err)
+	require.Equal(t, projectPath, config.ProjectConfigPath)
+	require.False(t, config.Enabled)
+	require.Equal(t, projectPath, config.EnabledSource)
+	require.Equal(t, []string{"npm", "mvn", "go"}, config.EnabledTools, "a project can't change which tools are aliased")
+
+	mode, policy := resolveToolMode(config, "npm", nil)
+	require.Equal(t, ModePass, mode)
+	require.Equal(t, projectPath, config.getModeSource(policy))
+
+	mode, policy = resolveToolMode(config, "mvn", nil)
+	require.Equal(t, ModeJF, mode)
+	require.Equal(t, globalPath, config.getModeSource(policy))
+
+	mode, policy = resolveToolMode(config, "go", []string{"mod", "tidy"})
+	require.Equal(t, ModePass, mode)
+	require.Equal(t, globalPath, config.getModeSource(policy))
+
+	mode, policy = resolveToolMode(config, "go", []string{"mod", "download"})
+	require.Equal(t, ModePass, mode)
+	require.Equal(t, projectPath, config.getModeSource(policy))
+
+	mode, policy = resolveToolMode(config, "pnpm", nil)
+	require.Equal(t, ModePass, mode)
+	require.Equal(t, defaultModeSource, config.getModeSource(policy))
+
+	require.Equal(t, ModePass, getToolMode("npm", []string{"install"}))
+	require.False(t, isEnabled())
+
+	global, err := loadConfig(aliasDir)
+	require.NoError(t, err)
+	require.Equal(t, ModeJF, global.ToolModes["npm"], "the global config is left untouched")
+	require.True(t, global.Enabled)
+}
+
+func TestLoadEffectiveConfigWithoutProjectPolicy(t *testing.T) {
+	testHomeDir := t.TempDir()
+	t.Setenv("JFROG_CLI_HOME_DIR", testHomeDir)
+	t.Chdir(t.TempDir())
+
+	config, err := loadEffectiveConfig(filepath.Join(testHomeDir, "package-alias"))
+	require.NoError(t, err)
+	require.Empty(t, config.ProjectConfigPath)
+	require.True(t, config.Enabled)
+	require.Equal(t, defaultModeSource, config.EnabledSource)
+}
+
+func TestLoadEffectiveConfigRejectsInvalidProjectPolicy(t *testing.T) {
+	testHomeDir := t.TempDir()
+	t.Setenv("JFROG_CLI_HOME_DIR", testHomeDir)
+	projectDir := t.TempDir()
+	projectPath := writeProjectConfig(t, projectDir, "tool_modes: [npm\n")
+	t.Chdir(projectDir)
+
+	_, err := loadEffectiveConfig(filepath.Join(testHomeDir, "package-alias"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), projectPath)
+	require.Equal(t, ModeJF, getToolMode("npm", nil), "an invalid policy falls back to the default mode")
+}
@@ -43,10 +43,23 @@ func (sc *StatusCommand) Run() error {
 	log.Info("Status: INSTALLED")
 	log.Info(fmt.Sprintf("Location: %s", binDir))
 
+	// Load the configuration, merged with the current project's policy file
+	aliasDir, _ := GetAliasHomeDir()
+	cfg, cfgErr := loadEffectiveConfig(aliasDir)
+	if cfgErr != nil {
+		log.Warn(fmt.Sprintf("Failed loading config for status: %v", cfgErr))
+		cfg = newDefaultConfig()
+	}
+	if cfg.ProjectConfigPath != "" {
+		log.Info(fmt.Sprintf("Project policy: %s", cfg.ProjectConfigPath))
+	}
+
 	// Check if enabled
-	enabled := isEnabled()
+	enabled := cfgErr == nil && cfg.Enabled
 	if enabled {
-		log.Info("State: ENABLED")
+		log.Info(fmt.Sprintf("State: ENABLED (%s)", cfg.EnabledSource))
+	} else if cfgErr == nil {
+		log.Info(fmt.Sprintf("State: DISABLED (%s)", cfg.EnabledSource))
 	} else {
 		log.Info("State: DISABLED")
 	}
@@ -64,16 +77,10 @@ func (sc *StatusCommand) Run() error {
 		}
 	}
 
-	// Load and display configuration
+	// Display configuration
 	log.Info("\nTool Configuration:")
-	aliasDir, _ := GetAliasHomeDir()
-	cfg, cfgErr := loadConfig(aliasDir)
-	if cfgErr != nil {
-		log.Warn(fmt.Sprintf("Failed loading config for status: %v", cfgErr))
-		cfg = newDefaultConfig()
-	}
 	for _, tool := range getConfiguredTools(cfg) {
-		mode := getModeForTool(cfg, tool, nil)
+		mode, policy := resolveToolMode(cfg, tool, nil)
 
 		// Check if alias exists
 		aliasPath := filepath.Join(binDir, addExecutableSuffix(tool))
@@ -88,7 +95,7 @@ func (sc *StatusCommand) Run() error {
 			realExists = "[MISSING]"
 		}
 
-		log.Info(fmt.Sprintf("  %-10s mode=%-5s alias=%s real=%s", tool, mode, aliasExists, realExists))
+		log.Info(fmt.Sprintf("  %-12s mode=%-5s alias=%s real=%s source=%s", tool, mode, aliasExists, realExists, cfg.getModeSource(policy)))
 		for _, detail := range getStatusModeDetails(cfg, tool) {
 			log.Info(fmt.Sprintf("    %s", detail))
 		}
@@ -211,24 +218,31 @@ func parseWindowsPathExtensions(pathExtValue string) []string {
 }
 
 func getStatusModeDetails(config *Config, tool string) []string {
-	if tool != "go" {
-		return nil
+	if _, isNativeOnly := nativeOnlyTools[tool]; isNativeOnly {
+		return []string{"no JFrog CLI integration, always runs natively"}
 	}
 	config = normalizeConfig(config)
+	var modeDetails []string
+	if _, hasPrefix := jfCommandPrefixes[tool]; hasPrefix && getModeForTool(config, tool, nil) == ModeJF {
+		modeDetails = append(modeDetails, fmt.Sprintf("runs as 'jf %s'", strings.Join(getJFCommand(tool), " ")))
+	}
 	policyKeys := make([]string, 0, len(config.SubcommandModes))
 	for policyKey := range config.SubcommandModes {
-		if strings.HasPrefix(policyKey, "go.") {
+		if strings.HasPrefix(policyKey, tool+".") {
 			policyKeys = append(policyKeys, policyKey)
 		}
 	}
-	if len(policyKeys) == 0 {
-		return nil
-	}
 	sort.Strings(policyKeys)
-	modeDetails := make([]string, 0, len(policyKeys))
 	for _, policyKey := range policyKeys {
-		mode := getModeForTool(config, "go", strings.Split(strings.TrimPrefix(policyKey, "go."), "."))
-		modeDetails = append(modeDetails, fmt.Sprintf("%s mode=%s", policyKey, mode))
+		mode := config.SubcommandModes[policyKey]
+		detail := fmt.Sprintf("%s mode=%s", policyKey, mode)
+		if !validateAliasMode(mode) {
+			detail += " (invalid, ignored)"
+		}
+		if source := config.getModeSource(subcommandModePolicy(policyKey)); source != defaultModeSource {
+			detail += " source=" + source
+		}
+		modeDetails = append(modeDetails, detail)
 	}
 	return modeDetails
 }
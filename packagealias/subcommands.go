@@ -0,0 +1,179 @@
+package packagealias
+
+import (
+	"slices"
+	"strings"
+)
+
+// subcommandGrammar describes how a tool's arguments name the subcommand its
+// policy keys are derived from.
+type subcommandGrammar struct {
+	// valueFlags are the flags that take their value as the next argument
+	// (e.g. docker --context x), which must be skipped to find the
+	// subcommand. For a subcommand path, only those given before it matter.
+	valueFlags []string
+	// goals is set for tools whose arguments are a list of goals or tasks,
+	// mixed with flags (e.g. mvn -B clean install), rather than a subcommand
+	// path (e.g. docker image push).
+	goals bool
+	// aliases maps a tool's subcommand aliases to the name policies use
+	// (e.g. npm i to npm install).
+	aliases map[string]string
+	// operations is set for tools whose operation is selected by a flag
+	// rather than a subcommand (e.g. nix-env -iA), mapping the flag to the
+	// operation's name. Combined short flags are matched by their first
+	// letter (nix-env -iA is nix-env -i).
+	operations map[string]string
+}
+
+// subcommandGrammars holds the grammar of the tools whose arguments aren't a
+// plain subcommand path, starting right away.
+var subcommandGrammars = map[string]subcommandGrammar{
+	"mvn": {
+		valueFlags: []string{"-f", "--file", "-s", "--settings", "-gs", "--global-settings", "-t", "--toolchains",
+			"-pl", "--projects", "-rf", "--resume-from", "-P", "--activate-profiles", "-D", "--define",
+			"-l", "--log-file", "-T", "--threads", "-b", "--builder"},
+		goals: true,
+	},
+	"gradle": {
+		valueFlags: []string{"-p", "--project-dir", "-b", "--build-file", "-c", "--settings-file", "-g", "--gradle-user-home",
+			"-I", "--init-script", "-x", "--exclude-task", "-D", "--system-prop", "-P", "--project-prop"},
+		goals: true,
+	},
+	"npm": {
+		valueFlags: []string{"--prefix", "--registry", "--userconfig", "--cache", "-w", "--workspace"},
+		aliases: map[string]string{
+			"i": "install", "in": "install", "add": "install",
+			"un": "uninstall", "rm": "uninstall", "r": "uninstall", "remove": "uninstall",
+			"run-script": "run", "t": "test", "tst": "test", "x": "exec",
+		},
+	},
+	"yarn": {
+		valueFlags: []string{"--cwd"},
+	},
+	"pnpm": {
+		valueFlags: []string{"-C", "--dir", "-F", "--filter"},
+		aliases: map[string]string{
+			"i": "install", "rm": "remove", "un": "remove", "uninstall": "remove", "run-script": "run", "t": "test",
+		},
+	},
+	"go": {
+		valueFlags: []string{"-C"},
+	},
+	"pip": {
+		valueFlags: []string{"--proxy", "--cache-dir", "--log", "--python", "--timeout", "--retries", "--cert", "--client-cert"},
+	},
+	"poetry": {
+		valueFlags: []string{"-C", "--directory", "-P", "--project"},
+	},
+	"docker": {
+		valueFlags: []string{"--config", "-c", "--context", "-H", "--host", "-l", "--log-level", "--tlscacert", "--tlscert", "--tlskey"},
+	},
+	"helm": {
+		valueFlags: []string{"--kube-context", "--kubeconfig", "-n", "--namespace", "--registry-config",
+			"--repository-config", "--repository-cache", "--burst-limit", "--qps"},
+	},
+	"apk": {
+		valueFlags: []string{"-X", "--repository", "-p", "--root", "--arch", "--cache-dir", "--keys-dir", "--repositories-file"},
+	},
+	"uv": {
+		valueFlags: []string{"--directory", "--project", "--cache-dir", "--config-file", "--color"},
+	},
+	"nix": {
+		valueFlags: []string{"--extra-experimental-features", "--experimental-features", "--store"},
+	},
+	"nix-env": {
+		operations: map[string]string{
+			"-i": "install", "--install": "install", "-e": "uninstall", "--uninstall": "uninstall",
+			"-u": "upgrade", "--upgrade": "upgrade", "-q": "query", "--query": "query",
+			"--set": "set", "--rollback": "rollback", "--switch-generation": "switch-generation",
+		},
+	},
+	"nix-channel": {
+		operations: map[string]string{
+			"--add": "add", "--remove": "remove", "--list": "list", "--update": "update", "--rollback": "rollback",
+		},
+	},
+}
+
+// getSubcommandPolicyKeys returns the policy keys an invocation of tool is
+// matched against, from the most specific to the tool itself, e.g.
+// "npm.run.build", "npm.run", "npm" for npm run build.
+//
+// Leading global flags, and the values of those in the tool's valueFlags, are
+// skipped. A tool selecting its operation with a flag, such as nix-env, has
+// a key for that operation only. For a subcommand path, the words up to the first flag after it
+// make a key each, the longest first. For the goals of mvn and gradle, every
+// goal makes a key, the last first, since later Maven phases include the
+// earlier ones.
+func getSubcommandPolicyKeys(tool string, args []string) []string {
+	grammar := subcommandGrammars[tool]
+	if grammar.operations != nil {
+		if operation := getOperation(grammar.operations, args); operation != "" {
+			return []string{tool + "." + operation, tool}
+		}
+		return []string{tool}
+	}
+	var words []string
+	for index := 0; index < len(args); index++ {
+		arg := args[index]
+		if arg == "--" {
+			break
+		}
+		if isFlagArg(arg) {
+			if len(words) > 0 && !grammar.goals {
+				break
+			}
+			if slices.Contains(grammar.valueFlags, arg) {
+				index++
+			}
+			continue
+		}
+		word := strings.ToLower(arg)
+		if len(words) == 0 {
+			if alias, found := grammar.aliases[word]; found {
+				word = alias
+			}
+		}
+		words = append(words, word)
+	}
+
+	keys := make([]string, 0, len(words)+1)
+	if grammar.goals {
+		for index := len(words) - 1; index >= 0; index-- {
+			if key := tool + "." + words[index]; !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	} else {
+		for index := len(words); index >= 1; index-- {
+			keys = append(keys, tool+"."+strings.Join(words[:index], "."))
+		}
+	}
+	return append(keys, tool)
+}
+
+// getOperation returns the operation selected by the first operation flag of
+// args, or "" if there is none.
+func getOperation(operations map[string]string, args []string) string {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if operation, found := operations[arg]; found {
+			return operation
+		}
+		if len(arg) > 2 && arg[0] == '-' && arg[1] != '-' {
+			if operation, found := operations[arg[:2]]; found {
+				return operation
+			}
+		}
+	}
+	return ""
+}
+
+// isFlagArg reports whether arg is a flag, including cargo's +toolchain
+// selector.
+func isFlagArg(arg string) bool {
+	return strings.HasPrefix(arg, "-") || strings.HasPrefix(arg, "+")
+}
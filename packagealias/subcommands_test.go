@@ -0,0 +1,116 @@
+package packagealias
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSubcommandPolicyKeys(t *testing.T) {
+	cases := []struct {
+		tool     string
+		args     []string
+		expected []string
+	}{
+		{"go", nil, []string{"go"}},
+		{"go", []string{"-v"}, []string{"go"}},
+		{"go", []string{"build", "./..."}, []string{"go.build../...", "go.build", "go"}},
+		{"go", []string{"-C", "cmd", "mod", "tidy"}, []string{"go.mod.tidy", "go.mod", "go"}},
+		{"npm", []string{"publish", "--tag", "beta"}, []string{"npm.publish", "npm"}},
+		{"npm", []string{"run", "build", "--", "--watch"}, []string{"npm.run.build", "npm.run", "npm"}},
+		{"npm", []string{"i", "lodash"}, []string{"npm.install.lodash", "npm.install", "npm"}},
+		{"npm", []string{"--prefix", "web", "ci"}, []string{"npm.ci", "npm"}},
+		{"npm", []string{"--silent", "test"}, []string{"npm.test", "npm"}},
+		{"pnpm", []string{"--filter", "app", "i"}, []string{"pnpm.install", "pnpm"}},
+		{"yarn", []string{"--cwd", "web", "add", "react"}, []string{"yarn.add.react", "yarn.add", "yarn"}},
+		{"mvn", []string{"-B", "clean", "install"}, []string{"mvn.install", "mvn.clean", "mvn"}},
+		{"mvn", []string{"clean", "-s", "settings.xml", "deploy", "-DskipTests"}, []string{"mvn.deploy", "mvn.clean", "mvn"}},
+		{"mvn", []string{"-f", "clean/pom.xml", "-P", "release", "verify"}, []string{"mvn.verify", "mvn"}},
+		{"gradle", []string{"clean", "build", "-x", "test", "publish"}, []string{"gradle.publish", "gradle.build", "gradle.clean", "gradle"}},
+		{"gradle", []string{"build", "--offline", "build"}, []string{"gradle.build", "gradle"}},
+		{"docker", []string{"--context", "remote", "build", "-t", "app", "."}, []string{"docker.build", "docker"}},
+		{"docker", []string{"-H", "tcp://host:2375", "exec", "-it", "app", "sh"}, []string{"docker.exec", "docker"}},
+		{"docker", []string{"image", "push", "repo/app:1"}, []string{"docker.image.push.repo/app:1", "docker.image.push", "docker.image", "docker"}},
+		{"docker", []string{"--debug", "buildx", "build", "--push", "."}, []string{"docker.buildx.build", "docker.buildx", "docker"}},
+		{"pip", []string{"--proxy", "http://proxy:3128", "install", "-r", "requirements.txt"}, []string{"pip.install", "pip"}},
+		{"poetry", []string{"-C", "svc", "publish"}, []string{"poetry.publish", "poetry"}},
+		{"dotnet", []string{"nuget", "push", "pkg.nupkg"}, []string{"dotnet.nuget.push.pkg.nupkg", "dotnet.nuget.push", "dotnet.nuget", "dotnet"}},
+		{"helm", []string{"-n", "prod", "--kube-context", "east", "upgrade", "app", "./chart"}, []string{"helm.upgrade.app../chart", "helm.upgrade.app", "helm.upgrade", "helm"}},
+		{"apk", []string{"--root", "/sysroot", "add", "curl"}, []string{"apk.add.curl", "apk.add", "apk"}},
+		{"uv", []string{"--directory", "svc", "pip", "install", "-e", "."}, []string{"uv.pip.install", "uv.pip", "uv"}},
+		{"nix", []string{"--extra-experimental-features", "nix-command flakes", "build"}, []string{"nix.build", "nix"}},
+		{"nix-env", []string{"-iA", "nixpkgs.hello"}, []string{"nix-env.install", "nix-env"}},
+		{"nix-env", []string{"--profile", "/nix/var/profile", "--query", "--installed"}, []string{"nix-env.query", "nix-env"}},
+		{"nix-env", []string{"--version"}, []string{"nix-env"}},
+		{"nix-channel", []string{"--add", "https://example.com/channel", "nixpkgs"}, []string{"nix-channel.add", "nix-channel"}},
+		{"cargo", []string{"+nightly", "build", "--release"}, []string{"cargo.build", "cargo"}},
+		{"terraform", []string{"-chdir=infra", "init"}, []string{"terraform.init", "terraform"}},
+		{"gem", []string{"PUSH", "pkg.gem"}, []string{"gem.push.pkg.gem", "gem.push", "gem"}},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.expected, getSubcommandPolicyKeys(tc.tool, tc.args), "%s %v", tc.tool, tc.args)
+	}
+}
+
+func TestGetModeForToolUsesSubcommandPolicies(t *testing.T) {
+	config := &Config{
+		ToolModes: map[string]AliasMode{
+			"npm":    ModeJF,
+			"docker": ModePass,
+			"mvn":    ModePass,
+		},
+		SubcommandModes: map[string]AliasMode{
+			"npm.publish":  ModeJF,
+			"npm.run":      ModePass,
+			"docker.build": ModeJF,
+			"docker.exec":  ModePass,
+			"mvn.deploy":   ModeJF,
+			"mvn.clean":    ModePass,
+			"pnpm.publish": ModeJF,
+		},
+	}
+
+	require.Equal(t, ModeJF, getModeForTool(config, "npm", []string{"publish"}))
+	require.Equal(t, ModePass, getModeForTool(config, "npm", []string{"run", "build"}))
+	require.Equal(t, ModeJF, getModeForTool(config, "npm", []string{"install"}))
+
+	require.Equal(t, ModeJF, getModeForTool(config, "docker", []string{"--context", "x", "build", "."}))
+	require.Equal(t, ModePass, getModeForTool(config, "docker", []string{"exec", "-it", "app", "sh"}))
+	require.Equal(t, ModePass, getModeForTool(config, "docker", []string{"ps"}))
+
+	require.Equal(t, ModeJF, getModeForTool(config, "mvn", []string{"-B", "clean", "deploy"}))
+	require.Equal(t, ModePass, getModeForTool(config, "mvn", []string{"clean"}))
+	require.Equal(t, ModePass, getModeForTool(config, "mvn", []string{"validate"}))
+
+	// A subcommand policy overrides the tool's default mode too.
+	require.Equal(t, ModeJF, getModeForTool(config, "pnpm", []string{"publish"}))
+	require.Equal(t, ModePass, getModeForTool(config, "pnpm", []string{"install"}))
+}
+
+func TestResolveToolModeReportsDecidingPolicy(t *testing.T) {
+	config := &Config{
+		ToolModes: map[string]AliasMode{
+			"npm":         ModeJF,
+			"npm.install": ModePass,
+		},
+		SubcommandModes: map[string]AliasMode{
+			"npm.run":       ModePass,
+			"npm.run.build": AliasMode("invalid"),
+		},
+	}
+	mode, policy := resolveToolMode(config, "npm", []string{"run", "build"})
+	require.Equal(t, ModePass, mode)
+	require.Equal(t, "subcommand_modes.npm.run", policy)
+
+	mode, policy = resolveToolMode(config, "npm", []string{"i"})
+	require.Equal(t, ModePass, mode)
+	require.Equal(t, "tool_modes.npm.install", policy)
+
+	mode, policy = resolveToolMode(config, "npm", []string{"publish"})
+	require.Equal(t, ModeJF, mode)
+	require.Equal(t, "tool_modes.npm", policy)
+
+	mode, policy = resolveToolMode(config, "yarn", []string{"publish"})
+	require.Equal(t, ModeJF, mode)
+	require.Empty(t, policy)
+}
@@ -12,13 +12,14 @@ import (
 // reordering and hands args straight to the stdlib flag package, which stops
 // parsing at the first non-flag token. That makes "jf api <path> --flag" fail
 // with a bogus argument-count error, even though it's the documented usage.
-// "jf api docs search/describe" are unaffected (they're leaf commands, so
-// urfave/cli reorders their flags on its own) and must be left untouched here.
+// "jf api batch" and "jf api docs search/describe/validate" are unaffected
+// (they're leaf commands, so urfave/cli reorders their flags on its own) and
+// must be left untouched here.
 func NormalizeApiTrailingFlags(argv []string) []string {
 	if len(argv) < 3 || argv[1] != "api" {
 		return argv
 	}
-	if len(argv) >= 3 && argv[2] == "docs" {
+	if len(argv) >= 3 && (argv[2] == "docs" || argv[2] == "batch") {
 		return argv
 	}
 
@@ -63,6 +63,16 @@ func TestNormalizeApiTrailingFlags(t *testing.T) {
 			argv: []string{"jf", "api", "docs", "describe", "GET", "/access/api/v2/users", "--format=json"},
 			want: []string{"jf", "api", "docs", "describe", "GET", "/access/api/v2/users", "--format=json"},
 		},
+		{
+			name: "docs validate: untouched",
+			argv: []string{"jf", "api", "docs", "validate", "POST", "/access/api/v2/users", "-d", "{}"},
+			want: []string{"jf", "api", "docs", "validate", "POST", "/access/api/v2/users", "-d", "{}"},
+		},
+		{
+			name: "batch: untouched",
+			argv: []string{"jf", "api", "batch", "requests.ndjson", "--threads=8"},
+			want: []string{"jf", "api", "batch", "requests.ndjson", "--threads=8"},
+		},
 		{
 			name: "bare docs: untouched",
 			argv: []string{"jf", "api", "docs"},
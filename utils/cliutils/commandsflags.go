@@ -142,11 +142,20 @@ const (
 	Api               = "api"
 	ApiDocsSearch     = "api-docs-search"
 	ApiDocsDescribe   = "api-docs-describe"
+	ApiDocsValidate   = "api-docs-validate"
+	ApiDocsSync       = "api-docs-sync"
+	ApiDocsTags       = "api-docs-tags"
+	ApiDocsList       = "api-docs-list"
+	ApiDocsDiff       = "api-docs-diff"
+	ApiBatch          = "api-batch"
 
 	// MCP commands keys
 	McpShow      = "mcp-show"
 	McpInstall   = "mcp-install"
 	McpUninstall = "mcp-uninstall"
+	McpList      = "mcp-list"
+	McpServe     = "mcp-serve"
+	McpDoctor    = "mcp-doctor"
 
 	// Plugin commands keys
 	PluginInstall = "plugin-install"
@@ -672,31 +681,74 @@ const (
 	RepoKey              = "repo-key"
 
 	// API command flags
-	apiHeader  = "api-header"
-	apiInput   = "api-input"
-	apiData    = "api-data"
-	apiMethod  = "api-method"
-	apiVerbose = "api-verbose"
-	apiTimeout = "api-timeout"
+	apiHeader          = "api-header"
+	apiInput           = "api-input"
+	apiData            = "api-data"
+	apiMethod          = "api-method"
+	apiVerbose         = "api-verbose"
+	apiTimeout         = "api-timeout"
+	apiPaginate        = "api-paginate"
+	apiNdjson          = "api-ndjson"
+	apiMaxPages        = "api-max-pages"
+	apiRetries         = "api-retries"
+	apiRetryWait       = "api-retry-wait"
+	apiRetryAllMethods = "api-retry-all-methods"
+	apiQuery           = "api-query"
+	apiRaw             = "api-raw"
+	apiValidate        = "api-validate"
+	apiPrintCurl       = "api-print-curl"
+	apiExport          = "api-export"
+	apiRecord          = "api-record"
+	apiReplay          = "api-replay"
+	apiForm            = "api-form"
+	apiOutput          = "api-output"
+	apiOp              = "api-op"
+	apiParam           = "api-param"
+
+	// API batch command flags
+	apiBatchFailFast = "api-batch-fail-fast"
 
 	// API docs search command flags
-	apiDocsSearchTag    = "api-docs-search-tag"
-	apiDocsSearchMethod = "api-docs-search-method"
-	apiDocsSearchLimit  = "api-docs-search-limit"
-	apiDocsSearchFormat = "api-docs-search-format"
+	apiDocsSearchTag     = "api-docs-search-tag"
+	apiDocsSearchMethod  = "api-docs-search-method"
+	apiDocsSearchLimit   = "api-docs-search-limit"
+	apiDocsSearchFormat  = "api-docs-search-format"
+	apiDocsSearchExplain = "api-docs-search-explain"
 
 	// API docs describe command flags
 	apiDocsDescribeFormat = "api-docs-describe-format"
 
+	// API docs validate command flags
+	apiDocsValidateInput  = "api-docs-validate-input"
+	apiDocsValidateData   = "api-docs-validate-data"
+	apiDocsValidateFormat = "api-docs-validate-format"
+
+	// API docs tags and list command flags
+	apiDocsTagsFormat = "api-docs-tags-format"
+	apiDocsListTag    = "api-docs-list-tag"
+	apiDocsListFormat = "api-docs-list-format"
+
+	// API docs diff command flags
+	apiDocsDiffFormat = "api-docs-diff-format"
+
+	// API docs sync command flags
+	apiDocsSyncSpecPath = "api-docs-sync-spec-path"
+
 	// MCP command flags
-	mcpUrl        = "mcp-url"
-	mcpAgent      = "mcp-agent"
-	mcpGlobal     = "mcp-global"
-	mcpProjectDir = "mcp-project-dir"
-	mcpName       = "mcp-name"
-	mcpDryRun     = "mcp-dry-run"
-	mcpSkipCheck  = "mcp-skip-check"
-	mcpShowFormat = "mcp-show-format"
+	mcpUrl          = "mcp-url"
+	mcpAgent        = "mcp-agent"
+	mcpGlobal       = "mcp-global"
+	mcpProjectDir   = "mcp-project-dir"
+	mcpName         = "mcp-name"
+	mcpDryRun       = "mcp-dry-run"
+	mcpSkipCheck    = "mcp-skip-check"
+	mcpShowFormat   = "mcp-show-format"
+	mcpShowAgent    = "mcp-show-agent"
+	mcpListFormat   = "mcp-list-format"
+	mcpLocal        = "mcp-local"
+	mcpServeStdio   = "mcp-serve-stdio"
+	mcpDoctorAgent  = "mcp-doctor-agent"
+	mcpDoctorFormat = "mcp-doctor-format"
 )
 
 var flagsMap = map[string]cli.Flag{
@@ -848,7 +900,81 @@ var flagsMap = map[string]cli.Flag{
 	},
 	apiTimeout: cli.IntFlag{
 		Name:  "timeout",
-		Usage: "[Default: 0] Overall HTTP request timeout in seconds. 0 means no timeout.` `",
+		Usage: "[Default: 0] Overall HTTP request timeout in seconds. 0 means no timeout. With --paginate, the timeout covers fetching all pages.` `",
+	},
+	apiPaginate: cli.BoolFlag{
+		Name:  "paginate",
+		Usage: "[Default: false] Fetch all pages of a list endpoint. The next page is taken from the Link rel=\"next\" response header or, when there is none, by advancing the offset/limit or page_num query parameters of the request. Pages are merged into a single JSON result.` `",
+	},
+	apiNdjson: cli.BoolFlag{
+		Name:  "ndjson",
+		Usage: "[Default: false] Used with --paginate. Print each page as a single compact JSON line instead of merging the pages.` `",
+	},
+	apiMaxPages: cli.IntFlag{
+		Name:  "max-pages",
+		Usage: "[Default: 1000] Used with --paginate. Maximum number of pages to fetch. The command fails when there are more pages.` `",
+	},
+	apiRetries: cli.IntFlag{
+		Name:  "retries",
+		Usage: "[Optional] Number of times to retry a request that fails with a network error or an HTTP 429, 502, 503 or 504 response. Only GET, HEAD, PUT, DELETE and OPTIONS requests are retried, unless --retry-all-methods is set.` `",
+	},
+	apiRetryWait: cli.StringFlag{
+		Name:  "retry-wait",
+		Usage: "[Default: 1s] Wait before the first retry, doubled for each further retry (up to 5 minutes). A Retry-After response header takes precedence. The value should end with s for seconds or ms for milliseconds (for example: 10s or 100ms).` `",
+	},
+	apiRetryAllMethods: cli.BoolFlag{
+		Name:  "retry-all-methods",
+		Usage: "[Default: false] Used with --retries. Also retry POST and PATCH requests, which may not be safe to repeat.` `",
+	},
+	apiQuery: cli.StringFlag{
+		Name:  "query",
+		Usage: "[Optional] JSONPath expression (or its jq-style equivalent) to select from a successful JSON response before printing it, for example '$.tokens[*].token_id' or '.tokens[].token_id'. Error responses are printed unfiltered.` `",
+	},
+	apiRaw: cli.BoolFlag{
+		Name:  "raw",
+		Usage: "[Default: false] Print each selected value on its own line, with strings unquoted, instead of as JSON.` `",
+	},
+	apiValidate: cli.BoolFlag{
+		Name:  "validate",
+		Usage: "[Default: false] Before sending, check the request against the OpenAPI operations embedded in this binary (path parameters, required query parameters, and the required properties and types of a JSON body) and fail locally with a report of every mismatch.` `",
+	},
+	apiPrintCurl: cli.BoolFlag{
+		Name:  "print-curl",
+		Usage: "[Default: false] Print the request as a curl command instead of sending it. Same as --export=curl.` `",
+	},
+	apiExport: cli.StringFlag{
+		Name:  "export",
+		Usage: "[Optional] Print the request as a snippet instead of sending it. Accepted values: curl, httpie, go. Credentials are replaced by the $JF_ACCESS_TOKEN (or $JF_USER and $JF_PASSWORD) environment variables, and credential headers such as X-JFrog-Art-Api by $JF_<HEADER>.` `",
+	},
+	apiRecord: cli.StringFlag{
+		Name:  "record",
+		Usage: "[Optional] Append every request sent and response received to this HAR file, created if needed. Credentials, including credential headers such as Cookie or X-JFrog-Art-Api, Set-Cookie values and the password, token and secret fields of query strings and bodies are redacted.` `",
+	},
+	apiReplay: cli.StringFlag{
+		Name:  "replay",
+		Usage: "[Optional] Serve responses from this HAR file, as written by --record, instead of sending requests. Requests are matched by method, path and query string.` `",
+	},
+	apiOp: cli.StringFlag{
+		Name:  "op",
+		Usage: "[Optional] Call the catalog operation with this operationId, as printed by 'jf api docs search', instead of an endpoint path. The method and path come from the OpenAPI spec, filled in from --param.` `",
+	},
+	apiParam: cli.StringSliceFlag{
+		Name:  "param",
+		Usage: "[Optional] A path or query parameter of the --op operation, in name=value format. May be repeated. A missing required parameter fails before anything is sent.` `",
+		Value: &cli.StringSlice{},
+	},
+	apiForm: cli.StringSliceFlag{
+		Name:  "form, F",
+		Usage: "[Optional] Send a multipart/form-data body field in key=value format, or key=@path to upload a file. May be repeated. Files are streamed from disk. Mutually exclusive with --input and --data.` `",
+		Value: &cli.StringSlice{},
+	},
+	apiOutput: cli.StringFlag{
+		Name:  "output, o",
+		Usage: "[Optional] Stream a successful response body to this file (use \"-\" for standard output) instead of printing it, with a progress bar. --input is then streamed from disk too.` `",
+	},
+	apiBatchFailFast: cli.BoolFlag{
+		Name:  "fail-fast",
+		Usage: "[Default: false] Stop at the first request that fails or returns a non-2xx status: requests that haven't started are skipped.` `",
 	},
 	apiDocsSearchTag: cli.StringFlag{
 		Name:  "tag",
@@ -863,6 +989,10 @@ var flagsMap = map[string]cli.Flag{
 		Value: 10,
 		Usage: "[Default: 10] Maximum number of ranked matches to return.` `",
 	},
+	apiDocsSearchExplain: cli.BoolFlag{
+		Name:  "explain",
+		Usage: "[Default: false] Include, for each match, the query terms and fields its score is made of.` `",
+	},
 	apiDocsSearchFormat: cli.StringFlag{
 		Name:  Format,
 		Usage: "[Optional] " + components.GetFormatFlagDescription([]format.OutputFormat{format.Json, format.Table}) + "` `",
@@ -871,21 +1001,77 @@ var flagsMap = map[string]cli.Flag{
 		Name:  Format,
 		Usage: "[Optional] " + components.GetFormatFlagDescription([]format.OutputFormat{format.Json, format.Table}) + "` `",
 	},
+	apiDocsTagsFormat: cli.StringFlag{
+		Name:  Format,
+		Usage: "[Optional] " + components.GetFormatFlagDescription([]format.OutputFormat{format.Json, format.Table}) + "` `",
+	},
+	apiDocsListTag: cli.StringFlag{
+		Name:  "tag",
+		Usage: "[Optional] List only the operations with this tag, as printed by 'jf api docs tags' (case-insensitive).` `",
+	},
+	apiDocsListFormat: cli.StringFlag{
+		Name:  Format,
+		Usage: "[Optional] " + components.GetFormatFlagDescription([]format.OutputFormat{format.Json, format.Table}) + "` `",
+	},
+	apiDocsValidateInput: cli.StringFlag{
+		Name:  "input",
+		Usage: "[Optional] File holding the JSON request body to validate (use \"-\" to read from standard input). Mutually exclusive with --data.` `",
+	},
+	apiDocsValidateData: cli.StringFlag{
+		Name:  "data, d",
+		Usage: "[Optional] JSON request body to validate, as a literal string. Mutually exclusive with --input.` `",
+	},
+	apiDocsValidateFormat: cli.StringFlag{
+		Name:  Format,
+		Usage: "[Optional] " + components.GetFormatFlagDescription([]format.OutputFormat{format.Json, format.Table}) + "` `",
+	},
+	apiDocsDiffFormat: cli.StringFlag{
+		Name:  Format,
+		Usage: "[Optional] " + components.GetFormatFlagDescription([]format.OutputFormat{format.Json, format.Table}) + "` `",
+	},
+	apiDocsSyncSpecPath: cli.StringFlag{
+		Name:  "spec-path",
+		Usage: "[Mandatory] Path of the OpenAPI document (a single YAML or JSON file) served by the platform, relative to the platform URL.` `",
+	},
 	mcpShowFormat: cli.StringFlag{
 		Name:  Format,
 		Usage: "[Optional] " + components.GetFormatFlagDescription([]format.OutputFormat{format.Table, format.Json}) + "` `",
 	},
+	mcpLocal: cli.BoolFlag{
+		Name:  "local",
+		Usage: "[Default: false] Register the local MCP server ('jf mcp serve --stdio'), launched by the agent with this jf executable and your JFrog CLI server configuration, instead of the remote one.` `",
+	},
+	mcpServeStdio: cli.BoolFlag{
+		Name:  "stdio",
+		Usage: "[Mandatory] Serve over stdin and stdout, the MCP stdio transport.` `",
+	},
+	mcpListFormat: cli.StringFlag{
+		Name:  Format,
+		Usage: "[Optional] " + components.GetFormatFlagDescription([]format.OutputFormat{format.Table, format.Json}) + "` `",
+	},
+	mcpDoctorFormat: cli.StringFlag{
+		Name:  Format,
+		Usage: "[Optional] " + components.GetFormatFlagDescription([]format.OutputFormat{format.Table, format.Json}) + "` `",
+	},
+	mcpDoctorAgent: cli.StringFlag{
+		Name:  "agent",
+		Usage: "[Optional] Only check this AI agent's configuration, and fail if it has no JFrog MCP server entry. One of: cursor, claude, vscode, windsurf, gemini, codex, zed, jetbrains.` `",
+	},
+	mcpShowAgent: cli.StringFlag{
+		Name:  "agent",
+		Usage: "[Optional] AI agent whose MCP configuration to show: where the entry is written, under which key and in which format. One of: cursor, claude, vscode, windsurf, gemini, codex, zed, jetbrains.` `",
+	},
 	mcpUrl: cli.StringFlag{
 		Name:  "mcp-url",
 		Usage: "[Optional] Remote MCP server endpoint. Overrides the value derived from the platform URL (<platform-url>/mcp) and the " + JfrogCliMcpUrl + " environment variable.` `",
 	},
 	mcpAgent: cli.StringFlag{
 		Name:  "agent",
-		Usage: "[Optional] Target AI agent to configure: 'cursor' or 'claude'.` `",
+		Usage: "[Optional] Target AI agent to configure. One of: cursor, claude, vscode, windsurf, gemini, codex, zed, jetbrains.` `",
 	},
 	mcpGlobal: cli.BoolFlag{
 		Name:  "global",
-		Usage: "[Default: false] Configure the MCP server in the agent's global (user-level) configuration instead of the current project.` `",
+		Usage: "[Default: false] Use the agent's global (user-level) configuration instead of the current project's.` `",
 	},
 	mcpProjectDir: cli.StringFlag{
 		Name:  "project-dir",
@@ -2359,29 +2545,66 @@ var commandFlags = map[string][]string{
 	Api: {
 		platformUrl, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, InsecureTls, configDisableRefreshAccessToken,
-		apiHeader, apiInput, apiData, apiMethod, apiVerbose, apiTimeout,
+		apiHeader, apiInput, apiData, apiMethod, apiVerbose, apiTimeout, apiPaginate, apiNdjson, apiMaxPages,
+		apiRetries, apiRetryWait, apiRetryAllMethods, apiQuery, apiRaw, apiValidate,
+		apiPrintCurl, apiExport, apiRecord, apiReplay, apiForm, apiOutput, apiOp, apiParam,
+	},
+	ApiBatch: {
+		platformUrl, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, InsecureTls, configDisableRefreshAccessToken,
+		threads, apiBatchFailFast,
 	},
 	ApiDocsSearch: {
-		apiDocsSearchTag, apiDocsSearchMethod, apiDocsSearchLimit, apiDocsSearchFormat,
+		apiDocsSearchTag, apiDocsSearchMethod, apiDocsSearchLimit, apiDocsSearchExplain, apiDocsSearchFormat,
 	},
 	ApiDocsDescribe: {
 		apiDocsDescribeFormat,
 	},
+	ApiDocsValidate: {
+		apiDocsValidateInput, apiDocsValidateData, apiDocsValidateFormat,
+	},
+	ApiDocsTags: {
+		apiDocsTagsFormat,
+	},
+	ApiDocsList: {
+		apiDocsListTag, apiDocsListFormat,
+	},
+	ApiDocsDiff: {
+		apiDocsDiffFormat,
+	},
+	ApiDocsSync: {
+		platformUrl, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, InsecureTls, configDisableRefreshAccessToken,
+		apiTimeout, apiDocsSyncSpecPath,
+	},
 	McpShow: {
 		platformUrl, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, InsecureTls, configDisableRefreshAccessToken,
-		mcpUrl, mcpShowFormat,
+		mcpUrl, mcpShowFormat, mcpShowAgent, mcpGlobal, mcpProjectDir,
 	},
 	McpInstall: {
 		platformUrl, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, InsecureTls, configDisableRefreshAccessToken,
-		mcpUrl, mcpAgent, mcpGlobal, mcpProjectDir, mcpName, mcpDryRun, mcpSkipCheck,
+		mcpUrl, mcpAgent, mcpGlobal, mcpProjectDir, mcpName, mcpDryRun, mcpSkipCheck, mcpLocal,
 	},
 	McpUninstall: {
 		platformUrl, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, InsecureTls, configDisableRefreshAccessToken,
 		mcpAgent, mcpGlobal, mcpProjectDir, mcpName, mcpDryRun,
 	},
+	McpList: {
+		platformUrl, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, InsecureTls, configDisableRefreshAccessToken,
+		mcpUrl, mcpProjectDir, mcpListFormat,
+	},
+	McpServe: {
+		serverId, mcpServeStdio,
+	},
+	McpDoctor: {
+		platformUrl, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
+		ClientCertKeyPath, InsecureTls, configDisableRefreshAccessToken,
+		mcpUrl, mcpDoctorAgent, mcpProjectDir, mcpName, mcpDoctorFormat,
+	},
 	TemplateConsumer: {
 		url, user, password, accessToken, sshPassphrase, sshKeyPath, serverId, ClientCertPath,
 		ClientCertKeyPath, vars,